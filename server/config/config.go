@@ -5,6 +5,8 @@ func InitConfiguration() {
 	InitRedis()
 	InitMailer()
 	InitDatabase()
-	InitCloudinary()
+	if !UsesLocalStorage() {
+		InitCloudinary()
+	}
 	InitGoogleOAuthConfig()
 }
@@ -40,16 +40,49 @@ func InitDatabase() {
 		panic("Failed to connect to database: " + err.Error())
 	}
 
+	// users that existed before email verification was introduced are trusted
+	backfillEmailVerification := DB.Migrator().HasTable(&models.User{}) &&
+		!DB.Migrator().HasColumn(&models.User{}, "EmailVerifiedAt")
+
 	// run migrations
 	if err := DB.AutoMigrate(
 		&models.User{},
 		&models.Asset{},
 		&models.Location{},
 		&models.Category{},
+		&models.AssetMovement{},
+		&models.AssetLoan{},
+		&models.NotificationPreference{},
+		&models.WarrantyReminder{},
+		&models.MaintenancePlan{},
+		&models.MaintenanceRecord{},
+		&models.MaintenanceAttachment{},
+		&models.AssetAttachment{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.AuditLog{},
+		&models.Session{},
+		&models.TwoFactorRecoveryCode{},
+		&models.PersonalAccessToken{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.StockTake{},
+		&models.StockTakeLocation{},
+		&models.StockTakeItem{},
+		&models.CustomField{},
+		&models.AssetCustomFieldValue{},
 	); err != nil {
 		panic("Migration failed: " + err.Error())
 	}
 
+	if backfillEmailVerification {
+		if err := DB.Model(&models.User{}).
+			Where("email_verified_at IS NULL").
+			Update("email_verified_at", gorm.Expr("created_at")).Error; err != nil {
+			panic("Failed to backfill email verification: " + err.Error())
+		}
+	}
+
 	sqlDB, err := DB.DB()
 	if err != nil {
 		panic("Failed to get database connection: " + err.Error())
@@ -48,6 +48,23 @@ type Config struct {
 	AppEnv      string
 	FrontendURL string
 
+	// admin settings
+	AdminEmails []string
+
+	// RequireEmailVerification blocks unverified accounts from writing assets
+	RequireEmailVerification bool
+
+	// WebhookAllowPrivateNetworks lets webhooks target loopback and private addresses, for local setups only
+	WebhookAllowPrivateNetworks bool
+
+	// scheduler settings
+	WarrantyReminderHour int
+
+	// storage settings
+	StorageDriver    string
+	LocalStoragePath string
+	LocalStorageURL  string
+
 	// cloudinary settings
 	CloudName   string
 	CloudSecret string
@@ -72,7 +89,7 @@ func LoadConfig() {
 		// google oauth
 		GoogleClientID:      getEnvOrDefault("GOOGLE_CLIENT_ID", "your-google-client-id"),
 		GoogleClientSecret:  getEnvOrDefault("GOOGLE_CLIENT_SECRET", "your-google-client-secret"),
-		GoogleRedirectURL:   getEnvOrDefault("GOOGLE_REDIRECT_URL", "http://localhost:5005/api/v1/users/google/callback"),
+		GoogleRedirectURL:   getEnvOrDefault("GOOGLE_REDIRECT_URL", "http://localhost:5005/api/v1/auth/google/callback"),
 		FrontendRedirectURL: getEnvOrDefault("FRONTEND_REDIRECT_URL", "http://localhost:5173"),
 
 		// Security
@@ -109,6 +126,23 @@ func LoadConfig() {
 		AppEnv:      getEnvOrDefault("APP_ENV", "development"),
 		FrontendURL: getEnvOrDefault("FRONTEND_URL", "http://localhost:5173"),
 
+		// Admin
+		AdminEmails: getEnvAsStringSlice("ADMIN_EMAILS", []string{}),
+
+		// Verification
+		RequireEmailVerification: getEnvAsBool("REQUIRE_EMAIL_VERIFICATION", false),
+
+		// Webhooks
+		WebhookAllowPrivateNetworks: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+
+		// Scheduler
+		WarrantyReminderHour: getEnvAsInt("WARRANTY_REMINDER_HOUR", 8),
+
+		// Storage
+		StorageDriver:    getEnvOrDefault("STORAGE_DRIVER", "cloudinary"),
+		LocalStoragePath: getEnvOrDefault("LOCAL_STORAGE_PATH", "./uploads"),
+		LocalStorageURL:  getEnvOrDefault("LOCAL_STORAGE_URL", "/api/v1/files"),
+
 		// Cloudinary
 		CloudName:   getEnvOrDefault("CLOUDINARY_CLOUD_NAME", "your-cloudinary-cloud-name"),
 		CloudSecret: getEnvOrDefault("CLOUDINARY_API_SECRET", "your-cloudinary-api-secret"),
@@ -146,6 +180,15 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
 	value := os.Getenv(key)
 	if value == "" {
@@ -184,6 +227,10 @@ func IsProduction() bool {
 	return AppConfig.AppEnv == "production"
 }
 
+func UsesLocalStorage() bool {
+	return AppConfig.StorageDriver == "local"
+}
+
 func IsDevelopment() bool {
 	return AppConfig.AppEnv == "development"
 }
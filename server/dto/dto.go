@@ -1,21 +1,85 @@
 package dto
 
 import (
+	"encoding/json"
 	"mime/multipart"
 	"time"
 )
 
 type UserSession struct {
-	ID       string `json:"id"`
-	Fullname string `json:"fullname"`
-	Email    string `json:"email"`
-	Avatar   string `json:"avatar"`
+	ID                   string `json:"id"`
+	Fullname             string `json:"fullname"`
+	Email                string `json:"email"`
+	Avatar               string `json:"avatar"`
+	ActiveOrganizationID string `json:"activeOrganizationId"`
+	Role                 string `json:"role"`
+	EmailVerified        bool   `json:"emailVerified"`
+	ImpersonatorID       string `json:"impersonatorId,omitempty"`
 }
 
+// AuthResponse carries either the new session or, when two-factor authentication is on, the Challenge to complete
 type AuthResponse struct {
-	User         UserSession `json:"user"`
-	AccessToken  string      `json:"accessToken"`
-	RefreshToken string      `json:"refreshToken"`
+	User         UserSession                 `json:"user"`
+	AccessToken  string                      `json:"accessToken"`
+	RefreshToken string                      `json:"refreshToken"`
+	Challenge    *TwoFactorChallengeResponse `json:"challenge,omitempty"`
+}
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string    `json:"challengeToken"`
+	Method         string    `json:"method"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type ResendTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	Method            string     `json:"method"`
+	EnabledAt         *time.Time `json:"enabledAt"`
+	RecoveryCodesLeft int        `json:"recoveryCodesLeft"`
+}
+
+type SetupTwoFactorRequest struct {
+	Method string `json:"method" binding:"required,oneof=totp email"`
+}
+
+type SetupTwoFactorResponse struct {
+	Method          string    `json:"method"`
+	Secret          string    `json:"secret,omitempty"`
+	ProvisioningURI string    `json:"provisioningUri,omitempty"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactorRequest re-authenticates the user, Password is required unless the account has none
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Current    bool      `json:"current"`
 }
 
 type LoginRequest struct {
@@ -30,11 +94,25 @@ type RegisterRequest struct {
 }
 
 type UserProfileResponse struct {
-	ID       string    `json:"id" binding:"required"`
-	Fullname string    `json:"fullname" binding:"required"`
-	Email    string    `json:"email" binding:"required,email"`
-	Avatar   string    `json:"avatar"`
-	JoinedAt time.Time `json:"joinedAt"`
+	ID            string    `json:"id" binding:"required"`
+	Fullname      string    `json:"fullname" binding:"required"`
+	Email         string    `json:"email" binding:"required,email"`
+	Avatar        string    `json:"avatar"`
+	HasPassword   bool      `json:"hasPassword"`
+	GoogleLinked  bool      `json:"googleLinked"`
+	EmailVerified bool      `json:"emailVerified"`
+	TwoFactor     bool      `json:"twoFactor"`
+	JoinedAt      time.Time `json:"joinedAt"`
+}
+
+type GoogleSignInRequest struct {
+	IDToken string `json:"idToken" binding:"required"`
+}
+
+// GoogleOAuthCallbackResponse holds the new session for a sign in, or Linked for an account link
+type GoogleOAuthCallbackResponse struct {
+	Auth   *AuthResponse
+	Linked bool
 }
 
 type UpdateUserRequest struct {
@@ -56,6 +134,50 @@ type ChangePasswordRequest struct {
 	ConfirmPassword string `json:"confirmPassword" binding:"required,min=6"`
 }
 
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=read-only assets:read assets:write assets:delete catalog:write reports:read audit:read stock-takes:count"`
+	ExpiresInDays *int     `json:"expiresInDays" binding:"omitempty,min=1,max=365"`
+}
+
+type AccessTokenResponse struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Prefix           string     `json:"prefix"`
+	Scopes           []string   `json:"scopes"`
+	OrganizationID   string     `json:"organizationId"`
+	OrganizationName string     `json:"organizationName"`
+	ExpiresAt        *time.Time `json:"expiresAt"`
+	LastUsedAt       *time.Time `json:"lastUsedAt"`
+	LastUsedIP       string     `json:"lastUsedIp"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	Expired          bool       `json:"expired"`
+}
+
+// CreatedAccessTokenResponse is the only time the plain token is returned
+type CreatedAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
+
+// AccessTokenIdentity is who a personal access token authenticates as
+type AccessTokenIdentity struct {
+	TokenID        string
+	UserID         string
+	OrganizationID string
+	Role           string
+	EmailVerified  bool
+	Scopes         []string
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
@@ -86,10 +208,14 @@ type CategoryResponse struct {
 	IsDefault bool               `json:"isDefault"`
 	IsCustom  bool               `json:"isCustom"`
 	IsParent  bool               `json:"isParent"`
-	Level     int                `json:"level"` // 0 for parent, 1 for child
+	Level     int                `json:"level"` // depth in the tree, 0 for a top level category
 	CreatedAt time.Time          `json:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt"`
 	Children  []CategoryResponse `json:"children,omitempty"`
+
+	DepreciationMethod *string  `json:"depreciationMethod,omitempty"`
+	UsefulLifeYears    *int     `json:"usefulLifeYears,omitempty"`
+	SalvagePercent     *float64 `json:"salvagePercent,omitempty"`
 }
 
 type CategoriesTreeResponse struct {
@@ -103,7 +229,7 @@ type CategoryFlatResponse struct {
 	ID        string    `json:"id"`
 	ParentID  *string   `json:"parentId"`
 	Name      string    `json:"name"`
-	FullName  string    `json:"fullname"` // "Technology > Electronics"
+	FullName  string    `json:"fullname"` // "Technology > Electronics > Laptops"
 	IsDefault bool      `json:"isDefault"`
 	IsCustom  bool      `json:"isCustom"`
 	IsParent  bool      `json:"isParent"`
@@ -125,6 +251,7 @@ type CategoryWithAssetsResponse struct {
 
 type CategoryAssetResponse struct {
 	ID           string    `json:"id"`
+	CategoryID   string    `json:"categoryId"`
 	Name         string    `json:"name"`
 	Description  string    `json:"description"`
 	Price        float64   `json:"price"`
@@ -137,21 +264,32 @@ type CategoryAssetResponse struct {
 type CreateCategoryRequest struct {
 	Name     string  `json:"name" binding:"required,min=2,max=100"`
 	ParentID *string `json:"parentId" binding:"omitempty"`
+
+	DepreciationMethod *string  `json:"depreciationMethod" binding:"omitempty,oneof=straight-line declining-balance sum-of-years-digits"`
+	UsefulLifeYears    *int     `json:"usefulLifeYears" binding:"omitempty,min=1,max=100"`
+	SalvagePercent     *float64 `json:"salvagePercent" binding:"omitempty,min=0,max=100"`
 }
 
 type UpdateCategoryRequest struct {
 	Name     string  `json:"name" binding:"required,min=2,max=100"`
 	ParentID *string `json:"parentId" binding:"omitempty"`
+
+	DepreciationMethod *string  `json:"depreciationMethod" binding:"omitempty,oneof=straight-line declining-balance sum-of-years-digits"`
+	UsefulLifeYears    *int     `json:"usefulLifeYears" binding:"omitempty,min=1,max=100"`
+	SalvagePercent     *float64 `json:"salvagePercent" binding:"omitempty,min=0,max=100"`
 }
 
 // location DTOs
 type LocationResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	IsDefault bool      `json:"isDefault"`
-	IsCustom  bool      `json:"isCustom"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        string             `json:"id"`
+	ParentID  *string            `json:"parentId,omitempty"`
+	Name      string             `json:"name"`
+	Path      string             `json:"path,omitempty"` // "Head Office > Floor 2 > Room 201"
+	IsDefault bool               `json:"isDefault"`
+	IsCustom  bool               `json:"isCustom"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Children  []LocationResponse `json:"children,omitempty"`
 }
 
 type LocationsResponse struct {
@@ -159,6 +297,12 @@ type LocationsResponse struct {
 	Total     int                `json:"total"`
 }
 
+type LocationsTreeResponse struct {
+	Locations []LocationResponse `json:"locations"`
+	Total     int                `json:"total"`
+	Roots     int                `json:"roots"`
+}
+
 type LocationAssetResponse struct {
 	ID           string    `json:"id"`
 	Name         string    `json:"name"`
@@ -176,11 +320,13 @@ type LocationWithAssetsResponse struct {
 }
 
 type CreateLocationRequest struct {
-	Name string `json:"name" binding:"required,min=2,max=100"`
+	Name     string  `json:"name" binding:"required,min=2,max=100"`
+	ParentID *string `json:"parentId" binding:"omitempty"`
 }
 
 type UpdateLocationRequest struct {
-	Name string `json:"name" binding:"required,min=2,max=100"`
+	Name     string  `json:"name" binding:"required,min=2,max=100"`
+	ParentID *string `json:"parentId" binding:"omitempty"`
 }
 
 // asset DTOs
@@ -196,6 +342,9 @@ type CreateAssetRequest struct {
 	SerialNumber string                `form:"serialNumber" json:"serialNumber" binding:"max=100"`
 	Warranty     *time.Time            `form:"warranty" json:"warranty" time_format:"2006-01-02"`
 	ImageURL     string                `json:"-"`
+
+	// CustomFields maps field keys to values, multipart forms send it as a JSON object string
+	CustomFields map[string]any `form:"customFields" json:"customFields"`
 }
 
 type UpdateAssetRequest struct {
@@ -210,37 +359,693 @@ type UpdateAssetRequest struct {
 	SerialNumber string                `form:"serialNumber" json:"serialNumber" binding:"max=100"`
 	Warranty     *time.Time            `form:"warranty" json:"warranty" time_format:"2006-01-02"`
 	ImageURL     string                `json:"-"`
+
+	// CustomFields only changes the given keys, a null value clears the field
+	CustomFields map[string]any `form:"customFields" json:"customFields"`
 }
 
 type GetAssetsRequest struct {
-	Page       int      `form:"page" json:"page" binding:"omitempty,min=1"`
-	Limit      int      `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
-	Search     string   `form:"search" json:"search" binding:"omitempty,max=100"`
-	CategoryID string   `form:"categoryId" json:"categoryId" binding:"omitempty,uuid"`
-	LocationID string   `form:"locationId" json:"locationId" binding:"omitempty,uuid"`
-	Condition  string   `form:"condition" json:"condition" binding:"omitempty,oneof=new good fair poor"`
-	MinPrice   *float64 `form:"minPrice" json:"minPrice" binding:"omitempty,min=0"`
-	MaxPrice   *float64 `form:"maxPrice" json:"maxPrice" binding:"omitempty,min=0"`
-	SortBy     string   `form:"sortBy" json:"sortBy" binding:"omitempty,oneof=name price createdAt purchaseDate"`
-	SortOrder  string   `form:"sortOrder" json:"sortOrder" binding:"omitempty,oneof=asc desc"`
+	Page         int      `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit        int      `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Search       string   `form:"search" json:"search" binding:"omitempty,max=100"`
+	CategoryID   string   `form:"categoryId" json:"categoryId" binding:"omitempty,uuid"`
+	LocationID   string   `form:"locationId" json:"locationId" binding:"omitempty,uuid"`
+	Condition    string   `form:"condition" json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	MinPrice     *float64 `form:"minPrice" json:"minPrice" binding:"omitempty,min=0"`
+	MaxPrice     *float64 `form:"maxPrice" json:"maxPrice" binding:"omitempty,min=0"`
+	Availability string   `form:"availability" json:"availability" binding:"omitempty,oneof=available checked-out overdue"`
+	SortBy       string   `form:"sortBy" json:"sortBy" binding:"omitempty,oneof=name price createdAt purchaseDate customField"`
+	SortOrder    string   `form:"sortOrder" json:"sortOrder" binding:"omitempty,oneof=asc desc"`
+
+	// IncludeSubLocations also matches assets in every location below LocationID
+	IncludeSubLocations bool `form:"includeSubLocations" json:"includeSubLocations"`
+
+	// SortField is the custom field key used with sortBy=customField
+	SortField string `form:"sortField" json:"sortField" binding:"required_if=SortBy customField,omitempty,max=50"`
+
+	// custom field filters are read from cf[key]=value, cfMin[key] and cfMax[key]
+	CustomFields    map[string]string `form:"-" json:"customFields"`
+	CustomFieldsMin map[string]string `form:"-" json:"customFieldsMin"`
+	CustomFieldsMax map[string]string `form:"-" json:"customFieldsMax"`
 }
 
 // Response DTOs
 type AssetResponse struct {
+	ID             string               `json:"id"`
+	Tag            string               `json:"tag"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description"`
+	LocationID     string               `json:"locationId"`
+	CategoryID     string               `json:"categoryId"`
+	UserID         string               `json:"userId"`
+	OrganizationID string               `json:"organizationId"`
+	Image          string               `json:"image"`
+	PurchaseDate   *time.Time           `json:"purchaseDate"`
+	Price          float64              `json:"price"`
+	Condition      string               `json:"condition"`
+	SerialNumber   string               `json:"serialNumber"`
+	Warranty       *time.Time           `json:"warranty"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	UpdatedAt      time.Time            `json:"updatedAt"`
+	Location       *LocationResponse    `json:"location,omitempty"`
+	Category       *CategoryResponse    `json:"category,omitempty"`
+	CurrentLoan    *LoanResponse        `json:"currentLoan"`
+	Attachments    []AttachmentResponse `json:"attachments,omitempty"`
+
+	BookValue               float64 `json:"bookValue"`
+	AccumulatedDepreciation float64 `json:"accumulatedDepreciation"`
+	DepreciationMethod      string  `json:"depreciationMethod"`
+
+	CustomFields map[string]any `json:"customFields"`
+}
+
+// asset movement DTOs
+type MoveAssetRequest struct {
+	LocationID string `json:"locationId" binding:"required,uuid"`
+	Note       string `json:"note" binding:"max=255"`
+}
+
+type GetAssetHistoryRequest struct {
+	From *time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" json:"to" time_format:"2006-01-02"`
+}
+
+type ActorResponse struct {
+	ID       string `json:"id"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+}
+
+type AssetMovementResponse struct {
 	ID           string            `json:"id"`
-	Name         string            `json:"name"`
-	Description  string            `json:"description"`
-	LocationID   string            `json:"locationId"`
-	CategoryID   string            `json:"categoryId"`
-	UserID       string            `json:"userId"`
-	Image        string            `json:"image"`
-	PurchaseDate *time.Time        `json:"purchaseDate"`
-	Price        float64           `json:"price"`
-	Condition    string            `json:"condition"`
-	SerialNumber string            `json:"serialNumber"`
-	Warranty     *time.Time        `json:"warranty"`
-	CreatedAt    time.Time         `json:"createdAt"`
-	UpdatedAt    time.Time         `json:"updatedAt"`
-	Location     *LocationResponse `json:"location,omitempty"`
-	Category     *CategoryResponse `json:"category,omitempty"`
+	AssetID      string            `json:"assetId"`
+	FromLocation *LocationResponse `json:"fromLocation"`
+	ToLocation   LocationResponse  `json:"toLocation"`
+	MovedBy      ActorResponse     `json:"movedBy"`
+	Note         string            `json:"note"`
+	MovedAt      time.Time         `json:"movedAt"`
+}
+
+type AssetHistoryResponse struct {
+	AssetID   string                  `json:"assetId"`
+	Movements []AssetMovementResponse `json:"movements"`
+	Total     int                     `json:"total"`
+}
+
+// loan DTOs
+type CheckOutAssetRequest struct {
+	BorrowerUserID string `json:"borrowerUserId" binding:"omitempty,uuid"`
+	BorrowerName   string `json:"borrowerName" binding:"omitempty,max=100"`
+	DueDate        string `json:"dueDate" binding:"required,datetime=2006-01-02"`
+	Condition      string `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Note           string `json:"note" binding:"max=255"`
+}
+
+type CheckInAssetRequest struct {
+	Condition string `json:"condition" binding:"required,oneof=new good fair poor"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+type GetLoansRequest struct {
+	Page   int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=active overdue returned"`
+}
+
+type LoanAssetResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	SerialNumber string `json:"serialNumber"`
+}
+
+type LoanResponse struct {
+	ID             string             `json:"id"`
+	AssetID        string             `json:"assetId"`
+	Asset          *LoanAssetResponse `json:"asset,omitempty"`
+	BorrowerUserID *string            `json:"borrowerUserId"`
+	BorrowerName   string             `json:"borrowerName"`
+	BorrowerEmail  string             `json:"borrowerEmail,omitempty"`
+	CheckedOutAt   time.Time          `json:"checkedOutAt"`
+	DueAt          time.Time          `json:"dueAt"`
+	ReturnedAt     *time.Time         `json:"returnedAt"`
+	ConditionOut   string             `json:"conditionOut"`
+	ConditionIn    string             `json:"conditionIn"`
+	NoteOut        string             `json:"noteOut"`
+	NoteIn         string             `json:"noteIn"`
+	Status         string             `json:"status"` // active, overdue or returned
+}
+
+type AssetLoansResponse struct {
+	AssetID string         `json:"assetId"`
+	Loans   []LoanResponse `json:"loans"`
+	Total   int            `json:"total"`
+}
+
+// asset import DTOs
+type ImportAssetsRequest struct {
+	File   *multipart.FileHeader `form:"file" binding:"required"`
+	DryRun bool                  `form:"dryRun"`
+}
+
+type ImportRowError struct {
+	Row    int               `json:"row"`
+	Name   string            `json:"name"`
+	Errors map[string]string `json:"errors"`
+}
+
+type ImportAssetsResponse struct {
+	DryRun    bool             `json:"dryRun"`
+	TotalRows int              `json:"totalRows"`
+	ValidRows int              `json:"validRows"`
+	Imported  int              `json:"imported"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// asset export DTOs
+type ExportAssetsRequest struct {
+	GetAssetsRequest
+	Format string `form:"format" json:"format" binding:"omitempty,oneof=csv xlsx json"`
+}
+
+// label DTOs
+type GetAssetLabelRequest struct {
+	Format  string `form:"format" binding:"omitempty,oneof=png svg"`
+	Type    string `form:"type" binding:"omitempty,oneof=qr code128"`
+	Content string `form:"content" binding:"omitempty,oneof=link tag"`
+}
+
+// LabelSheetRequest prints the listed assets, or every asset matching the filters when AssetIDs is empty
+type LabelSheetRequest struct {
+	AssetIDs     []string `json:"assetIds" binding:"omitempty,max=500,dive,uuid"`
+	Search       string   `json:"search" binding:"omitempty,max=100"`
+	CategoryID   string   `json:"categoryId" binding:"omitempty,uuid"`
+	LocationID   string   `json:"locationId" binding:"omitempty,uuid"`
+	Condition    string   `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Availability string   `json:"availability" binding:"omitempty,oneof=available checked-out overdue"`
+	SortBy       string   `json:"sortBy" binding:"omitempty,oneof=name price createdAt purchaseDate"`
+	SortOrder    string   `json:"sortOrder" binding:"omitempty,oneof=asc desc"`
+	Layout       string   `json:"layout" binding:"omitempty,oneof=a4-3x8 letter-3x10"`
+	Type         string   `json:"type" binding:"omitempty,oneof=qr code128"`
+	Content      string   `json:"content" binding:"omitempty,oneof=link tag"`
+
+	// IncludeSubLocations also matches assets in every location below LocationID
+	IncludeSubLocations bool `json:"includeSubLocations"`
+}
+
+// depreciation report DTOs
+type DepreciationReportRequest struct {
+	AsOf string `form:"asOf" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type DepreciationYearResponse struct {
+	Year           int       `json:"year"`
+	StartDate      time.Time `json:"startDate"`
+	EndDate        time.Time `json:"endDate"`
+	StartBookValue float64   `json:"startBookValue"`
+	Depreciation   float64   `json:"depreciation"`
+	Accumulated    float64   `json:"accumulated"`
+	EndBookValue   float64   `json:"endBookValue"`
+}
+
+type AssetDepreciationResponse struct {
+	AssetID                 string                     `json:"assetId"`
+	Name                    string                     `json:"name"`
+	Category                string                     `json:"category"`
+	Method                  string                     `json:"method"`
+	UsefulLifeYears         int                        `json:"usefulLifeYears"`
+	SalvagePercent          float64                    `json:"salvagePercent"`
+	Cost                    float64                    `json:"cost"`
+	SalvageValue            float64                    `json:"salvageValue"`
+	PurchaseDate            *time.Time                 `json:"purchaseDate"`
+	AccumulatedDepreciation float64                    `json:"accumulatedDepreciation"`
+	BookValue               float64                    `json:"bookValue"`
+	Schedule                []DepreciationYearResponse `json:"schedule"`
+}
+
+type DepreciationReportResponse struct {
+	AsOf             string                      `json:"asOf"`
+	Assets           []AssetDepreciationResponse `json:"assets"`
+	Total            int                         `json:"total"`
+	TotalCost        float64                     `json:"totalCost"`
+	TotalAccumulated float64                     `json:"totalAccumulated"`
+	TotalBookValue   float64                     `json:"totalBookValue"`
+}
+
+// dashboard DTOs
+type DashboardBreakdownItem struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Count int64   `json:"count"`
+	Value float64 `json:"value"`
+}
+
+type DashboardMonthlyItem struct {
+	Month string  `json:"month"`
+	Count int64   `json:"count"`
+	Value float64 `json:"value"`
+}
+
+type DashboardAssetItem struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Category     string     `json:"category"`
+	Location     string     `json:"location"`
+	Price        float64    `json:"price"`
+	Condition    string     `json:"condition"`
+	PurchaseDate *time.Time `json:"purchaseDate"`
+	Warranty     *time.Time `json:"warranty"`
+	DaysLeft     *int       `json:"daysLeft,omitempty"`
+}
+
+type DashboardWarrantyResponse struct {
+	Within30 int                  `json:"within30"`
+	Within60 int                  `json:"within60"`
+	Within90 int                  `json:"within90"`
+	Assets   []DashboardAssetItem `json:"assets"`
+}
+
+type DashboardSummaryResponse struct {
+	TotalAssets        int64                     `json:"totalAssets"`
+	TotalValue         float64                   `json:"totalValue"`
+	ByCategory         []DashboardBreakdownItem  `json:"byCategory"`
+	ByLocation         []DashboardBreakdownItem  `json:"byLocation"`
+	ByCondition        []DashboardBreakdownItem  `json:"byCondition"`
+	MonthlyPurchases   []DashboardMonthlyItem    `json:"monthlyPurchases"`
+	TopAssets          []DashboardAssetItem      `json:"topAssets"`
+	ExpiringWarranties DashboardWarrantyResponse `json:"expiringWarranties"`
+	GeneratedAt        time.Time                 `json:"generatedAt"`
+}
+
+// notification DTOs
+type UpdateNotificationPreferenceRequest struct {
+	WarrantyReminders *bool `json:"warrantyReminders"`
+	WarrantyLeadDays  *int  `json:"warrantyLeadDays" binding:"omitempty,min=1,max=365"`
+}
+
+type NotificationPreferenceResponse struct {
+	WarrantyReminders bool `json:"warrantyReminders"`
+	WarrantyLeadDays  int  `json:"warrantyLeadDays"`
+}
+
+// maintenance DTOs
+type CreateMaintenancePlanRequest struct {
+	Title         string  `json:"title" binding:"required,min=1,max=100"`
+	IntervalDays  *int    `json:"intervalDays" binding:"omitempty,min=1,max=3650"`
+	NextDueDate   string  `json:"nextDueDate" binding:"required_without=IntervalDays,omitempty,datetime=2006-01-02"`
+	Vendor        string  `json:"vendor" binding:"max=100"`
+	EstimatedCost float64 `json:"estimatedCost" binding:"min=0"`
+}
+
+type CreateMaintenanceRecordRequest struct {
+	PlanID         string                  `form:"planId" binding:"omitempty,uuid"`
+	Title          string                  `form:"title" binding:"required_without=PlanID,max=100"`
+	ServicedDate   string                  `form:"servicedDate" binding:"omitempty,datetime=2006-01-02"`
+	Vendor         string                  `form:"vendor" binding:"max=100"`
+	Cost           float64                 `form:"cost" binding:"min=0"`
+	Notes          string                  `form:"notes" binding:"max=2000"`
+	Attachments    []*multipart.FileHeader `form:"attachments"`
+	AttachmentURLs []string                `json:"-"`
+}
+
+type GetUpcomingMaintenanceRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+type MaintenancePlanResponse struct {
+	ID             string             `json:"id"`
+	AssetID        string             `json:"assetId"`
+	Asset          *LoanAssetResponse `json:"asset,omitempty"`
+	Title          string             `json:"title"`
+	IntervalDays   *int               `json:"intervalDays"`
+	NextDueAt      time.Time          `json:"nextDueAt"`
+	Vendor         string             `json:"vendor"`
+	EstimatedCost  float64            `json:"estimatedCost"`
+	IsActive       bool               `json:"isActive"`
+	IsOverdue      bool               `json:"isOverdue"`
+	LastServicedAt *time.Time         `json:"lastServicedAt"`
+	CreatedAt      time.Time          `json:"createdAt"`
+}
+
+type MaintenanceAttachmentResponse struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	FileName string `json:"fileName"`
+}
+
+type MaintenanceRecordResponse struct {
+	ID          string                          `json:"id"`
+	AssetID     string                          `json:"assetId"`
+	PlanID      *string                         `json:"planId"`
+	Title       string                          `json:"title"`
+	ServicedAt  time.Time                       `json:"servicedAt"`
+	Vendor      string                          `json:"vendor"`
+	Cost        float64                         `json:"cost"`
+	Notes       string                          `json:"notes"`
+	LoggedBy    ActorResponse                   `json:"loggedBy"`
+	Attachments []MaintenanceAttachmentResponse `json:"attachments"`
+	CreatedAt   time.Time                       `json:"createdAt"`
+}
+
+type AssetMaintenanceResponse struct {
+	AssetID              string                      `json:"assetId"`
+	Plans                []MaintenancePlanResponse   `json:"plans"`
+	Records              []MaintenanceRecordResponse `json:"records"`
+	PurchasePrice        float64                     `json:"purchasePrice"`
+	MaintenanceCost      float64                     `json:"maintenanceCost"`
+	TotalCostOfOwnership float64                     `json:"totalCostOfOwnership"`
+}
+
+type UpcomingMaintenanceResponse struct {
+	Until string                    `json:"until"`
+	Plans []MaintenancePlanResponse `json:"plans"`
+	Total int                       `json:"total"`
+}
+
+// asset attachment DTOs
+type UploadAttachmentsRequest struct {
+	Files []*multipart.FileHeader `form:"files" binding:"required"`
+	Kind  string                  `form:"kind" binding:"omitempty,oneof=image receipt manual warranty other"`
+}
+
+type ReorderAttachmentsRequest struct {
+	AttachmentIDs []string `json:"attachmentIds" binding:"required,min=1,dive,uuid"`
+}
+
+type AttachmentResponse struct {
+	ID        string    `json:"id"`
+	AssetID   string    `json:"assetId"`
+	URL       string    `json:"url"`
+	FileName  string    `json:"fileName"`
+	MimeType  string    `json:"mimeType"`
+	Kind      string    `json:"kind"`
+	Size      int64     `json:"size"`
+	Position  int       `json:"position"`
+	IsPrimary bool      `json:"isPrimary"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type AssetAttachmentsResponse struct {
+	AssetID     string               `json:"assetId"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	Total       int                  `json:"total"`
+}
+
+// organization DTOs
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name           string `json:"name" binding:"required,min=2,max=100"`
+	AssetTagPrefix string `json:"assetTagPrefix" binding:"omitempty,alphanum,min=2,max=10"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner manager editor viewer auditor"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner manager editor viewer auditor"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID   string    `json:"userId"`
+	Fullname string    `json:"fullname"`
+	Email    string    `json:"email"`
+	Avatar   string    `json:"avatar"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type OrganizationResponse struct {
+	ID             string                       `json:"id"`
+	Name           string                       `json:"name"`
+	IsPersonal     bool                         `json:"isPersonal"`
+	OwnerID        string                       `json:"ownerId"`
+	AssetTagPrefix string                       `json:"assetTagPrefix"`
+	Role           string                       `json:"role"`
+	IsActive       bool                         `json:"isActive"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+	Members        []OrganizationMemberResponse `json:"members,omitempty"`
+}
+
+type OrganizationsResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Total         int                    `json:"total"`
+}
+
+type OrganizationMembersResponse struct {
+	OrganizationID string                       `json:"organizationId"`
+	Members        []OrganizationMemberResponse `json:"members"`
+	Total          int                          `json:"total"`
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// system admin DTOs
+type GetUsersRequest struct {
+	Page   int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Search string `form:"search" json:"search" binding:"omitempty,max=100"`
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=active deactivated"`
+}
+
+type AdminUserResponse struct {
+	ID            string     `json:"id"`
+	Fullname      string     `json:"fullname"`
+	Email         string     `json:"email"`
+	Avatar        string     `json:"avatar"`
+	IsAdmin       bool       `json:"isAdmin"`
+	IsDeactivated bool       `json:"isDeactivated"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+	JoinedAt      time.Time  `json:"joinedAt"`
+}
+
+// audit log DTOs
+type GetAuditLogsRequest struct {
+	Page     int        `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit    int        `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Entity   string     `form:"entity" json:"entity" binding:"omitempty,oneof=asset category location loan user"`
+	EntityID string     `form:"entityId" json:"entityId" binding:"omitempty,uuid"`
+	Actor    string     `form:"actor" json:"actor" binding:"omitempty,uuid"`
+	From     *time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" json:"to" time_format:"2006-01-02"`
+}
+
+type AuditLogResponse struct {
+	ID             string          `json:"id"`
+	OrganizationID *string         `json:"organizationId"`
+	Actor          *ActorResponse  `json:"actor"`
+	ImpersonatorID *string         `json:"impersonatorId,omitempty"`
+	Action         string          `json:"action"`
+	Entity         string          `json:"entity"`
+	EntityID       string          `json:"entityId"`
+	Before         json.RawMessage `json:"before"`
+	After          json.RawMessage `json:"after"`
+	IPAddress      string          `json:"ipAddress"`
+	UserAgent      string          `json:"userAgent"`
+	RequestID      string          `json:"requestId"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// webhook DTOs
+type CreateWebhookRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	URL    string   `json:"url" binding:"required,url,max=500"`
+	Secret string   `json:"secret" binding:"required,min=16,max=100"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=asset.created asset.updated asset.moved asset.condition_changed asset.deleted asset.checked_out category.created category.updated category.deleted location.created location.updated location.deleted"`
+}
+
+// UpdateWebhookRequest keeps the current secret when none is given
+type UpdateWebhookRequest struct {
+	Name     string   `json:"name" binding:"required,max=100"`
+	URL      string   `json:"url" binding:"required,url,max=500"`
+	Secret   string   `json:"secret" binding:"omitempty,min=16,max=100"`
+	Events   []string `json:"events" binding:"required,min=1,dive,oneof=asset.created asset.updated asset.moved asset.condition_changed asset.deleted asset.checked_out category.created category.updated category.deleted location.created location.updated location.deleted"`
+	IsActive *bool    `json:"isActive" binding:"required"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"isActive"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type GetWebhookDeliveriesRequest struct {
+	Page   int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=pending succeeded failed"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	EventID        string          `json:"eventId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt"`
+	ResponseStatus int             `json:"responseStatus"`
+	ResponseBody   string          `json:"responseBody"`
+	Error          string          `json:"error"`
+	DurationMs     int64           `json:"durationMs"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	RedeliveryOf   *string         `json:"redeliveryOf"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// WebhookEventPayload is the body posted to webhooks, ID is shared by redeliveries of the same event
+type WebhookEventPayload struct {
+	ID             string    `json:"id"`
+	Event          string    `json:"event"`
+	CreatedAt      time.Time `json:"createdAt"`
+	OrganizationID string    `json:"organizationId"`
+	Data           any       `json:"data"`
+}
+
+type AssetConditionChangedEvent struct {
+	AssetID           string `json:"assetId"`
+	Name              string `json:"name"`
+	PreviousCondition string `json:"previousCondition"`
+	Condition         string `json:"condition"`
+}
+
+// stock-take DTOs
+type CreateStockTakeRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Note        string   `json:"note" binding:"omitempty,max=255"`
+	LocationIDs []string `json:"locationIds" binding:"required,min=1,max=50,dive,uuid"`
+}
+
+type GetStockTakesRequest struct {
+	Page   int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=open closed cancelled"`
+}
+
+type GetStockTakeItemsRequest struct {
+	Page       int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit      int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Status     string `form:"status" json:"status" binding:"omitempty,oneof=pending found missing found_elsewhere"`
+	LocationID string `form:"locationId" json:"locationId" binding:"omitempty,uuid"`
+}
+
+// ScanStockTakeItemRequest identifies the asset by ID or by its scanned tag, LocationID is where it was seen
+type ScanStockTakeItemRequest struct {
+	AssetID    string `json:"assetId" binding:"required_without=Tag,omitempty,uuid"`
+	Tag        string `json:"tag" binding:"required_without=AssetID,omitempty,max=30"`
+	LocationID string `json:"locationId" binding:"required,uuid"`
+	Condition  string `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Note       string `json:"note" binding:"omitempty,max=255"`
+}
+
+// UpdateStockTakeItemRequest marks an item by hand, found elsewhere needs the location it was seen at
+type UpdateStockTakeItemRequest struct {
+	Status     string `json:"status" binding:"required,oneof=pending found missing found_elsewhere"`
+	LocationID string `json:"locationId" binding:"required_if=Status found_elsewhere,omitempty,uuid"`
+	Condition  string `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Note       string `json:"note" binding:"omitempty,max=255"`
+}
+
+type CloseStockTakeRequest struct {
+	ApplyCorrections bool `json:"applyCorrections"`
+}
+
+type StockTakeSummary struct {
+	Expected         int `json:"expected"`
+	Unlisted         int `json:"unlisted"`
+	Pending          int `json:"pending"`
+	Found            int `json:"found"`
+	Missing          int `json:"missing"`
+	FoundElsewhere   int `json:"foundElsewhere"`
+	ConditionChanged int `json:"conditionChanged"`
+}
+
+type StockTakeResponse struct {
+	ID                 string             `json:"id"`
+	Name               string             `json:"name"`
+	Note               string             `json:"note"`
+	Status             string             `json:"status"`
+	Locations          []LocationResponse `json:"locations"`
+	StartedBy          string             `json:"startedBy"`
+	ClosedBy           *string            `json:"closedBy"`
+	ClosedAt           *time.Time         `json:"closedAt"`
+	CorrectionsApplied bool               `json:"correctionsApplied"`
+	Summary            *StockTakeSummary  `json:"summary,omitempty"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+type StockTakeItemResponse struct {
+	ID                string            `json:"id"`
+	AssetID           string            `json:"assetId"`
+	AssetName         string            `json:"assetName"`
+	AssetTag          string            `json:"assetTag"`
+	Status            string            `json:"status"`
+	Unlisted          bool              `json:"unlisted"`
+	ExpectedLocation  LocationResponse  `json:"expectedLocation"`
+	ObservedLocation  *LocationResponse `json:"observedLocation"`
+	ExpectedCondition string            `json:"expectedCondition"`
+	ObservedCondition string            `json:"observedCondition"`
+	Note              string            `json:"note"`
+	CheckedBy         *string           `json:"checkedBy"`
+	CheckedAt         *time.Time        `json:"checkedAt"`
+}
+
+// StockTakeReportResponse lists every discrepancy, an item shows up in ConditionChanges as well
+// as in its status list when both differ
+type StockTakeReportResponse struct {
+	StockTake        StockTakeResponse       `json:"stockTake"`
+	Missing          []StockTakeItemResponse `json:"missing"`
+	FoundElsewhere   []StockTakeItemResponse `json:"foundElsewhere"`
+	ConditionChanges []StockTakeItemResponse `json:"conditionChanges"`
+	Pending          []StockTakeItemResponse `json:"pending"`
+}
+
+// custom field DTOs
+type CreateCustomFieldRequest struct {
+	Key      string   `json:"key" binding:"required,min=1,max=50"`
+	Label    string   `json:"label" binding:"required,min=1,max=100"`
+	Type     string   `json:"type" binding:"required,oneof=text number date boolean enum"`
+	Options  []string `json:"options" binding:"omitempty,max=100,dive,min=1,max=100"`
+	Required bool     `json:"required"`
+	Position *int     `json:"position" binding:"omitempty,min=0"`
+}
+
+// UpdateCustomFieldRequest can't change the key or type since stored values depend on them
+type UpdateCustomFieldRequest struct {
+	Label    string   `json:"label" binding:"required,min=1,max=100"`
+	Options  []string `json:"options" binding:"omitempty,max=100,dive,min=1,max=100"`
+	Required bool     `json:"required"`
+	Position *int     `json:"position" binding:"omitempty,min=0"`
+}
+
+type CustomFieldResponse struct {
+	ID         string    `json:"id"`
+	CategoryID string    `json:"categoryId"`
+	Key        string    `json:"key"`
+	Label      string    `json:"label"`
+	Type       string    `json:"type"`
+	Options    []string  `json:"options"`
+	Required   bool      `json:"required"`
+	Position   int       `json:"position"`
+	Inherited  bool      `json:"inherited"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CategoryFieldsResponse lists every field assets of the category have, inherited ones first
+type CategoryFieldsResponse struct {
+	CategoryID string                `json:"categoryId"`
+	Fields     []CustomFieldResponse `json:"fields"`
+	Total      int                   `json:"total"`
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AccessTokenHandler struct {
+	service services.AccessTokenService
+}
+
+func NewAccessTokenHandler(service services.AccessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{service}
+}
+
+func (h *AccessTokenHandler) GetTokens(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	tokens, err := h.service.GetTokens(userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Access tokens retrieved successfully", tokens)
+}
+
+func (h *AccessTokenHandler) CreateToken(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CreateAccessTokenRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	token, err := h.service.CreateToken(userID, organizationID, c.GetString("role"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Access token created successfully, copy it now as it won't be shown again", token)
+}
+
+func (h *AccessTokenHandler) DeleteToken(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	tokenID := c.Param("id")
+
+	if err := h.service.DeleteToken(userID, tokenID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Access token revoked successfully", tokenID)
+}
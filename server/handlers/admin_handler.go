@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	service services.AdminService
+}
+
+func NewAdminHandler(service services.AdminService) *AdminHandler {
+	return &AdminHandler{service}
+}
+
+func (h *AdminHandler) GetUsers(c *gin.Context) {
+	var req dto.GetUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	// apply pagination defaults
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.BadRequest(err.Error()))
+		return
+	}
+
+	users, total, err := h.service.GetUsers(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Users retrieved successfully", users, pag)
+}
+
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	user, err := h.service.DeactivateUser(utils.GetAuditActor(c), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "User deactivated successfully", user)
+}
+
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	user, err := h.service.ReactivateUser(utils.GetAuditActor(c), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "User reactivated successfully", user)
+}
+
+func (h *AdminHandler) ImpersonateUser(c *gin.Context) {
+	adminID := utils.MustGetUserID(c)
+
+	impersonation, err := h.service.ImpersonateUser(adminID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	// only the access token is replaced, refreshing the session returns to the admin account
+	utils.SetAccessTokenCookie(c, impersonation.AccessToken)
+
+	response.OK(c, "Impersonation started successfully", impersonation.User)
+}
+
+func (h *AdminHandler) GetSystemCategories(c *gin.Context) {
+	categories, err := h.service.GetSystemCategories()
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Categories retrieved successfully", categories)
+}
+
+func (h *AdminHandler) CreateSystemCategory(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	category, err := h.service.CreateSystemCategory(utils.GetAuditActor(c), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Category created successfully", category)
+}
+
+func (h *AdminHandler) UpdateSystemCategory(c *gin.Context) {
+	var req dto.UpdateCategoryRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	category, err := h.service.UpdateSystemCategory(utils.GetAuditActor(c), c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Category updated successfully", category)
+}
+
+func (h *AdminHandler) DeleteSystemCategory(c *gin.Context) {
+	categoryID := c.Param("id")
+
+	if err := h.service.DeleteSystemCategory(utils.GetAuditActor(c), categoryID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Category deleted successfully", categoryID)
+}
+
+func (h *AdminHandler) GetSystemLocations(c *gin.Context) {
+	locations, err := h.service.GetSystemLocations()
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Locations retrieved successfully", locations)
+}
+
+func (h *AdminHandler) CreateSystemLocation(c *gin.Context) {
+	var req dto.CreateLocationRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	location, err := h.service.CreateSystemLocation(utils.GetAuditActor(c), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Location created successfully", location)
+}
+
+func (h *AdminHandler) UpdateSystemLocation(c *gin.Context) {
+	var req dto.UpdateLocationRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	location, err := h.service.UpdateSystemLocation(utils.GetAuditActor(c), c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Location updated successfully", location)
+}
+
+func (h *AdminHandler) DeleteSystemLocation(c *gin.Context) {
+	locationID := c.Param("id")
+
+	if err := h.service.DeleteSystemLocation(utils.GetAuditActor(c), locationID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Location deleted successfully", locationID)
+}
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/fiqrioemry/asset_management_system_app/server/dto"
 	"github.com/fiqrioemry/asset_management_system_app/server/services"
 	"github.com/fiqrioemry/asset_management_system_app/server/utils"
@@ -20,7 +24,7 @@ func NewAssetHandler(service services.AssetService) *AssetHandler {
 }
 
 func (h *AssetHandler) CreateAsset(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
 	var req dto.CreateAssetRequest
 	if !utils.BindAndValidateForm(c, &req) {
@@ -37,7 +41,7 @@ func (h *AssetHandler) CreateAsset(c *gin.Context) {
 		req.ImageURL = imageURL
 	}
 
-	asset, err := h.service.CreateAsset(userID, &req)
+	asset, err := h.service.CreateAsset(utils.GetAuditActor(c), organizationID, &req)
 	if err != nil {
 		utils.CleanupImageOnError(req.ImageURL)
 		response.Error(c, err)
@@ -48,13 +52,16 @@ func (h *AssetHandler) CreateAsset(c *gin.Context) {
 }
 
 func (h *AssetHandler) GetAssets(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	var req dto.GetAssetsRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		response.Error(c, response.NewBadRequest("Invalid query parameters"))
 		return
 	}
+	req.CustomFields = c.QueryMap("cf")
+	req.CustomFieldsMin = c.QueryMap("cfMin")
+	req.CustomFieldsMax = c.QueryMap("cfMax")
 
 	// apply pagination defaults
 	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
@@ -62,7 +69,7 @@ func (h *AssetHandler) GetAssets(c *gin.Context) {
 		return
 	}
 
-	assets, total, err := h.service.GetAssets(userID, &req)
+	assets, total, err := h.service.GetAssets(organizationID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -74,10 +81,22 @@ func (h *AssetHandler) GetAssets(c *gin.Context) {
 }
 
 func (h *AssetHandler) GetAssetByID(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	assetID := c.Param("id")
 
-	asset, err := h.service.GetAssetByID(userID, assetID)
+	asset, err := h.service.GetAssetByID(organizationID, assetID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset retrieved successfully", asset)
+}
+
+func (h *AssetHandler) GetAssetByTag(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	asset, err := h.service.GetAssetByTag(organizationID, c.Param("tag"))
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -88,7 +107,7 @@ func (h *AssetHandler) GetAssetByID(c *gin.Context) {
 
 func (h *AssetHandler) UpdateAsset(c *gin.Context) {
 	assetID := c.Param("id")
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
 	var req dto.UpdateAssetRequest
 	if !utils.BindAndValidateForm(c, &req) {
@@ -103,7 +122,7 @@ func (h *AssetHandler) UpdateAsset(c *gin.Context) {
 		req.ImageURL = imageURL
 	}
 
-	asset, err := h.service.UpdateAsset(userID, assetID, &req)
+	asset, err := h.service.UpdateAsset(utils.GetAuditActor(c), organizationID, assetID, &req)
 	if err != nil {
 		utils.CleanupImageOnError(req.ImageURL)
 		response.Error(c, err)
@@ -115,12 +134,115 @@ func (h *AssetHandler) UpdateAsset(c *gin.Context) {
 
 func (h *AssetHandler) DeleteAsset(c *gin.Context) {
 	assetID := c.Param("id")
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	if err := h.service.DeleteAsset(userID, assetID); err != nil {
+	if err := h.service.DeleteAsset(utils.GetAuditActor(c), organizationID, assetID); err != nil {
 		response.Error(c, err)
 		return
 	}
 
 	response.OK(c, "Asset deleted successfully", assetID)
 }
+
+func (h *AssetHandler) MoveAsset(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.MoveAssetRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	movement, err := h.service.MoveAsset(utils.GetAuditActor(c), organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset moved successfully", movement)
+}
+
+func (h *AssetHandler) GetAssetHistory(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetAssetHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	history, err := h.service.GetAssetHistory(organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset history retrieved successfully", history)
+}
+
+func (h *AssetHandler) ImportAssets(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.ImportAssetsRequest
+	if !utils.BindAndValidateForm(c, &req) {
+		return
+	}
+
+	// dryRun may also be passed as a query parameter
+	if dryRun, err := strconv.ParseBool(c.Query("dryRun")); err == nil {
+		req.DryRun = dryRun
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		response.Error(c, response.NewBadRequest("Failed to read uploaded file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportAssets(utils.GetAuditActor(c), organizationID, file, req.DryRun)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if req.DryRun {
+		response.OK(c, "Import validated successfully", result)
+		return
+	}
+
+	response.Created(c, "Assets imported successfully", result)
+}
+
+func (h *AssetHandler) ExportAssets(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.ExportAssetsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+	req.CustomFields = c.QueryMap("cf")
+	req.CustomFieldsMin = c.QueryMap("cfMin")
+	req.CustomFieldsMax = c.QueryMap("cfMax")
+
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+
+	filename := fmt.Sprintf("assets-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+	c.Header("Content-Type", utils.ExportContentType(req.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.service.ExportAssets(organizationID, &req, c.Writer); err != nil {
+		// once the body has started streaming the error can only be logged
+		if c.Writer.Written() {
+			utils.GetLogger().Error("asset export interrupted: " + err.Error())
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		response.Error(c, err)
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandler struct {
+	service services.AttachmentService
+}
+
+func NewAttachmentHandler(service services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service}
+}
+
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	attachments, err := h.service.GetAttachments(organizationID, assetID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Attachments retrieved successfully", attachments)
+}
+
+func (h *AttachmentHandler) UploadAttachments(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.UploadAttachmentsRequest
+	if !utils.BindAndValidateForm(c, &req) {
+		return
+	}
+
+	// upload every file first, roll back the stored ones if any fails
+	files := make([]services.UploadedFile, 0, len(req.Files))
+	cleanup := func() {
+		urls := make([]string, 0, len(files))
+		for _, file := range files {
+			urls = append(urls, file.URL)
+		}
+		utils.CleanupImagesOnError(urls)
+	}
+
+	for _, fileHeader := range req.Files {
+		url, mimeType, err := utils.UploadAttachmentWithValidation(fileHeader)
+		if err != nil {
+			cleanup()
+			response.Error(c, response.NewBadRequest(err.Error()))
+			return
+		}
+		files = append(files, services.UploadedFile{
+			URL:      url,
+			FileName: fileHeader.Filename,
+			MimeType: mimeType,
+			Size:     fileHeader.Size,
+		})
+	}
+
+	attachments, err := h.service.AddAttachments(organizationID, assetID, req.Kind, files)
+	if err != nil {
+		cleanup()
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Attachments uploaded successfully", attachments)
+}
+
+func (h *AttachmentHandler) ReorderAttachments(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.ReorderAttachmentsRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	attachments, err := h.service.ReorderAttachments(organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Attachments reordered successfully", attachments)
+}
+
+func (h *AttachmentHandler) SetPrimaryAttachment(c *gin.Context) {
+	assetID := c.Param("id")
+	attachmentID := c.Param("attachmentId")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	attachments, err := h.service.SetPrimaryAttachment(organizationID, assetID, attachmentID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Primary image updated successfully", attachments)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	assetID := c.Param("id")
+	attachmentID := c.Param("attachmentId")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	if err := h.service.DeleteAttachment(organizationID, assetID, attachmentID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Attachment deleted successfully", attachmentID)
+}
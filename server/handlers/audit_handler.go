@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	service services.AuditService
+}
+
+func NewAuditHandler(service services.AuditService) *AuditHandler {
+	return &AuditHandler{service}
+}
+
+// GetAuditLogs lists the audit log of the active organization
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	h.getAuditLogs(c, utils.MustGetOrganizationID(c))
+}
+
+// GetSystemAuditLogs lists the audit log of every organization, including user and system catalog changes
+func (h *AuditHandler) GetSystemAuditLogs(c *gin.Context) {
+	h.getAuditLogs(c, "")
+}
+
+func (h *AuditHandler) getAuditLogs(c *gin.Context, organizationID string) {
+	var req dto.GetAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	// apply pagination defaults
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.BadRequest(err.Error()))
+		return
+	}
+
+	logs, total, err := h.service.GetAuditLogs(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Audit log retrieved successfully", logs, pag)
+}
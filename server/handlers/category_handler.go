@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"strconv"
+
 	"github.com/fiqrioemry/asset_management_system_app/server/dto"
 	"github.com/fiqrioemry/asset_management_system_app/server/services"
 	"github.com/fiqrioemry/asset_management_system_app/server/utils"
@@ -17,9 +19,9 @@ func NewCategoryHandler(service services.CategoryService) *CategoryHandler {
 }
 
 func (h *CategoryHandler) GetCategoriesTree(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	categories, err := h.service.GetCategoriesTree(userID)
+	categories, err := h.service.GetCategoriesTree(organizationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -30,9 +32,9 @@ func (h *CategoryHandler) GetCategoriesTree(c *gin.Context) {
 }
 
 func (h *CategoryHandler) GetCategoriesFlat(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	categories, err := h.service.GetCategoriesFlat(userID)
+	categories, err := h.service.GetCategoriesFlat(organizationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -42,9 +44,9 @@ func (h *CategoryHandler) GetCategoriesFlat(c *gin.Context) {
 }
 
 func (h *CategoryHandler) GetParentCategories(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	categories, err := h.service.GetParentCategories(userID)
+	categories, err := h.service.GetParentCategories(organizationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -55,9 +57,9 @@ func (h *CategoryHandler) GetParentCategories(c *gin.Context) {
 
 func (h *CategoryHandler) GetChildCategories(c *gin.Context) {
 	parentID := c.Param("id")
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	categories, err := h.service.GetChildCategories(parentID, userID)
+	categories, err := h.service.GetChildCategories(parentID, organizationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -68,10 +70,10 @@ func (h *CategoryHandler) GetChildCategories(c *gin.Context) {
 }
 
 func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	categoryID := c.Param("id")
 
-	category, err := h.service.GetCategoryByID(userID, categoryID)
+	category, err := h.service.GetCategoryByID(organizationID, categoryID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -81,10 +83,13 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 }
 
 func (h *CategoryHandler) GetAssetsByCategory(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	categoryID := c.Param("id")
 
-	result, err := h.service.GetAssetsByCategory(userID, categoryID)
+	// includeDescendants=true also lists the assets of every subcategory
+	includeDescendants, _ := strconv.ParseBool(c.Query("includeDescendants"))
+
+	result, err := h.service.GetAssetsByCategory(organizationID, categoryID, includeDescendants)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -94,7 +99,7 @@ func (h *CategoryHandler) GetAssetsByCategory(c *gin.Context) {
 }
 
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
 	// bind and validate request
 	var req dto.CreateCategoryRequest
@@ -102,7 +107,7 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := h.service.CreateCategory(userID, &req)
+	category, err := h.service.CreateCategory(utils.GetAuditActor(c), organizationID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -112,7 +117,7 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 }
 
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	categoryID := c.Param("id")
 
 	// bind and validate request
@@ -121,7 +126,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := h.service.UpdateCategory(userID, categoryID, &req)
+	category, err := h.service.UpdateCategory(utils.GetAuditActor(c), organizationID, categoryID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -132,9 +137,9 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	categoryID := c.Param("id")
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	if err := h.service.DeleteCategory(userID, categoryID); err != nil {
+	if err := h.service.DeleteCategory(utils.GetAuditActor(c), organizationID, categoryID); err != nil {
 		response.Error(c, err)
 		return
 	}
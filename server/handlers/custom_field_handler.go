@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	service services.CustomFieldService
+}
+
+func NewCustomFieldHandler(service services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service}
+}
+
+func (h *CustomFieldHandler) GetCategoryFields(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	fields, err := h.service.GetCategoryFields(organizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Custom fields retrieved successfully", fields)
+}
+
+func (h *CustomFieldHandler) CreateField(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CreateCustomFieldRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	field, err := h.service.CreateField(organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Custom field created successfully", field)
+}
+
+func (h *CustomFieldHandler) UpdateField(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.UpdateCustomFieldRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	field, err := h.service.UpdateField(organizationID, c.Param("id"), c.Param("fieldId"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Custom field updated successfully", field)
+}
+
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+	fieldID := c.Param("fieldId")
+
+	if err := h.service.DeleteField(organizationID, c.Param("id"), fieldID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Custom field deleted successfully", fieldID)
+}
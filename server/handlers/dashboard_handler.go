@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardHandler struct {
+	service services.DashboardService
+}
+
+func NewDashboardHandler(service services.DashboardService) *DashboardHandler {
+	return &DashboardHandler{service}
+}
+
+func (h *DashboardHandler) GetSummary(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	summary, err := h.service.GetSummary(organizationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Dashboard summary retrieved successfully", summary)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+// FileHandler serves files kept by the local storage driver
+type FileHandler struct{}
+
+func NewFileHandler() *FileHandler {
+	return &FileHandler{}
+}
+
+func (h *FileHandler) GetFile(c *gin.Context) {
+	server, ok := utils.GetStorage().(utils.FileServer)
+	if !ok {
+		response.Error(c, response.NewNotFound("File not found"))
+		return
+	}
+
+	file, err := server.Open(c.Param("key"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, utils.ErrInvalidFileKey) {
+			response.Error(c, response.NewNotFound("File not found"))
+			return
+		}
+		response.Error(c, response.NewInternalServerError("Failed to open file", err))
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		response.Error(c, response.NewInternalServerError("Failed to read file", err))
+		return
+	}
+
+	// detect the content type from the file itself instead of trusting the extension
+	buffer := make([]byte, 512)
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		response.Error(c, response.NewInternalServerError("Failed to read file", err))
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		response.Error(c, response.NewInternalServerError("Failed to read file", err))
+		return
+	}
+
+	c.Header("Content-Type", http.DetectContentType(buffer[:n]))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age=86400")
+
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
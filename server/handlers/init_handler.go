@@ -5,21 +5,50 @@ import (
 )
 
 type Handlers struct {
-	UserHandler     *UserHandler
-	AssetHandler    *AssetHandler
-	LocationHandler *LocationHandler
-	CategoryHandler *CategoryHandler
-	// 	DashboardHandler *DashboardHandler
-	//
+	UserHandler         *UserHandler
+	AssetHandler        *AssetHandler
+	LocationHandler     *LocationHandler
+	CategoryHandler     *CategoryHandler
+	LoanHandler         *LoanHandler
+	ReportHandler       *ReportHandler
+	DashboardHandler    *DashboardHandler
+	NotificationHandler *NotificationHandler
+	MaintenanceHandler  *MaintenanceHandler
+	AttachmentHandler   *AttachmentHandler
+	FileHandler         *FileHandler
+	OrganizationHandler *OrganizationHandler
+	AdminHandler        *AdminHandler
+	AuditHandler        *AuditHandler
+	TwoFactorHandler    *TwoFactorHandler
+	AccessTokenHandler  *AccessTokenHandler
+	WebhookHandler      *WebhookHandler
+	LabelHandler        *LabelHandler
+	StockTakeHandler    *StockTakeHandler
+	CustomFieldHandler  *CustomFieldHandler
 }
 
 func InitHandlers(s *services.Services) *Handlers {
 	return &Handlers{
-		UserHandler:     NewUserHandler(s.UserService),
-		AssetHandler:    NewAssetHandler(s.AssetService),
-		LocationHandler: NewLocationHandler(s.LocationService),
-		CategoryHandler: NewCategoryHandler(s.CategoryService),
-		// DashboardHandler: NewDashboardHandler(s.DashboardService),
+		UserHandler:         NewUserHandler(s.UserService),
+		AssetHandler:        NewAssetHandler(s.AssetService),
+		LocationHandler:     NewLocationHandler(s.LocationService),
+		CategoryHandler:     NewCategoryHandler(s.CategoryService),
+		LoanHandler:         NewLoanHandler(s.LoanService),
+		ReportHandler:       NewReportHandler(s.ReportService),
+		DashboardHandler:    NewDashboardHandler(s.DashboardService),
+		NotificationHandler: NewNotificationHandler(s.NotificationService),
+		MaintenanceHandler:  NewMaintenanceHandler(s.MaintenanceService),
+		AttachmentHandler:   NewAttachmentHandler(s.AttachmentService),
+		FileHandler:         NewFileHandler(),
+		OrganizationHandler: NewOrganizationHandler(s.OrganizationService),
+		AdminHandler:        NewAdminHandler(s.AdminService),
+		AuditHandler:        NewAuditHandler(s.AuditService),
+		TwoFactorHandler:    NewTwoFactorHandler(s.TwoFactorService),
+		AccessTokenHandler:  NewAccessTokenHandler(s.AccessTokenService),
+		WebhookHandler:      NewWebhookHandler(s.WebhookService),
+		LabelHandler:        NewLabelHandler(s.LabelService),
+		StockTakeHandler:    NewStockTakeHandler(s.StockTakeService),
+		CustomFieldHandler:  NewCustomFieldHandler(s.CustomFieldService),
 	}
 
 }
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type LabelHandler struct {
+	service services.LabelService
+}
+
+func NewLabelHandler(service services.LabelService) *LabelHandler {
+	return &LabelHandler{service}
+}
+
+func (h *LabelHandler) GetAssetLabel(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+	assetID := c.Param("id")
+
+	var req dto.GetAssetLabelRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	if req.Format == "" {
+		req.Format = "png"
+	}
+
+	label, err := h.service.GetAssetLabel(organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	contentType := "image/png"
+	if req.Format == "svg" {
+		contentType = "image/svg+xml"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("label-%s.%s", assetID, req.Format)))
+	c.Data(http.StatusOK, contentType, label)
+}
+
+func (h *LabelHandler) GetLabelSheet(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.LabelSheetRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	sheet, err := h.service.GetLabelSheet(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("labels-%s.pdf", time.Now().Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", sheet)
+}
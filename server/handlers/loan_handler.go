@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoanHandler struct {
+	service services.LoanService
+}
+
+func NewLoanHandler(service services.LoanService) *LoanHandler {
+	return &LoanHandler{service}
+}
+
+func (h *LoanHandler) CheckOutAsset(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CheckOutAssetRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	loan, err := h.service.CheckOutAsset(utils.GetAuditActor(c), organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Asset checked out successfully", loan)
+}
+
+func (h *LoanHandler) CheckInAsset(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CheckInAssetRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	loan, err := h.service.CheckInAsset(utils.GetAuditActor(c), organizationID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset checked in successfully", loan)
+}
+
+func (h *LoanHandler) GetAssetLoans(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	loans, err := h.service.GetAssetLoans(organizationID, assetID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset loans retrieved successfully", loans)
+}
+
+func (h *LoanHandler) GetLoans(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetLoansRequest
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	loans, total, err := h.service.GetLoans(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Loans retrieved successfully", loans, pag)
+}
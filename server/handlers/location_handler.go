@@ -17,9 +17,9 @@ func NewLocationHandler(service services.LocationService) *LocationHandler {
 }
 
 func (h *LocationHandler) GetLocations(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
-	locationResp, err := h.service.GetLocations(userID)
+	locationResp, err := h.service.GetLocations(organizationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -28,15 +28,27 @@ func (h *LocationHandler) GetLocations(c *gin.Context) {
 	response.OK(c, "Locations retrieved successfully", locationResp.Locations)
 }
 
+func (h *LocationHandler) GetLocationTree(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	tree, err := h.service.GetLocationTree(organizationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Location tree retrieved successfully", tree)
+}
+
 func (h *LocationHandler) CreateLocation(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 
 	var req dto.CreateLocationRequest
 	if !utils.BindAndValidateJSON(c, &req) {
 		return
 	}
 
-	location, err := h.service.CreateLocation(userID, &req)
+	location, err := h.service.CreateLocation(utils.GetAuditActor(c), organizationID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -46,7 +58,7 @@ func (h *LocationHandler) CreateLocation(c *gin.Context) {
 }
 
 func (h *LocationHandler) UpdateLocation(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	locationID := c.Param("id")
 
 	var req dto.UpdateLocationRequest
@@ -54,7 +66,7 @@ func (h *LocationHandler) UpdateLocation(c *gin.Context) {
 		return
 	}
 
-	location, err := h.service.UpdateLocation(userID, locationID, &req)
+	location, err := h.service.UpdateLocation(utils.GetAuditActor(c), organizationID, locationID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -65,10 +77,10 @@ func (h *LocationHandler) UpdateLocation(c *gin.Context) {
 }
 
 func (h *LocationHandler) DeleteLocation(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	locationID := c.Param("id")
 
-	if err := h.service.DeleteLocation(userID, locationID); err != nil {
+	if err := h.service.DeleteLocation(utils.GetAuditActor(c), organizationID, locationID); err != nil {
 		response.Error(c, err)
 		return
 	}
@@ -77,10 +89,10 @@ func (h *LocationHandler) DeleteLocation(c *gin.Context) {
 }
 
 func (h *LocationHandler) GetLocationByID(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	locationID := c.Param("id")
 
-	location, err := h.service.GetLocationByID(userID, locationID)
+	location, err := h.service.GetLocationByID(organizationID, locationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -90,10 +102,10 @@ func (h *LocationHandler) GetLocationByID(c *gin.Context) {
 }
 
 func (h *LocationHandler) GetAssetsByLocation(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
 	locationID := c.Param("id")
 
-	result, err := h.service.GetAssetsByLocation(userID, locationID)
+	result, err := h.service.GetAssetsByLocation(organizationID, locationID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandler struct {
+	service services.MaintenanceService
+}
+
+func NewMaintenanceHandler(service services.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service}
+}
+
+func (h *MaintenanceHandler) GetAssetMaintenance(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+
+	maintenance, err := h.service.GetAssetMaintenance(organizationID, assetID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset maintenance retrieved successfully", maintenance)
+}
+
+func (h *MaintenanceHandler) CreatePlan(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+	userID := utils.MustGetUserID(c)
+
+	var req dto.CreateMaintenancePlanRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	plan, err := h.service.CreatePlan(organizationID, userID, assetID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Maintenance plan created successfully", plan)
+}
+
+func (h *MaintenanceHandler) CreateRecord(c *gin.Context) {
+	assetID := c.Param("id")
+	organizationID := utils.MustGetOrganizationID(c)
+	userID := utils.MustGetUserID(c)
+
+	var req dto.CreateMaintenanceRecordRequest
+	if !utils.BindAndValidateForm(c, &req) {
+		return
+	}
+
+	// Handle attachment uploads
+	if len(req.Attachments) > 0 {
+		urls, err := utils.UploadMultipleImagesWithValidation(req.Attachments)
+		if err != nil {
+			response.Error(c, response.NewBadRequest(err.Error()))
+			return
+		}
+		req.AttachmentURLs = urls
+	}
+
+	record, err := h.service.CreateRecord(organizationID, userID, assetID, &req)
+	if err != nil {
+		utils.CleanupImagesOnError(req.AttachmentURLs)
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Maintenance logged successfully", record)
+}
+
+func (h *MaintenanceHandler) GetUpcoming(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetUpcomingMaintenanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("days must be between 1 and 365"))
+		return
+	}
+
+	upcoming, err := h.service.GetUpcoming(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Upcoming maintenance retrieved successfully", upcoming)
+}
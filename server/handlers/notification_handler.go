@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service}
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	preferences, err := h.service.GetPreferences(userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Notification preferences retrieved successfully", preferences)
+}
+
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	var req dto.UpdateNotificationPreferenceRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	preferences, err := h.service.UpdatePreferences(userID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Notification preferences updated successfully", preferences)
+}
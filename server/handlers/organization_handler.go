@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	service services.OrganizationService
+}
+
+func NewOrganizationHandler(service services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service}
+}
+
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	organizations, err := h.service.GetOrganizations(userID, organizationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Organizations retrieved successfully", organizations)
+}
+
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	activeOrganizationID := utils.MustGetOrganizationID(c)
+
+	organization, err := h.service.GetOrganization(userID, activeOrganizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Organization retrieved successfully", organization)
+}
+
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	var req dto.CreateOrganizationRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	organization, err := h.service.CreateOrganization(userID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Organization created successfully", organization)
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	activeOrganizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.UpdateOrganizationRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	organization, err := h.service.UpdateOrganization(userID, activeOrganizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Organization updated successfully", organization)
+}
+
+func (h *OrganizationHandler) SwitchOrganization(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	organization, err := h.service.SwitchOrganization(c, userID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Active organization switched successfully", organization)
+}
+
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	var req dto.AddOrganizationMemberRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	member, err := h.service.AddMember(userID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Member added successfully", member)
+}
+
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	if err := h.service.RemoveMember(userID, c.Param("id"), c.Param("userId")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Member removed successfully", nil)
+}
+
+func (h *OrganizationHandler) GetRoles(c *gin.Context) {
+	response.OK(c, "Roles retrieved successfully", h.service.GetRoles())
+}
+
+func (h *OrganizationHandler) GetMembers(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	members, err := h.service.GetMembers(organizationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Members retrieved successfully", members)
+}
+
+func (h *OrganizationHandler) AssignRole(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.AssignRoleRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	member, err := h.service.AssignRole(userID, organizationID, c.Param("userId"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Role assigned successfully", member)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	service services.ReportService
+}
+
+func NewReportHandler(service services.ReportService) *ReportHandler {
+	return &ReportHandler{service}
+}
+
+func (h *ReportHandler) GetDepreciationReport(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.DepreciationReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("asOf must be in YYYY-MM-DD format"))
+		return
+	}
+
+	report, err := h.service.GetDepreciationReport(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Depreciation report generated successfully", report)
+}
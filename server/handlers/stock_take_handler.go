@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type StockTakeHandler struct {
+	service services.StockTakeService
+}
+
+func NewStockTakeHandler(service services.StockTakeService) *StockTakeHandler {
+	return &StockTakeHandler{service}
+}
+
+func (h *StockTakeHandler) GetStockTakes(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetStockTakesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	// apply pagination defaults
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.BadRequest(err.Error()))
+		return
+	}
+
+	stockTakes, total, err := h.service.GetStockTakes(organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Stock-takes retrieved successfully", stockTakes, pag)
+}
+
+func (h *StockTakeHandler) GetStockTakeByID(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	stockTake, err := h.service.GetStockTakeByID(organizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Stock-take retrieved successfully", stockTake)
+}
+
+func (h *StockTakeHandler) CreateStockTake(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CreateStockTakeRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	stockTake, err := h.service.CreateStockTake(userID, organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Stock-take started successfully", stockTake)
+}
+
+func (h *StockTakeHandler) CancelStockTake(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	stockTake, err := h.service.CancelStockTake(organizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Stock-take cancelled successfully", stockTake)
+}
+
+func (h *StockTakeHandler) CloseStockTake(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CloseStockTakeRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	report, err := h.service.CloseStockTake(utils.GetAuditActor(c), organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Stock-take closed successfully", report)
+}
+
+func (h *StockTakeHandler) GetReport(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	report, err := h.service.GetReport(organizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Stock-take report retrieved successfully", report)
+}
+
+func (h *StockTakeHandler) GetItems(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetStockTakeItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	// apply pagination defaults
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.BadRequest(err.Error()))
+		return
+	}
+
+	items, total, err := h.service.GetItems(organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Stock-take items retrieved successfully", items, pag)
+}
+
+func (h *StockTakeHandler) ScanItem(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.ScanStockTakeItemRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	item, err := h.service.ScanItem(userID, organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Asset recorded successfully", item)
+}
+
+func (h *StockTakeHandler) UpdateItem(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.UpdateStockTakeItemRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	item, err := h.service.UpdateItem(userID, organizationID, c.Param("id"), c.Param("itemId"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Stock-take item updated successfully", item)
+}
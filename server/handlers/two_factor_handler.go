@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	service services.TwoFactorService
+}
+
+func NewTwoFactorHandler(service services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{service}
+}
+
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	status, err := h.service.GetStatus(userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor status retrieved successfully", status)
+}
+
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	var req dto.SetupTwoFactorRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	setup, err := h.service.Setup(userID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor setup started, confirm it with a code", setup)
+}
+
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	codes, err := h.service.Enable(utils.GetAuditActor(c), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor authentication enabled successfully", codes)
+}
+
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req dto.DisableTwoFactorRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.Disable(utils.GetAuditActor(c), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor authentication disabled successfully", nil)
+}
+
+func (h *TwoFactorHandler) SendEmailCode(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	if err := h.service.SendEmailCode(userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor code sent successfully", nil)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(utils.GetAuditActor(c), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Recovery codes regenerated successfully", codes)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/config"
 	"github.com/fiqrioemry/asset_management_system_app/server/dto"
@@ -26,12 +27,18 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	loginResponse, err := h.service.Login(&req)
+	loginResponse, err := h.service.Login(utils.GetAuditActor(c), &req)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
+	// no cookies until the second factor is verified
+	if loginResponse.Challenge != nil {
+		response.OK(c, "Two-factor authentication required", loginResponse.Challenge)
+		return
+	}
+
 	utils.SetAccessTokenCookie(c, loginResponse.AccessToken)
 
 	utils.SetRefreshTokenCookie(c, loginResponse.RefreshToken)
@@ -45,7 +52,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
-	registerResponse, err := h.service.Register(&req)
+	registerResponse, err := h.service.Register(utils.GetAuditActor(c), &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -60,6 +67,14 @@ func (h *UserHandler) Register(c *gin.Context) {
 }
 
 func (h *UserHandler) Logout(c *gin.Context) {
+	// revoke the session so its refresh token can't be used again
+	if refreshToken, err := c.Cookie("refreshToken"); err == nil {
+		if err := h.service.Logout(refreshToken); err != nil {
+			response.Error(c, err)
+			return
+		}
+	}
+
 	utils.ClearAccessTokenCookie(c)
 	utils.ClearRefreshTokenCookie(c)
 
@@ -83,6 +98,40 @@ func (h *UserHandler) RefreshSession(c *gin.Context) {
 	response.OK(c, "Session refreshed successfully", user)
 }
 
+func (h *UserHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.VerifyTwoFactorRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	verifyResponse, err := h.service.VerifyTwoFactor(utils.GetAuditActor(c), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	utils.SetAccessTokenCookie(c, verifyResponse.AccessToken)
+
+	utils.SetRefreshTokenCookie(c, verifyResponse.RefreshToken)
+
+	response.OK(c, "Two-factor verification successful", verifyResponse.User)
+}
+
+func (h *UserHandler) ResendTwoFactor(c *gin.Context) {
+	var req dto.ResendTwoFactorRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	challenge, err := h.service.ResendTwoFactor(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Two-factor code sent successfully", challenge)
+}
+
 func (h *UserHandler) GetMe(c *gin.Context) {
 	userID := utils.MustGetUserID(c)
 
@@ -96,8 +145,6 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 }
 
 func (h *UserHandler) UpdateMe(c *gin.Context) {
-	userID := utils.MustGetUserID(c)
-
 	var req dto.UpdateUserRequest
 	if !utils.BindAndValidateForm(c, &req) {
 		return
@@ -112,7 +159,7 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 		req.AvatarURL = avatarURL
 	}
 
-	updatedUser, err := h.service.UpdateMe(userID, &req)
+	updatedUser, err := h.service.UpdateMe(utils.GetAuditActor(c), &req)
 	if err != nil {
 		utils.CleanupImageOnError(req.AvatarURL)
 		response.Error(c, err)
@@ -123,19 +170,94 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 }
 
 func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.ChangePassword(utils.GetAuditActor(c), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	// every session was signed out, including this one
+	utils.ClearAccessTokenCookie(c)
+	utils.ClearRefreshTokenCookie(c)
+
+	response.OK(c, "Password changed successfully, please sign in again", nil)
+}
+
+func (h *UserHandler) GetSessions(c *gin.Context) {
 	userID := utils.MustGetUserID(c)
 
-	var req dto.ChangePasswordRequest
+	sessions, err := h.service.GetSessions(userID, c.GetString("sessionID"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Sessions retrieved successfully", sessions)
+}
+
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	sessionID := c.Param("id")
+
+	if err := h.service.RevokeSession(userID, sessionID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	// signing out the current session works like a logout
+	if sessionID == c.GetString("sessionID") {
+		utils.ClearAccessTokenCookie(c)
+		utils.ClearRefreshTokenCookie(c)
+	}
+
+	response.OK(c, "Session revoked successfully", sessionID)
+}
+
+func (h *UserHandler) RevokeAllSessions(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	if err := h.service.RevokeAllSessions(userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	utils.ClearAccessTokenCookie(c)
+	utils.ClearRefreshTokenCookie(c)
+
+	response.OK(c, "Logged out of all sessions successfully", nil)
+}
+
+// VerifyEmail confirms the user's email address with the emailed token
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.VerifyEmail(utils.GetAuditActor(c), &req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Email verified successfully", nil)
+}
+
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
 	if !utils.BindAndValidateJSON(c, &req) {
 		return
 	}
 
-	if err := h.service.ChangePassword(userID, &req); err != nil {
+	if err := h.service.ResendVerification(c, &req); err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.OK(c, "Password changed successfully", nil)
+	response.OK(c, "If the email is registered and not verified yet, a verification link has been sent", nil)
 }
 
 // step 1 : User requests password reset
@@ -175,7 +297,7 @@ func (h *UserHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ResetPassword(&req); err != nil {
+	if err := h.service.ResetPassword(utils.GetAuditActor(c), &req); err != nil {
 		response.Error(c, err)
 		return
 	}
@@ -184,8 +306,15 @@ func (h *UserHandler) ResetPassword(c *gin.Context) {
 }
 
 func (h *UserHandler) GoogleOAuthRedirect(c *gin.Context) {
-	url := h.service.GetGoogleOAuthURL()
-	c.Redirect(http.StatusTemporaryRedirect, url)
+	redirectURL, state, err := h.service.GetGoogleOAuthURL("")
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	utils.SetOAuthStateCookie(c, state)
+
+	c.Redirect(http.StatusTemporaryRedirect, redirectURL)
 }
 
 func (h *UserHandler) GoogleOAuthCallback(c *gin.Context) {
@@ -195,15 +324,98 @@ func (h *UserHandler) GoogleOAuthCallback(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.service.HandleGoogleOAuthCallback(code)
+	// the state must match the one issued to this browser
+	state := c.Query("state")
+	cookieState, err := c.Cookie("oauthState")
+	if state == "" || err != nil || cookieState != state {
+		response.Error(c, response.NewUnauthorized("Invalid OAuth state"))
+		return
+	}
+	utils.ClearOAuthStateCookie(c)
+
+	result, err := h.service.HandleGoogleOAuthCallback(utils.GetAuditActor(c), state, code)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	utils.SetAccessTokenCookie(c, tokens.AccessToken)
+	// the frontend completes the second factor with the challenge token
+	if result.Auth != nil && result.Auth.Challenge != nil {
+		query := url.Values{}
+		query.Set("twoFactorChallenge", result.Auth.Challenge.ChallengeToken)
+		query.Set("method", result.Auth.Challenge.Method)
+		c.Redirect(http.StatusTemporaryRedirect, config.AppConfig.FrontendRedirectURL+"?"+query.Encode())
+		return
+	}
+
+	if result.Auth != nil {
+		utils.SetAccessTokenCookie(c, result.Auth.AccessToken)
 
-	utils.SetRefreshTokenCookie(c, tokens.RefreshToken)
+		utils.SetRefreshTokenCookie(c, result.Auth.RefreshToken)
+	}
 
 	c.Redirect(http.StatusTemporaryRedirect, config.AppConfig.FrontendRedirectURL)
 }
+
+func (h *UserHandler) GoogleSignIn(c *gin.Context) {
+	var req dto.GoogleSignInRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	signInResponse, err := h.service.GoogleSignIn(utils.GetAuditActor(c), req.IDToken)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if signInResponse.Challenge != nil {
+		response.OK(c, "Two-factor authentication required", signInResponse.Challenge)
+		return
+	}
+
+	utils.SetAccessTokenCookie(c, signInResponse.AccessToken)
+
+	utils.SetRefreshTokenCookie(c, signInResponse.RefreshToken)
+
+	response.OK(c, "Google sign in successful", signInResponse.User)
+}
+
+func (h *UserHandler) LinkGoogleRedirect(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+
+	redirectURL, state, err := h.service.GetGoogleOAuthURL(userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	utils.SetOAuthStateCookie(c, state)
+
+	c.Redirect(http.StatusTemporaryRedirect, redirectURL)
+}
+
+func (h *UserHandler) LinkGoogle(c *gin.Context) {
+	var req dto.GoogleSignInRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	user, err := h.service.LinkGoogle(utils.GetAuditActor(c), req.IDToken)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Google account linked successfully", user)
+}
+
+func (h *UserHandler) UnlinkGoogle(c *gin.Context) {
+	user, err := h.service.UnlinkGoogle(utils.GetAuditActor(c))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Google account unlinked successfully", user)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/services"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service}
+}
+
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	webhooks, err := h.service.GetWebhooks(organizationID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Webhooks retrieved successfully", webhooks)
+}
+
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	webhook, err := h.service.GetWebhookByID(organizationID, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook retrieved successfully", webhook)
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID := utils.MustGetUserID(c)
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.CreateWebhookRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(userID, organizationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Webhook created successfully", webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.UpdateWebhookRequest
+	if !utils.BindAndValidateJSON(c, &req) {
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook updated successfully", webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+	webhookID := c.Param("id")
+
+	if err := h.service.DeleteWebhook(organizationID, webhookID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook deleted successfully", webhookID)
+}
+
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	var req dto.GetWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, response.NewBadRequest("Invalid query parameters"))
+		return
+	}
+
+	// apply pagination defaults
+	if err := pagination.BindAndSetDefaults(c, &req); err != nil {
+		response.Error(c, response.BadRequest(err.Error()))
+		return
+	}
+
+	deliveries, total, err := h.service.GetDeliveries(organizationID, c.Param("id"), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	pag := pagination.Build(req.Page, req.Limit, total)
+
+	response.OKWithPagination(c, "Webhook deliveries retrieved successfully", deliveries, pag)
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	organizationID := utils.MustGetOrganizationID(c)
+
+	delivery, err := h.service.Redeliver(organizationID, c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, "Webhook delivery queued successfully", delivery)
+}
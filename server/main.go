@@ -8,6 +8,7 @@ import (
 	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
 	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
 	"github.com/fiqrioemry/asset_management_system_app/server/routes"
+	"github.com/fiqrioemry/asset_management_system_app/server/seeders"
 	"github.com/fiqrioemry/asset_management_system_app/server/services"
 	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/fiqrioemry/go-api-toolkit/response"
@@ -28,10 +29,25 @@ func main() {
 	// ========== Configuration =================
 	config.InitConfiguration()
 	utils.InitLogger()
+	utils.InitStorage()
 	db := config.DB
 
 	// seeders.ResetDatabase(db)
 
+	// ========== Data migrations =============
+	if err := seeders.MigratePersonalOrganizations(db); err != nil {
+		log.Fatal("failed to migrate personal organizations: ", err)
+	}
+	if err := seeders.MigrateAssetTags(db); err != nil {
+		log.Fatal("failed to migrate asset tags: ", err)
+	}
+	if err := seeders.ClearOAuthPlaceholderPasswords(db); err != nil {
+		log.Fatal("failed to clear oauth placeholder passwords: ", err)
+	}
+	if err := seeders.PromoteAdmins(db, config.AppConfig.AdminEmails); err != nil {
+		log.Fatal("failed to promote admins: ", err)
+	}
+
 	// ========== Initialize response toolkit ===
 	// This initializes the response toolkit with custom configurations
 	// such as logging success and error responses.
@@ -45,6 +61,13 @@ func main() {
 	repo := repositories.InitRepositories(db)
 	s := services.InitServices(repo)
 	h := handlers.InitHandlers(s)
+	middlewares.UseAccessTokens(s.AccessTokenService)
+
+	// ========== Background jobs =============
+	utils.StartDailyJob("warranty reminders", config.AppConfig.WarrantyReminderHour, s.NotificationService.SendWarrantyReminders)
+	utils.StartDailyJob("expired session cleanup", 3, s.UserService.CleanupExpiredSessions)
+	utils.StartDailyJob("webhook delivery cleanup", 3, s.WebhookService.CleanupDeliveries)
+	s.WebhookService.StartWorker()
 
 	// ========== Initialize gin engine =======
 	r := gin.Default()
@@ -54,6 +77,7 @@ func main() {
 	r.Use(
 		ginzap.Ginzap(utils.GetLogger(), time.RFC3339, true),
 		middlewares.Recovery(),
+		middlewares.RequestContext(),
 		middlewares.CORS(),
 		middlewares.RateLimiterInit(),
 		middlewares.LimitFileSize(config.AppConfig.MaxFileSize),
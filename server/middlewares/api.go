@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/config"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/fiqrioemry/go-api-toolkit/response"
 	"github.com/gin-gonic/gin"
 )
@@ -32,6 +33,14 @@ func APIKeyGateway(skippedPaths []string) gin.HandlerFunc {
 			}
 		}
 
+		// a valid personal access token replaces the shared key
+		if strings.HasPrefix(c.GetHeader("Authorization"), "Bearer "+utils.PersonalAccessTokenPrefix) {
+			if resolveAccessToken(c, c.GetHeader("Authorization")) {
+				c.Next()
+			}
+			return
+		}
+
 		apiKey := c.GetHeader("X-API-KEY")
 		if apiKey == "" {
 			response.Error(c, response.NewUnauthorized("Unauthorized - API key is required"))
@@ -1,14 +1,37 @@
 package middlewares
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
 	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/fiqrioemry/go-api-toolkit/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AccessTokenAuthenticator resolves personal access tokens sent as a bearer token
+type AccessTokenAuthenticator interface {
+	Authenticate(token, clientIP string) (*dto.AccessTokenIdentity, error)
+}
+
+var accessTokens AccessTokenAuthenticator
+
+// UseAccessTokens enables bearer authentication in AuthRequired, called once services are initialized
+func UseAccessTokens(authenticator AccessTokenAuthenticator) {
+	accessTokens = authenticator
+}
+
 func AuthRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// scripts authenticate with a personal access token instead of the cookie
+		if header := c.GetHeader("Authorization"); header != "" {
+			authenticateAccessToken(c, header)
+			return
+		}
+
 		tokenString, err := c.Cookie("accessToken")
 		if err != nil || tokenString == "" {
 			response.Error(c, response.NewUnauthorized("Unauthorized!! Token missing"))
@@ -23,7 +46,125 @@ func AuthRequired() gin.HandlerFunc {
 			return
 		}
 
+		// tokens issued before organizations and roles existed must be refreshed
+		if claims.OrganizationID == "" || claims.Role == "" {
+			response.Error(c, response.NewUnauthorized("Unauthorized!! Token has no active organization"))
+			c.Abort()
+			return
+		}
+
 		c.Set("userID", claims.UserID)
+		c.Set("organizationID", claims.OrganizationID)
+		c.Set("role", claims.Role)
+		c.Set("isAdmin", claims.IsAdmin)
+		c.Set("impersonatorID", claims.ImpersonatorID)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("emailVerified", claims.EmailVerified)
+
+		c.Next()
+	}
+}
+
+func authenticateAccessToken(c *gin.Context, header string) {
+	if !resolveAccessToken(c, header) {
+		return
+	}
+
+	identity := c.MustGet("accessTokenIdentity").(*dto.AccessTokenIdentity)
+
+	// personal access tokens never carry the system admin flag
+	c.Set("userID", identity.UserID)
+	c.Set("organizationID", identity.OrganizationID)
+	c.Set("role", identity.Role)
+	c.Set("emailVerified", identity.EmailVerified)
+	c.Set("accessTokenID", identity.TokenID)
+	c.Set("accessTokenScopes", identity.Scopes)
+
+	c.Next()
+}
+
+// resolveAccessToken authenticates the bearer token once per request, the API key gateway may already have done it
+func resolveAccessToken(c *gin.Context, header string) bool {
+	if _, exists := c.Get("accessTokenIdentity"); exists {
+		return true
+	}
+
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(token) == "" || accessTokens == nil {
+		response.Error(c, response.NewUnauthorized("Unauthorized!! Invalid authorization header"))
+		c.Abort()
+		return false
+	}
+
+	identity, err := accessTokens.Authenticate(strings.TrimSpace(token), c.ClientIP())
+	if err != nil {
+		response.Error(c, err)
+		c.Abort()
+		return false
+	}
+
+	c.Set("accessTokenIdentity", identity)
+	return true
+}
+
+// RequirePermission must run after AuthRequired, the role comes from the access token
+// and applies to the active organization only
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !utils.HasPermission(c.GetString("role"), permission) || !accessTokenAllows(c, permission) {
+			response.Error(c, response.NewForbidden("You don't have permission to perform this action").
+				WithContext("permission", permission))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSession rejects personal access tokens, for account settings that need a signed in browser
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("accessTokenID") != "" {
+			response.Error(c, response.NewForbidden("This endpoint can't be used with an access token"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// accessTokenAllows limits requests made with a personal access token to its scopes
+func accessTokenAllows(c *gin.Context, permission string) bool {
+	if c.GetString("accessTokenID") == "" {
+		return true
+	}
+	return slices.Contains(c.GetStringSlice("accessTokenScopes"), permission)
+}
+
+// RequireVerifiedEmail must run after AuthRequired and only applies when REQUIRE_EMAIL_VERIFICATION is on.
+// The flag comes from the access token, so a refresh is needed right after verifying.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.AppConfig.RequireEmailVerification && !c.GetBool("emailVerified") {
+			response.Error(c, response.NewForbidden("Please verify your email address first"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSystemAdmin must run after AuthRequired, impersonation tokens never carry the admin flag
+func RequireSystemAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("isAdmin") {
+			response.Error(c, response.NewForbidden("Administrator access required"))
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestContext tags every request with an ID and the client IP used by the audit log
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("requestID", requestID)
+		c.Set("clientIP", GetClientIP(c))
+		c.Header(requestIDHeader, requestID)
+
+		c.Next()
+	}
+}
@@ -1,6 +1,11 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +22,24 @@ type User struct {
 	UpdatedAt time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"deletedAt" gorm:"index"`
 
+	// ActiveOrganizationID is the workspace the user's access tokens are issued for
+	ActiveOrganizationID *uuid.UUID `json:"activeOrganizationId" gorm:"type:varchar(36)"`
+
+	// IsAdmin grants the system administration API, DeactivatedAt is set while the account is disabled
+	IsAdmin       bool       `json:"isAdmin" gorm:"not null"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+
+	// EmailVerifiedAt is set once the user opened the verification link, or signed up with Google
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+
+	// GoogleID is the subject of the linked Google account, users who signed up with Google have no password
+	GoogleID *string `json:"googleId" gorm:"type:varchar(64);uniqueIndex"`
+
+	// TwoFactorMethod is empty while two-factor authentication is off, the TOTP secret is never serialized
+	TwoFactorMethod    string     `json:"twoFactorMethod" gorm:"type:varchar(10)"`
+	TwoFactorSecret    string     `json:"-" gorm:"type:varchar(64)"`
+	TwoFactorEnabledAt *time.Time `json:"twoFactorEnabledAt"`
+
 	Assets     []Asset    `json:"assets" gorm:"foreignKey:UserID"`
 	Categories []Category `json:"categories" gorm:"foreignKey:UserID"`
 	Locations  []Location `json:"locations" gorm:"foreignKey:UserID"`
@@ -29,9 +52,112 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+func (u *User) HasGoogle() bool {
+	return u.GoogleID != nil
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) HasTwoFactor() bool {
+	return u.TwoFactorMethod != ""
+}
+
+const (
+	TwoFactorMethodTOTP  = "totp"
+	TwoFactorMethodEmail = "email"
+)
+
+// PersonalAccessToken lets scripts act as the user in one organization, limited to Scopes.
+// Only the hash of the token is stored, Prefix is kept to recognize it in the list.
+type PersonalAccessToken struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	UserID         uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null;index"`
+	OrganizationID uuid.UUID  `json:"organizationId" gorm:"type:varchar(36);not null;index"`
+	Name           string     `json:"name" gorm:"type:varchar(100);not null"`
+	TokenHash      string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Prefix         string     `json:"prefix" gorm:"type:varchar(20);not null"`
+	Scopes         string     `json:"scopes" gorm:"type:varchar(255);not null"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+	LastUsedAt     *time.Time `json:"lastUsedAt"`
+	LastUsedIP     string     `json:"lastUsedIp" gorm:"type:varchar(45)"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+
+	Organization Organization `json:"organization" gorm:"foreignKey:OrganizationID"`
+}
+
+func (t *PersonalAccessToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t *PersonalAccessToken) ScopeList() []string {
+	if t.Scopes == "" {
+		return nil
+	}
+	return strings.Split(t.Scopes, ",")
+}
+
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// TwoFactorRecoveryCode is a one-time code that passes the second factor when the usual one is unavailable
+type TwoFactorRecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	UserID    uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null;index"`
+	CodeHash  string     `json:"-" gorm:"type:varchar(64);not null;index"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (r *TwoFactorRecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// Session is a refresh token family, one per sign in. TokenID is the only refresh token of the
+// family that is still valid, presenting an older one revokes the whole session.
+type Session struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	UserID     uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null;index"`
+	TokenID    string     `json:"-" gorm:"type:varchar(36);not null"`
+	UserAgent  string     `json:"userAgent" gorm:"type:varchar(255)"`
+	IPAddress  string     `json:"ipAddress" gorm:"type:varchar(45)"`
+	LastSeenAt time.Time  `json:"lastSeenAt"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"index"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
 // Location model
 type Location struct {
 	ID        uuid.UUID      `json:"id" gorm:"type:varchar(36);primaryKey"`
+	ParentID  *uuid.UUID     `json:"parentId" gorm:"type:varchar(36);index"`
 	Name      string         `json:"name" gorm:"type:varchar(100);not null"`
 	UserID    *uuid.UUID     `json:"userId" gorm:"type:varchar(36);index"`
 	IsDefault bool           `json:"isDefault" gorm:"default:false"`
@@ -39,8 +165,13 @@ type Location struct {
 	UpdatedAt time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"deletedAt" gorm:"index"`
 
-	Assets []Asset `json:"assets" gorm:"foreignKey:LocationID"`
-	User   *User   `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	// OrganizationID is nil for system locations
+	OrganizationID *uuid.UUID `json:"organizationId" gorm:"type:varchar(36);index"`
+
+	Assets   []Asset    `json:"assets" gorm:"foreignKey:LocationID"`
+	User     *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Parent   *Location  `json:"parent,omitempty" gorm:"foreignKey:ParentID"`
+	Children []Location `json:"children,omitempty" gorm:"foreignKey:ParentID"`
 }
 
 func (l *Location) BeforeCreate(tx *gorm.DB) error {
@@ -60,6 +191,14 @@ type Category struct {
 	UpdatedAt time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"deletedAt" gorm:"index"`
 
+	// OrganizationID is nil for system categories
+	OrganizationID *uuid.UUID `json:"organizationId" gorm:"type:varchar(36);index"`
+
+	// Depreciation settings, nil values are inherited from the parent category
+	DepreciationMethod *string  `json:"depreciationMethod" gorm:"type:varchar(30)"`
+	UsefulLifeYears    *int     `json:"usefulLifeYears"`
+	SalvagePercent     *float64 `json:"salvagePercent" gorm:"type:decimal(5,2)"`
+
 	// Relationships
 	Assets   []Asset    `json:"assets" gorm:"foreignKey:CategoryID"`
 	User     *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -108,14 +247,642 @@ type Asset struct {
 	UpdatedAt    time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 	DeletedAt    gorm.DeletedAt `json:"deletedAt" gorm:"index"`
 
-	Location Location `json:"location" gorm:"foreignKey:LocationID"`
-	Category Category `json:"category" gorm:"foreignKey:CategoryID"`
-	User     User     `json:"user" gorm:"foreignKey:UserID"`
+	// OrganizationID owns the asset, UserID is the member who created it
+	OrganizationID uuid.UUID `json:"organizationId" gorm:"type:varchar(36);index;uniqueIndex:idx_asset_organization_tag,priority:1"`
+
+	// Tag is the human readable label printed on the asset, it's assigned on creation and never reused
+	Tag *string `json:"tag" gorm:"type:varchar(30);uniqueIndex:idx_asset_organization_tag,priority:2"`
+
+	Location    Location          `json:"location" gorm:"foreignKey:LocationID"`
+	Category    Category          `json:"category" gorm:"foreignKey:CategoryID"`
+	User        User              `json:"user" gorm:"foreignKey:UserID"`
+	Loans       []AssetLoan       `json:"loans,omitempty" gorm:"foreignKey:AssetID"`
+	Attachments []AssetAttachment `json:"attachments,omitempty" gorm:"foreignKey:AssetID"`
+
+	CustomFieldValues []AssetCustomFieldValue `json:"customFieldValues,omitempty" gorm:"foreignKey:AssetID"`
 }
 
 func (a *Asset) BeforeCreate(tx *gorm.DB) error {
 	if a.ID == uuid.Nil {
 		a.ID = uuid.New()
 	}
+	if a.Tag == nil && a.OrganizationID != uuid.Nil {
+		tags, err := ReserveAssetTags(tx.Session(&gorm.Session{NewDB: true}), a.OrganizationID, 1)
+		if err != nil {
+			return err
+		}
+		a.Tag = &tags[0]
+	}
+	return nil
+}
+
+// TagValue is the asset's tag or empty when it hasn't been assigned yet
+func (a *Asset) TagValue() string {
+	if a.Tag == nil {
+		return ""
+	}
+	return *a.Tag
+}
+
+// ReserveAssetTags hands out the next count tags of the organization. The counter is bumped first
+// so the organization row stays locked until tx ends and concurrent creates can't get the same tag.
+func ReserveAssetTags(tx *gorm.DB, organizationID uuid.UUID, count int) ([]string, error) {
+	if err := tx.Unscoped().Model(&Organization{}).
+		Where("id = ?", organizationID).
+		Update("asset_tag_counter", gorm.Expr("asset_tag_counter + ?", count)).Error; err != nil {
+		return nil, err
+	}
+
+	var organization Organization
+	if err := tx.Unscoped().Select("asset_tag_prefix", "asset_tag_counter").
+		Where("id = ?", organizationID).
+		First(&organization).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, count)
+	first := organization.AssetTagCounter - count + 1
+	for i := range tags {
+		tags[i] = fmt.Sprintf("%s-%06d", organization.AssetTagPrefix, first+i)
+	}
+	return tags, nil
+}
+
+// AssetMovement records every change of an asset's location
+type AssetMovement struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID        uuid.UUID  `json:"assetId" gorm:"type:varchar(36);not null;index"`
+	FromLocationID *uuid.UUID `json:"fromLocationId" gorm:"type:varchar(36)"`
+	ToLocationID   uuid.UUID  `json:"toLocationId" gorm:"type:varchar(36);not null"`
+	UserID         uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null;index"`
+	Note           string     `json:"note" gorm:"type:varchar(255)"`
+	MovedAt        time.Time  `json:"movedAt" gorm:"not null;index"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+
+	Asset        Asset     `json:"asset" gorm:"foreignKey:AssetID"`
+	FromLocation *Location `json:"fromLocation,omitempty" gorm:"foreignKey:FromLocationID"`
+	ToLocation   Location  `json:"toLocation" gorm:"foreignKey:ToLocationID"`
+	User         User      `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (m *AssetMovement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	if m.MovedAt.IsZero() {
+		m.MovedAt = time.Now()
+	}
+	return nil
+}
+
+// AssetLoan tracks who currently holds a lent asset
+type AssetLoan struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID        uuid.UUID  `json:"assetId" gorm:"type:varchar(36);not null;index"`
+	UserID         uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null;index"`
+	BorrowerUserID *uuid.UUID `json:"borrowerUserId" gorm:"type:varchar(36);index"`
+	BorrowerName   string     `json:"borrowerName" gorm:"type:varchar(100);not null"`
+	CheckedOutAt   time.Time  `json:"checkedOutAt" gorm:"not null"`
+	DueAt          time.Time  `json:"dueAt" gorm:"type:date;not null;index"`
+	ReturnedAt     *time.Time `json:"returnedAt" gorm:"index"`
+	ConditionOut   string     `json:"conditionOut" gorm:"type:varchar(50);not null"`
+	ConditionIn    string     `json:"conditionIn" gorm:"type:varchar(50)"`
+	NoteOut        string     `json:"noteOut" gorm:"type:varchar(255)"`
+	NoteIn         string     `json:"noteIn" gorm:"type:varchar(255)"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Asset        Asset `json:"asset" gorm:"foreignKey:AssetID"`
+	User         User  `json:"user" gorm:"foreignKey:UserID"`
+	BorrowerUser *User `json:"borrowerUser,omitempty" gorm:"foreignKey:BorrowerUserID"`
+}
+
+func (l *AssetLoan) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	if l.CheckedOutAt.IsZero() {
+		l.CheckedOutAt = time.Now()
+	}
+	return nil
+}
+
+// Helper methods
+func (l *AssetLoan) IsActive() bool {
+	return l.ReturnedAt == nil
+}
+
+// IsOverdue reports whether the loan is still open after its due date
+func (l *AssetLoan) IsOverdue() bool {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return l.ReturnedAt == nil && l.DueAt.Before(today)
+}
+
+// DefaultWarrantyLeadDays applies to users who never saved their notification preferences
+const DefaultWarrantyLeadDays = 30
+
+// NotificationPreference holds per-user email notification settings
+type NotificationPreference struct {
+	ID                uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	UserID            uuid.UUID `json:"userId" gorm:"type:varchar(36);not null;uniqueIndex"`
+	WarrantyReminders bool      `json:"warrantyReminders" gorm:"not null"`
+	WarrantyLeadDays  int       `json:"warrantyLeadDays" gorm:"not null"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (p *NotificationPreference) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// WarrantyReminder records a reminder sent to one user, so each recipient gets a warranty date only once
+type WarrantyReminder struct {
+	ID        uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID   uuid.UUID `json:"assetId" gorm:"type:varchar(36);not null;uniqueIndex:idx_warranty_reminder_asset"`
+	UserID    uuid.UUID `json:"userId" gorm:"type:varchar(36);not null;uniqueIndex:idx_warranty_reminder_asset;index"`
+	Warranty  time.Time `json:"warranty" gorm:"type:date;not null;uniqueIndex:idx_warranty_reminder_asset"`
+	SentAt    time.Time `json:"sentAt" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (r *WarrantyReminder) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.SentAt.IsZero() {
+		r.SentAt = time.Now()
+	}
+	return nil
+}
+
+// MaintenancePlan schedules servicing for an asset, either every IntervalDays or once on NextDueAt
+type MaintenancePlan struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID        uuid.UUID      `json:"assetId" gorm:"type:varchar(36);not null;index"`
+	UserID         uuid.UUID      `json:"userId" gorm:"type:varchar(36);not null;index"`
+	Title          string         `json:"title" gorm:"type:varchar(100);not null"`
+	IntervalDays   *int           `json:"intervalDays"`
+	NextDueAt      time.Time      `json:"nextDueAt" gorm:"type:date;not null;index"`
+	Vendor         string         `json:"vendor" gorm:"type:varchar(100)"`
+	EstimatedCost  float64        `json:"estimatedCost" gorm:"type:decimal(10,2);not null"`
+	IsActive       bool           `json:"isActive" gorm:"not null;index"`
+	LastServicedAt *time.Time     `json:"lastServicedAt" gorm:"type:date"`
+	CreatedAt      time.Time      `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt" gorm:"index"`
+
+	Asset Asset `json:"asset" gorm:"foreignKey:AssetID"`
+}
+
+func (p *MaintenancePlan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsOverdue reports whether an active plan has passed its due date
+func (p *MaintenancePlan) IsOverdue() bool {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return p.IsActive && p.NextDueAt.Before(today)
+}
+
+// MaintenanceRecord is a completed service, optionally fulfilling a plan
+type MaintenanceRecord struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID    uuid.UUID  `json:"assetId" gorm:"type:varchar(36);not null;index"`
+	PlanID     *uuid.UUID `json:"planId" gorm:"type:varchar(36);index"`
+	UserID     uuid.UUID  `json:"userId" gorm:"type:varchar(36);not null"`
+	Title      string     `json:"title" gorm:"type:varchar(100);not null"`
+	ServicedAt time.Time  `json:"servicedAt" gorm:"type:date;not null;index"`
+	Vendor     string     `json:"vendor" gorm:"type:varchar(100)"`
+	Cost       float64    `json:"cost" gorm:"type:decimal(10,2);not null"`
+	Notes      string     `json:"notes" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Asset       Asset                   `json:"asset" gorm:"foreignKey:AssetID"`
+	Plan        *MaintenancePlan        `json:"plan,omitempty" gorm:"foreignKey:PlanID"`
+	User        User                    `json:"user" gorm:"foreignKey:UserID"`
+	Attachments []MaintenanceAttachment `json:"attachments" gorm:"foreignKey:RecordID"`
+}
+
+func (r *MaintenanceRecord) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+type MaintenanceAttachment struct {
+	ID        uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	RecordID  uuid.UUID `json:"recordId" gorm:"type:varchar(36);not null;index"`
+	URL       string    `json:"url" gorm:"type:varchar(255);not null"`
+	FileName  string    `json:"fileName" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (a *MaintenanceAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AssetAttachment is an image or document stored for an asset.
+// The primary image is mirrored to Asset.Image so list views keep working.
+type AssetAttachment struct {
+	ID        uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	AssetID   uuid.UUID `json:"assetId" gorm:"type:varchar(36);not null;index"`
+	UserID    uuid.UUID `json:"userId" gorm:"type:varchar(36);not null"`
+	URL       string    `json:"url" gorm:"type:varchar(255);not null"`
+	FileName  string    `json:"fileName" gorm:"type:varchar(255)"`
+	MimeType  string    `json:"mimeType" gorm:"type:varchar(100);not null"`
+	Kind      string    `json:"kind" gorm:"type:varchar(20);not null"` // image, receipt, manual, warranty, other
+	Size      int64     `json:"size"`
+	Position  int       `json:"position" gorm:"not null"`
+	IsPrimary bool      `json:"isPrimary" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (a *AssetAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *AssetAttachment) IsImage() bool {
+	return strings.HasPrefix(a.MimeType, "image/")
+}
+
+// Organization roles, what each role may do is defined in utils/permissions.go
+const (
+	OrganizationRoleOwner   = "owner"
+	OrganizationRoleManager = "manager"
+	OrganizationRoleEditor  = "editor"
+	OrganizationRoleViewer  = "viewer"
+	OrganizationRoleAuditor = "auditor"
+)
+
+// Organization is a workspace whose members share one inventory.
+// Every user gets a personal organization on sign up.
+type Organization struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:varchar(36);primaryKey"`
+	Name       string         `json:"name" gorm:"type:varchar(100);not null"`
+	IsPersonal bool           `json:"isPersonal" gorm:"not null"`
+	OwnerID    uuid.UUID      `json:"ownerId" gorm:"type:varchar(36);not null;index"`
+	CreatedAt  time.Time      `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `json:"deletedAt" gorm:"index"`
+
+	// AssetTagPrefix starts every new asset tag, AssetTagCounter is the last number handed out
+	AssetTagPrefix  string `json:"assetTagPrefix" gorm:"type:varchar(10);not null;default:AST"`
+	AssetTagCounter int    `json:"-" gorm:"not null;default:0"`
+
+	Owner   User                 `json:"owner" gorm:"foreignKey:OwnerID"`
+	Members []OrganizationMember `json:"members,omitempty" gorm:"foreignKey:OrganizationID"`
+}
+
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	if o.AssetTagPrefix == "" {
+		o.AssetTagPrefix = DefaultAssetTagPrefix
+	}
+	return nil
+}
+
+// DefaultAssetTagPrefix is used until an organization configures its own
+const DefaultAssetTagPrefix = "AST"
+
+// PersonalOrganizationName is the default name of a user's own workspace
+func PersonalOrganizationName(user *User) string {
+	if user.Fullname == "" {
+		return "Personal Workspace"
+	}
+	return user.Fullname + "'s Workspace"
+}
+
+type OrganizationMember struct {
+	ID             uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	OrganizationID uuid.UUID `json:"organizationId" gorm:"type:varchar(36);not null;uniqueIndex:idx_organization_member"`
+	UserID         uuid.UUID `json:"userId" gorm:"type:varchar(36);not null;uniqueIndex:idx_organization_member;index"`
+	Role           string    `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Organization Organization `json:"organization" gorm:"foreignKey:OrganizationID"`
+	User         User         `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (m *OrganizationMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditEntityAsset    = "asset"
+	AuditEntityCategory = "category"
+	AuditEntityLocation = "location"
+	AuditEntityLoan     = "loan"
+	AuditEntityUser     = "user"
+)
+
+// ErrAuditLogAppendOnly is returned when something tries to change a written audit entry
+var ErrAuditLogAppendOnly = errors.New("audit log entries are append-only")
+
+// AuditLog is append-only, it has no UpdatedAt or DeletedAt and refuses updates and deletes
+type AuditLog struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:varchar(36);primaryKey"`
+	OrganizationID *uuid.UUID      `json:"organizationId" gorm:"type:varchar(36);index"`
+	ActorID        *uuid.UUID      `json:"actorId" gorm:"type:varchar(36);index"`
+	ImpersonatorID *uuid.UUID      `json:"impersonatorId" gorm:"type:varchar(36)"`
+	Action         string          `json:"action" gorm:"type:varchar(20);not null"`
+	Entity         string          `json:"entity" gorm:"type:varchar(30);not null;index:idx_audit_entity"`
+	EntityID       string          `json:"entityId" gorm:"type:varchar(36);not null;index:idx_audit_entity"`
+	Before         json.RawMessage `json:"before" gorm:"type:json"`
+	After          json.RawMessage `json:"after" gorm:"type:json"`
+	IPAddress      string          `json:"ipAddress" gorm:"type:varchar(45)"`
+	UserAgent      string          `json:"userAgent" gorm:"type:varchar(255)"`
+	RequestID      string          `json:"requestId" gorm:"type:varchar(64);index"`
+	CreatedAt      time.Time       `json:"createdAt" gorm:"autoCreateTime;index"`
+
+	Actor *User `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *AuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
+
+func (a *AuditLog) BeforeDelete(tx *gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
+
+const (
+	WebhookEventAssetCreated          = "asset.created"
+	WebhookEventAssetUpdated          = "asset.updated"
+	WebhookEventAssetMoved            = "asset.moved"
+	WebhookEventAssetConditionChanged = "asset.condition_changed"
+	WebhookEventAssetDeleted          = "asset.deleted"
+	WebhookEventAssetCheckedOut       = "asset.checked_out"
+	WebhookEventCategoryCreated       = "category.created"
+	WebhookEventCategoryUpdated       = "category.updated"
+	WebhookEventCategoryDeleted       = "category.deleted"
+	WebhookEventLocationCreated       = "location.created"
+	WebhookEventLocationUpdated       = "location.updated"
+	WebhookEventLocationDeleted       = "location.deleted"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an endpoint of an organization that receives the subscribed Events, comma separated.
+// The secret signs every delivery and is never serialized.
+type Webhook struct {
+	ID             uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	OrganizationID uuid.UUID `json:"organizationId" gorm:"type:varchar(36);not null;index"`
+	CreatedBy      uuid.UUID `json:"createdBy" gorm:"type:varchar(36);not null"`
+	Name           string    `json:"name" gorm:"type:varchar(100);not null"`
+	URL            string    `json:"url" gorm:"type:varchar(500);not null"`
+	Secret         string    `json:"-" gorm:"type:varchar(100);not null"`
+	Events         string    `json:"events" gorm:"type:varchar(500);not null"`
+	IsActive       bool      `json:"isActive" gorm:"not null"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+func (w *Webhook) EventList() []string {
+	if w.Events == "" {
+		return nil
+	}
+	return strings.Split(w.Events, ",")
+}
+
+func (w *Webhook) Subscribes(event string) bool {
+	return slices.Contains(w.EventList(), event)
+}
+
+// WebhookDelivery is one event sent to one webhook, retried until it succeeds or runs out of attempts.
+// Redeliveries are new rows that keep the EventID so receivers can deduplicate.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:varchar(36);primaryKey"`
+	WebhookID      uuid.UUID       `json:"webhookId" gorm:"type:varchar(36);not null;index"`
+	EventID        uuid.UUID       `json:"eventId" gorm:"type:varchar(36);not null;index"`
+	Event          string          `json:"event" gorm:"type:varchar(50);not null"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:json;not null"`
+	Status         string          `json:"status" gorm:"type:varchar(20);not null;index:idx_webhook_delivery_due"`
+	Attempts       int             `json:"attempts" gorm:"not null"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt" gorm:"index:idx_webhook_delivery_due"`
+	ResponseStatus int             `json:"responseStatus"`
+	ResponseBody   string          `json:"responseBody" gorm:"type:text"`
+	Error          string          `json:"error" gorm:"type:varchar(500)"`
+	DurationMs     int64           `json:"durationMs"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	RedeliveryOf   *uuid.UUID      `json:"redeliveryOf" gorm:"type:varchar(36)"`
+	CreatedAt      time.Time       `json:"createdAt" gorm:"autoCreateTime;index"`
+	UpdatedAt      time.Time       `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Webhook *Webhook `json:"webhook,omitempty" gorm:"foreignKey:WebhookID"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+const (
+	StockTakeOpen      = "open"
+	StockTakeClosed    = "closed"
+	StockTakeCancelled = "cancelled"
+)
+
+const (
+	StockTakeItemPending        = "pending"
+	StockTakeItemFound          = "found"
+	StockTakeItemMissing        = "missing"
+	StockTakeItemFoundElsewhere = "found_elsewhere"
+)
+
+// StockTake is a physical inventory audit of one or more locations. The expected assets are
+// snapshotted into items when it starts so later moves don't change what was audited.
+type StockTake struct {
+	ID                 uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	OrganizationID     uuid.UUID  `json:"organizationId" gorm:"type:varchar(36);not null;index"`
+	Name               string     `json:"name" gorm:"type:varchar(100);not null"`
+	Note               string     `json:"note" gorm:"type:varchar(255)"`
+	Status             string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	StartedBy          uuid.UUID  `json:"startedBy" gorm:"type:varchar(36);not null"`
+	ClosedBy           *uuid.UUID `json:"closedBy" gorm:"type:varchar(36)"`
+	ClosedAt           *time.Time `json:"closedAt"`
+	CorrectionsApplied bool       `json:"correctionsApplied" gorm:"not null"`
+	CreatedAt          time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Locations []StockTakeLocation `json:"locations,omitempty" gorm:"foreignKey:StockTakeID"`
+}
+
+func (s *StockTake) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *StockTake) IsOpen() bool {
+	return s.Status == StockTakeOpen
+}
+
+func (s *StockTake) HasLocation(locationID uuid.UUID) bool {
+	return slices.ContainsFunc(s.Locations, func(l StockTakeLocation) bool { return l.LocationID == locationID })
+}
+
+type StockTakeLocation struct {
+	StockTakeID uuid.UUID `json:"stockTakeId" gorm:"type:varchar(36);primaryKey"`
+	LocationID  uuid.UUID `json:"locationId" gorm:"type:varchar(36);primaryKey;index"`
+
+	Location Location `json:"location" gorm:"foreignKey:LocationID"`
+}
+
+// StockTakeItem is one asset of a stock-take. Unlisted items were scanned during the audit but
+// weren't expected at any of the audited locations.
+type StockTakeItem struct {
+	ID                 uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+	StockTakeID        uuid.UUID  `json:"stockTakeId" gorm:"type:varchar(36);not null;uniqueIndex:idx_stock_take_item_asset"`
+	AssetID            uuid.UUID  `json:"assetId" gorm:"type:varchar(36);not null;uniqueIndex:idx_stock_take_item_asset"`
+	ExpectedLocationID uuid.UUID  `json:"expectedLocationId" gorm:"type:varchar(36);not null"`
+	ExpectedCondition  string     `json:"expectedCondition" gorm:"type:varchar(50);not null"`
+	Unlisted           bool       `json:"unlisted" gorm:"not null"`
+	Status             string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	ObservedLocationID *uuid.UUID `json:"observedLocationId" gorm:"type:varchar(36)"`
+	ObservedCondition  string     `json:"observedCondition" gorm:"type:varchar(50)"`
+	Note               string     `json:"note" gorm:"type:varchar(255)"`
+	CheckedBy          *uuid.UUID `json:"checkedBy" gorm:"type:varchar(36)"`
+	CheckedAt          *time.Time `json:"checkedAt"`
+	CreatedAt          time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	Asset            Asset     `json:"asset" gorm:"foreignKey:AssetID"`
+	ExpectedLocation Location  `json:"expectedLocation" gorm:"foreignKey:ExpectedLocationID"`
+	ObservedLocation *Location `json:"observedLocation,omitempty" gorm:"foreignKey:ObservedLocationID"`
+}
+
+func (i *StockTakeItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// ConditionChanged reports whether the auditor recorded a condition other than the expected one
+func (i *StockTakeItem) ConditionChanged() bool {
+	return i.ObservedCondition != "" && i.ObservedCondition != i.ExpectedCondition
+}
+
+const (
+	CustomFieldText    = "text"
+	CustomFieldNumber  = "number"
+	CustomFieldDate    = "date"
+	CustomFieldBoolean = "boolean"
+	CustomFieldEnum    = "enum"
+)
+
+// CustomField is an extra asset attribute an organization defines on a category, it applies to
+// assets of that category and of every category below it. Fields are scoped to the organization
+// so fields on shared system categories stay private.
+type CustomField struct {
+	ID             uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+	OrganizationID uuid.UUID `json:"organizationId" gorm:"type:varchar(36);not null;index;uniqueIndex:idx_custom_field_key,priority:1"`
+	CategoryID     uuid.UUID `json:"categoryId" gorm:"type:varchar(36);not null;index;uniqueIndex:idx_custom_field_key,priority:2"`
+	Key            string    `json:"key" gorm:"type:varchar(50);not null;uniqueIndex:idx_custom_field_key,priority:3"`
+	Label          string    `json:"label" gorm:"type:varchar(100);not null"`
+	Type           string    `json:"type" gorm:"type:varchar(20);not null"`
+	Options        []string  `json:"options" gorm:"type:text;serializer:json"`
+	Required       bool      `json:"required" gorm:"not null"`
+	Position       int       `json:"position" gorm:"not null"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (f *CustomField) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// ValueColumn is the AssetCustomFieldValue column that holds values of the field's type
+func (f *CustomField) ValueColumn() string {
+	switch f.Type {
+	case CustomFieldNumber:
+		return "number_value"
+	case CustomFieldDate:
+		return "date_value"
+	case CustomFieldBoolean:
+		return "bool_value"
+	default:
+		return "text_value"
+	}
+}
+
+// AssetCustomFieldValue stores one value in the column of its field's type so assets can be
+// filtered and sorted by it
+type AssetCustomFieldValue struct {
+	AssetID     uuid.UUID  `json:"assetId" gorm:"type:varchar(36);primaryKey"`
+	FieldID     uuid.UUID  `json:"fieldId" gorm:"type:varchar(36);primaryKey;index:idx_custom_value_text,priority:1;index:idx_custom_value_number,priority:1;index:idx_custom_value_date,priority:1"`
+	TextValue   *string    `json:"textValue" gorm:"type:varchar(255);index:idx_custom_value_text,priority:2"`
+	NumberValue *float64   `json:"numberValue" gorm:"type:decimal(20,4);index:idx_custom_value_number,priority:2"`
+	DateValue   *time.Time `json:"dateValue" gorm:"type:date;index:idx_custom_value_date,priority:2"`
+	BoolValue   *bool      `json:"boolValue"`
+
+	Field CustomField `json:"field" gorm:"foreignKey:FieldID"`
+}
+
+// Value returns the stored value as text, number, date (2006-01-02) or bool
+func (v *AssetCustomFieldValue) Value() any {
+	switch {
+	case v.NumberValue != nil:
+		return *v.NumberValue
+	case v.DateValue != nil:
+		return v.DateValue.Format("2006-01-02")
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.TextValue != nil:
+		return *v.TextValue
+	}
 	return nil
 }
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type AccessTokenRepository interface {
+	Create(data *models.PersonalAccessToken) error
+	Delete(data *models.PersonalAccessToken) error
+	GetByID(id string) (*models.PersonalAccessToken, error)
+	GetByHash(tokenHash string) (*models.PersonalAccessToken, error)
+	GetByUserID(userID string) ([]models.PersonalAccessToken, error)
+	TouchLastUsed(id string, ip string, now time.Time) error
+}
+
+type accessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessTokenRepository(db *gorm.DB) AccessTokenRepository {
+	return &accessTokenRepository{db}
+}
+
+func (r *accessTokenRepository) Create(data *models.PersonalAccessToken) error {
+	return r.db.Create(data).Error
+}
+
+func (r *accessTokenRepository) Delete(data *models.PersonalAccessToken) error {
+	return r.db.Delete(data).Error
+}
+
+func (r *accessTokenRepository) GetByID(id string) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &token, err
+}
+
+func (r *accessTokenRepository) GetByHash(tokenHash string) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &token, err
+}
+
+func (r *accessTokenRepository) GetByUserID(userID string) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	err := r.db.Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// TouchLastUsed records usage at most once a minute, so busy scripts don't write on every request
+func (r *accessTokenRepository) TouchLastUsed(id string, ip string, now time.Time) error {
+	return r.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-time.Minute)).
+		Updates(map[string]any{"last_used_at": now, "last_used_ip": ip}).Error
+}
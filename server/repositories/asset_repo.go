@@ -2,36 +2,73 @@ package repositories
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AssetRepository interface {
 	Create(asset *models.Asset) error
 	Update(asset *models.Asset) error
+	CreateWithMovement(asset *models.Asset, movement *models.AssetMovement) error
+	UpdateWithMovement(asset *models.Asset, movement *models.AssetMovement) error
+	UpdateWithCustomFields(asset *models.Asset, movement *models.AssetMovement, values []models.AssetCustomFieldValue) error
+	BulkCreateWithMovements(assets []models.Asset, movements []models.AssetMovement) error
 	Delete(asset *models.Asset) error
 	GetByID(id string) (*models.Asset, error)
-	GetByIDAndUserID(id, userID string) (*models.Asset, error)
+	GetByIDAndOrganizationID(id, organizationID string) (*models.Asset, error)
+	GetByTagAndOrganizationID(tag, organizationID string) (*models.Asset, error)
+	GetAllByOrganizationID(organizationID string) ([]models.Asset, error)
 	GetAssetsWithFilter(filter AssetFilter) ([]models.Asset, int, error)
+	ExportAssets(filter AssetFilter, batchSize int, fn func(batch []models.Asset) error) error
 }
 
 type AssetFilter struct {
-	UserID     string
-	Search     string
-	CategoryID string
-	LocationID string
-	Condition  string
-	MinPrice   *float64
-	MaxPrice   *float64
-	SortBy     string
-	SortOrder  string
-	Page       int
-	Limit      int
+	OrganizationID string
+	IDs            []string
+	Search         string
+	CategoryID     string
+	LocationID     string
+	Condition      string
+	MinPrice       *float64
+	MaxPrice       *float64
+	Availability   string
+	SortBy         string
+	SortOrder      string
+	Page           int
+	Limit          int
+
+	// LocationIDs takes precedence over LocationID, it holds a location together with its sub-locations
+	LocationIDs []string
+
+	CustomFields    []CustomFieldFilter
+	CustomFieldSort *CustomFieldSort
+}
+
+// CustomFieldFilter matches assets with a value of one of the fields, Column and Operator come
+// from models.CustomField.ValueColumn and a fixed set of comparisons, never from user input
+type CustomFieldFilter struct {
+	FieldIDs []string
+	Column   string
+	Operator string
+	Value    any
+}
+
+// CustomFieldSort orders by the value of one of the fields, assets without a value sort first
+type CustomFieldSort struct {
+	FieldIDs []string
+	Column   string
 }
 
+var (
+	customFieldColumns   = []string{"text_value", "number_value", "date_value", "bool_value"}
+	customFieldOperators = []string{"=", ">=", "<="}
+)
+
 type assetRepository struct {
 	db *gorm.DB
 }
@@ -45,11 +82,96 @@ func (r *assetRepository) Create(asset *models.Asset) error {
 }
 
 func (r *assetRepository) Update(asset *models.Asset) error {
-	return r.db.Save(asset).Error
+	// preloaded relations would otherwise overwrite the foreign keys
+	return r.db.Omit(clause.Associations).Save(asset).Error
+}
+
+func (r *assetRepository) CreateWithMovement(asset *models.Asset, movement *models.AssetMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(asset).Error; err != nil {
+			return err
+		}
+		movement.AssetID = asset.ID
+		return tx.Create(movement).Error
+	})
+}
+
+// UpdateWithCustomFields saves the asset and replaces its custom field values, movement is optional
+// and nil values leave the stored values untouched
+func (r *assetRepository) UpdateWithCustomFields(asset *models.Asset, movement *models.AssetMovement, values []models.AssetCustomFieldValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(asset).Error; err != nil {
+			return err
+		}
+		if movement != nil {
+			if err := tx.Create(movement).Error; err != nil {
+				return err
+			}
+		}
+		if values == nil {
+			return nil
+		}
+
+		if err := tx.Where("asset_id = ?", asset.ID).Delete(&models.AssetCustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		for i := range values {
+			values[i].AssetID = asset.ID
+		}
+		return tx.Omit(clause.Associations).Create(&values).Error
+	})
+}
+
+func (r *assetRepository) UpdateWithMovement(asset *models.Asset, movement *models.AssetMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(asset).Error; err != nil {
+			return err
+		}
+		return tx.Create(movement).Error
+	})
 }
 
+// Delete soft deletes the asset and drops the rows of its stored files, removing the files is up to the caller
 func (r *assetRepository) Delete(asset *models.Asset) error {
-	return r.db.Delete(asset).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("asset_id = ?", asset.ID).Delete(&models.AssetAttachment{}).Error; err != nil {
+			return err
+		}
+		records := tx.Model(&models.MaintenanceRecord{}).Select("id").Where("asset_id = ?", asset.ID)
+		if err := tx.Where("record_id IN (?)", records).Delete(&models.MaintenanceAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(asset).Error
+	})
+}
+
+// BulkCreateWithMovements inserts all assets or none of them
+func (r *assetRepository) BulkCreateWithMovements(assets []models.Asset, movements []models.AssetMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).CreateInBatches(&assets, 100).Error; err != nil {
+			return err
+		}
+		var values []models.AssetCustomFieldValue
+		for i := range assets {
+			for _, value := range assets[i].CustomFieldValues {
+				value.AssetID = assets[i].ID
+				values = append(values, value)
+			}
+		}
+		if len(values) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&values, 200).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range movements {
+			movements[i].AssetID = assets[i].ID
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(&movements, 100).Error
+	})
 }
 
 func (r *assetRepository) GetByID(id string) (*models.Asset, error) {
@@ -65,10 +187,29 @@ func (r *assetRepository) GetByID(id string) (*models.Asset, error) {
 	return &asset, nil
 }
 
-func (r *assetRepository) GetByIDAndUserID(id, userID string) (*models.Asset, error) {
+func (r *assetRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.Asset, error) {
+	var asset models.Asset
+	err := r.db.Preload("Location").Preload("Category").Preload("User").
+		Preload("Loans", "returned_at IS NULL").
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC, created_at ASC") }).
+		Preload("CustomFieldValues.Field").
+		Where("id = ? AND organization_id = ?", id, organizationID).First(&asset).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *assetRepository) GetByTagAndOrganizationID(tag, organizationID string) (*models.Asset, error) {
 	var asset models.Asset
 	err := r.db.Preload("Location").Preload("Category").Preload("User").
-		Where("id = ? AND user_id = ?", id, userID).First(&asset).Error
+		Preload("Loans", "returned_at IS NULL").
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC, created_at ASC") }).
+		Preload("CustomFieldValues.Field").
+		Where("tag = ? AND organization_id = ?", tag, organizationID).First(&asset).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -78,30 +219,99 @@ func (r *assetRepository) GetByIDAndUserID(id, userID string) (*models.Asset, er
 	return &asset, nil
 }
 
+func (r *assetRepository) GetAllByOrganizationID(organizationID string) ([]models.Asset, error) {
+	var assets []models.Asset
+	err := r.db.Preload("Category").Preload("Location").
+		Where("organization_id = ?", organizationID).
+		Order("purchase_date ASC, name ASC").
+		Find(&assets).Error
+	return assets, err
+}
+
 func (r *assetRepository) GetAssetsWithFilter(filter AssetFilter) ([]models.Asset, int, error) {
 	var assets []models.Asset
 	var totalCount int64
 
 	// Build query
-	query := r.db.Model(&models.Asset{}).Where("user_id = ?", filter.UserID)
+	query := r.applyFilters(r.db.Model(&models.Asset{}), filter)
+
+	// Count total records
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply sorting
+	query = r.applyOrder(query, filter)
+
+	// Apply pagination
+	offset := (filter.Page - 1) * filter.Limit
+	query = query.Offset(offset).Limit(filter.Limit)
+
+	// Execute query with preloading
+	err := query.Preload("Location").Preload("Category").
+		Preload("Loans", "returned_at IS NULL").
+		Preload("CustomFieldValues.Field").
+		Find(&assets).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return assets, int(totalCount), nil
+}
+
+// ExportAssets walks every asset matching the filter in sorted batches, ignoring pagination
+func (r *assetRepository) ExportAssets(filter AssetFilter, batchSize int, fn func(batch []models.Asset) error) error {
+	query := r.applyOrder(r.applyFilters(r.db.Model(&models.Asset{}), filter), filter)
+
+	for offset := 0; ; offset += batchSize {
+		var batch []models.Asset
+		err := query.Session(&gorm.Session{}).
+			Preload("Location").
+			Offset(offset).Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (r *assetRepository) applyFilters(query *gorm.DB, filter AssetFilter) *gorm.DB {
+	query = query.Where("organization_id = ?", filter.OrganizationID)
+
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
 
-	// Apply filters
 	if filter.Search != "" {
 		searchTerm := "%" + strings.ToLower(filter.Search) + "%"
-		query = query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(serial_number) LIKE ?",
-			searchTerm, searchTerm, searchTerm)
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(serial_number) LIKE ? OR LOWER(tag) LIKE ?",
+			searchTerm, searchTerm, searchTerm, searchTerm)
 	}
 
 	if filter.CategoryID != "" {
 		query = query.Where("category_id = ?", filter.CategoryID)
 	}
 
-	if filter.LocationID != "" {
+	if len(filter.LocationIDs) > 0 {
+		query = query.Where("location_id IN ?", filter.LocationIDs)
+	} else if filter.LocationID != "" {
 		query = query.Where("location_id = ?", filter.LocationID)
 	}
 
 	if filter.Condition != "" {
-		query = query.Where("condition = ?", filter.Condition)
+		query = query.Where("`condition` = ?", filter.Condition)
 	}
 
 	if filter.MinPrice != nil {
@@ -112,28 +322,46 @@ func (r *assetRepository) GetAssetsWithFilter(filter AssetFilter) ([]models.Asse
 		query = query.Where("price <= ?", *filter.MaxPrice)
 	}
 
-	// Count total records
-	if err := query.Count(&totalCount).Error; err != nil {
-		return nil, 0, err
+	if filter.Availability != "" {
+		activeLoans := r.db.Model(&models.AssetLoan{}).Select("asset_id").Where("returned_at IS NULL")
+
+		switch filter.Availability {
+		case "available":
+			query = query.Where("id NOT IN (?)", activeLoans)
+		case "checked-out":
+			query = query.Where("id IN (?)", activeLoans)
+		case "overdue":
+			query = query.Where("id IN (?)", activeLoans.Where("due_at < CURDATE()"))
+		}
 	}
 
-	// Apply sorting
-	orderBy := r.buildOrderBy(filter.SortBy, filter.SortOrder)
-	if orderBy != "" {
-		query = query.Order(orderBy)
+	for _, cf := range filter.CustomFields {
+		if !slices.Contains(customFieldColumns, cf.Column) || !slices.Contains(customFieldOperators, cf.Operator) {
+			continue
+		}
+		query = query.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM asset_custom_field_values v WHERE v.asset_id = assets.id AND v.field_id IN ? AND v.%s %s ?)", cf.Column, cf.Operator),
+			cf.FieldIDs, cf.Value)
 	}
 
-	// Apply pagination
-	offset := (filter.Page - 1) * filter.Limit
-	query = query.Offset(offset).Limit(filter.Limit)
+	return query
+}
 
-	// Execute query with preloading
-	err := query.Preload("Location").Preload("Category").Find(&assets).Error
-	if err != nil {
-		return nil, 0, err
+// applyOrder sorts by the requested column or custom field, id breaks ties so batches and pages are stable
+func (r *assetRepository) applyOrder(query *gorm.DB, filter AssetFilter) *gorm.DB {
+	sort := filter.CustomFieldSort
+	if sort == nil || !slices.Contains(customFieldColumns, sort.Column) {
+		return query.Order(r.buildOrderBy(filter.SortBy, filter.SortOrder)).Order("id ASC")
 	}
 
-	return assets, int(totalCount), nil
+	order := "ASC"
+	if filter.SortOrder == "desc" {
+		order = "DESC"
+	}
+	// gorm drops an order expression when columns are added later, so the tie breakers are part of it
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  fmt.Sprintf("(SELECT v.%s FROM asset_custom_field_values v WHERE v.asset_id = assets.id AND v.field_id IN ? LIMIT 1) %s, created_at DESC, id ASC", sort.Column, order),
+		Vars: []any{sort.FieldIDs},
+	}})
 }
 
 func (r *assetRepository) buildOrderBy(sortBy, sortOrder string) string {
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(attachments []models.AssetAttachment) error
+	Delete(attachment *models.AssetAttachment) error
+	GetByIDAndAssetID(id, assetID string) (*models.AssetAttachment, error)
+	GetByAssetID(assetID string) ([]models.AssetAttachment, error)
+	GetNextPosition(assetID string) (int, error)
+	UpdatePositions(assetID string, orderedIDs []string) error
+	SetPrimary(assetID string, attachment *models.AssetAttachment) error
+	GetStoredFileURLs(assetID string) ([]string, error)
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db}
+}
+
+func (r *attachmentRepository) Create(attachments []models.AssetAttachment) error {
+	return r.db.Create(&attachments).Error
+}
+
+func (r *attachmentRepository) Delete(attachment *models.AssetAttachment) error {
+	return r.db.Delete(attachment).Error
+}
+
+func (r *attachmentRepository) GetByIDAndAssetID(id, assetID string) (*models.AssetAttachment, error) {
+	var attachment models.AssetAttachment
+	err := r.db.Where("id = ? AND asset_id = ?", id, assetID).First(&attachment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &attachment, err
+}
+
+func (r *attachmentRepository) GetByAssetID(assetID string) ([]models.AssetAttachment, error) {
+	var attachments []models.AssetAttachment
+	err := r.db.Where("asset_id = ?", assetID).
+		Order("position ASC, created_at ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) GetNextPosition(assetID string) (int, error) {
+	var position int
+	err := r.db.Model(&models.AssetAttachment{}).
+		Select("COALESCE(MAX(position), -1) + 1").
+		Where("asset_id = ?", assetID).
+		Scan(&position).Error
+	return position, err
+}
+
+// UpdatePositions stores the given order, orderedIDs must contain every attachment of the asset
+func (r *attachmentRepository) UpdatePositions(assetID string, orderedIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range orderedIDs {
+			if err := tx.Model(&models.AssetAttachment{}).
+				Where("id = ? AND asset_id = ?", id, assetID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetPrimary flags one image as primary and mirrors it to the asset, a nil attachment clears the primary image
+func (r *attachmentRepository) SetPrimary(assetID string, attachment *models.AssetAttachment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.AssetAttachment{}).
+			Where("asset_id = ? AND is_primary = ?", assetID, true).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		image := ""
+		if attachment != nil {
+			if err := tx.Model(attachment).Update("is_primary", true).Error; err != nil {
+				return err
+			}
+			image = attachment.URL
+		}
+
+		return tx.Model(&models.Asset{}).Where("id = ?", assetID).Update("image", image).Error
+	})
+}
+
+// GetStoredFileURLs returns every file kept for an asset, including maintenance attachments
+func (r *attachmentRepository) GetStoredFileURLs(assetID string) ([]string, error) {
+	var urls []string
+	if err := r.db.Model(&models.AssetAttachment{}).
+		Where("asset_id = ?", assetID).
+		Pluck("url", &urls).Error; err != nil {
+		return nil, err
+	}
+
+	var maintenanceURLs []string
+	if err := r.db.Model(&models.MaintenanceAttachment{}).
+		Joins("JOIN maintenance_records ON maintenance_records.id = maintenance_attachments.record_id").
+		Where("maintenance_records.asset_id = ?", assetID).
+		Pluck("maintenance_attachments.url", &maintenanceURLs).Error; err != nil {
+		return nil, err
+	}
+
+	return append(urls, maintenanceURLs...), nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository only appends and reads, audit entries are never changed
+type AuditRepository interface {
+	Create(data *models.AuditLog) error
+	GetAuditLogsWithFilter(filter AuditFilter) ([]models.AuditLog, int, error)
+}
+
+type AuditFilter struct {
+	// OrganizationID is empty for the system wide log
+	OrganizationID string
+	Entity         string
+	EntityID       string
+	ActorID        string
+	From           *time.Time
+	To             *time.Time
+	Page           int
+	Limit          int
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db}
+}
+
+func (r *auditRepository) Create(data *models.AuditLog) error {
+	return r.db.Omit("Actor").Create(data).Error
+}
+
+func (r *auditRepository) GetAuditLogsWithFilter(filter AuditFilter) ([]models.AuditLog, int, error) {
+	var logs []models.AuditLog
+	var totalCount int64
+
+	query := r.db.Model(&models.AuditLog{})
+
+	if filter.OrganizationID != "" {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// actors may have been deleted since, keep them in the log
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.
+		Preload("Actor", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("created_at DESC").
+		Offset(offset).Limit(filter.Limit).
+		Find(&logs).Error
+	return logs, int(totalCount), err
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CategoryRepository interface {
@@ -12,16 +15,21 @@ type CategoryRepository interface {
 	Update(data *models.Category) error
 	Delete(data *models.Category) error
 	GetByID(id string) (*models.Category, error)
-	GetByIDAndUserID(id, userID string) (*models.Category, error)
-	GetAllUserCategories(userID string) ([]models.Category, error)
-	GetParentCategories(userID string) ([]models.Category, error)
-	GetChildCategories(parentID, userID string) ([]models.Category, error)
-	GetCategoryTree(userID string) ([]models.Category, error)
-	CheckNameExists(name, userID string, parentID *string) (bool, error)
-	GetAssetsByCategory(categoryID, userID string) ([]models.Asset, error)
-	CountAssetsByCategory(categoryID, userID string) (int64, error)
+	GetByIDAndOrganizationID(id, organizationID string) (*models.Category, error)
+	GetAllOrganizationCategories(organizationID string) ([]models.Category, error)
+	GetParentCategories(organizationID string) ([]models.Category, error)
+	GetChildCategories(parentID, organizationID string) ([]models.Category, error)
+	CheckNameExists(name, organizationID string, parentID *string) (bool, error)
+	GetAssetsByCategories(categoryIDs []uuid.UUID, organizationID string) ([]models.Asset, error)
+	CountAssetsByCategory(categoryID, organizationID string) (int64, error)
 	CountChildCategories(parentID string) (int64, error)
-	ValidateParentAccess(parentID, userID string) (bool, error)
+	ValidateParentAccess(parentID, organizationID string) (bool, error)
+
+	// system default categories
+	GetSystemByID(id string) (*models.Category, error)
+	GetSystemCategories() ([]models.Category, error)
+	CheckSystemNameExists(name string, parentID *string) (bool, error)
+	CountAllAssetsByCategory(categoryID string) (int64, error)
 }
 
 type categoryRepository struct {
@@ -36,12 +44,19 @@ func (r *categoryRepository) Create(data *models.Category) error {
 	return r.db.Create(data).Error
 }
 
+// Update leaves associations alone, a preloaded Parent would otherwise win over a changed ParentID
 func (r *categoryRepository) Update(data *models.Category) error {
-	return r.db.Save(data).Error
+	return r.db.Omit(clause.Associations).Save(data).Error
 }
 
+// Delete removes the category and the custom fields defined on it, a category in use has no values left
 func (r *categoryRepository) Delete(data *models.Category) error {
-	return r.db.Delete(data).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", data.ID).Delete(&models.CustomField{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(data).Error
+	})
 }
 
 func (r *categoryRepository) GetByID(id string) (*models.Category, error) {
@@ -53,53 +68,44 @@ func (r *categoryRepository) GetByID(id string) (*models.Category, error) {
 	return &category, err
 }
 
-func (r *categoryRepository) GetByIDAndUserID(id, userID string) (*models.Category, error) {
+func (r *categoryRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.Category, error) {
 	var category models.Category
-	err := r.db.Preload("Parent").Where("id = ? AND user_id = ?", id, userID).First(&category).Error
+	err := r.db.Preload("Parent").Where("id = ? AND organization_id = ?", id, organizationID).First(&category).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return &category, err
 }
 
-func (r *categoryRepository) GetAllUserCategories(userID string) ([]models.Category, error) {
+func (r *categoryRepository) GetAllOrganizationCategories(organizationID string) ([]models.Category, error) {
 	var categories []models.Category
 	err := r.db.Preload("Parent").
-		Where("user_id IS NULL OR user_id = ?", userID).
+		Where("organization_id IS NULL OR organization_id = ?", organizationID).
 		Order("parent_id IS NULL DESC, name ASC").
 		Find(&categories).Error
 	return categories, err
 }
 
-func (r *categoryRepository) GetParentCategories(userID string) ([]models.Category, error) {
-	var categories []models.Category
-	err := r.db.Where("parent_id IS NULL AND (user_id IS NULL OR user_id = ?)", userID).
-		Order("is_default DESC, name ASC").
-		Find(&categories).Error
-	return categories, err
-}
-
-func (r *categoryRepository) GetChildCategories(parentID, userID string) ([]models.Category, error) {
+func (r *categoryRepository) GetParentCategories(organizationID string) ([]models.Category, error) {
 	var categories []models.Category
-	err := r.db.Where("parent_id = ? AND (user_id IS NULL OR user_id = ?)", parentID, userID).
+	err := r.db.Where("parent_id IS NULL AND (organization_id IS NULL OR organization_id = ?)", organizationID).
 		Order("is_default DESC, name ASC").
 		Find(&categories).Error
 	return categories, err
 }
 
-func (r *categoryRepository) GetCategoryTree(userID string) ([]models.Category, error) {
+func (r *categoryRepository) GetChildCategories(parentID, organizationID string) ([]models.Category, error) {
 	var categories []models.Category
-	err := r.db.Preload("Children", "user_id IS NULL OR user_id = ?", userID).
-		Where("parent_id IS NULL AND (user_id IS NULL OR user_id = ?)", userID).
+	err := r.db.Where("parent_id = ? AND (organization_id IS NULL OR organization_id = ?)", parentID, organizationID).
 		Order("is_default DESC, name ASC").
 		Find(&categories).Error
 	return categories, err
 }
 
-func (r *categoryRepository) CheckNameExists(name, userID string, parentID *string) (bool, error) {
+func (r *categoryRepository) CheckNameExists(name, organizationID string, parentID *string) (bool, error) {
 	var count int64
 	query := r.db.Model(&models.Category{}).
-		Where("LOWER(name) = LOWER(?) AND (user_id IS NULL OR user_id = ?)", name, userID)
+		Where("LOWER(name) = LOWER(?) AND (organization_id IS NULL OR organization_id = ?)", name, organizationID)
 
 	if parentID != nil {
 		query = query.Where("parent_id = ?", *parentID)
@@ -111,18 +117,18 @@ func (r *categoryRepository) CheckNameExists(name, userID string, parentID *stri
 	return count > 0, err
 }
 
-func (r *categoryRepository) GetAssetsByCategory(categoryID, userID string) ([]models.Asset, error) {
+func (r *categoryRepository) GetAssetsByCategories(categoryIDs []uuid.UUID, organizationID string) ([]models.Asset, error) {
 	var assets []models.Asset
-	err := r.db.Where("category_id = ? AND user_id = ?", categoryID, userID).
-		Order("created_at DESC").
+	err := r.db.Where("category_id IN ? AND organization_id = ?", categoryIDs, organizationID).
+		Order("created_at DESC, id ASC").
 		Find(&assets).Error
 	return assets, err
 }
 
-func (r *categoryRepository) CountAssetsByCategory(categoryID, userID string) (int64, error) {
+func (r *categoryRepository) CountAssetsByCategory(categoryID, organizationID string) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Asset{}).
-		Where("category_id= ? AND user_id= ?", categoryID, userID).
+		Where("category_id= ? AND organization_id = ?", categoryID, organizationID).
 		Count(&count).Error
 	return count, err
 }
@@ -135,10 +141,52 @@ func (r *categoryRepository) CountChildCategories(parentID string) (int64, error
 	return count, err
 }
 
-func (r *categoryRepository) ValidateParentAccess(parentID, userID string) (bool, error) {
+func (r *categoryRepository) ValidateParentAccess(parentID, organizationID string) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.Category{}).
-		Where("id = ? AND (user_id IS NULL OR user_id = ?)", parentID, userID).
+		Where("id = ? AND (organization_id IS NULL OR organization_id = ?)", parentID, organizationID).
 		Count(&count).Error
 	return count > 0, err
 }
+
+func (r *categoryRepository) GetSystemByID(id string) (*models.Category, error) {
+	var category models.Category
+	err := r.db.Where("id = ? AND organization_id IS NULL", id).First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &category, err
+}
+
+// GetSystemCategories returns every system category at any depth, nesting is left to the caller
+func (r *categoryRepository) GetSystemCategories() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Where("organization_id IS NULL").
+		Order("name ASC").
+		Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryRepository) CheckSystemNameExists(name string, parentID *string) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.Category{}).
+		Where("LOWER(name) = LOWER(?) AND organization_id IS NULL", name)
+
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// CountAllAssetsByCategory counts usage across every organization
+func (r *categoryRepository) CountAllAssetsByCategory(categoryID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Asset{}).
+		Where("category_id = ?", categoryID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomFieldRepository interface {
+	Create(field *models.CustomField) error
+	Update(field *models.CustomField) error
+	Delete(field *models.CustomField) error
+	GetByIDAndOrganizationID(id, organizationID string) (*models.CustomField, error)
+	GetByOrganizationID(organizationID string) ([]models.CustomField, error)
+	GetByKey(organizationID, key string) ([]models.CustomField, error)
+	CountValuesOutsideOptions(fieldID uuid.UUID, options []string) (int64, error)
+}
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db}
+}
+
+func (r *customFieldRepository) Create(field *models.CustomField) error {
+	return r.db.Create(field).Error
+}
+
+func (r *customFieldRepository) Update(field *models.CustomField) error {
+	return r.db.Save(field).Error
+}
+
+// Delete removes the field together with the values stored for it
+func (r *customFieldRepository) Delete(field *models.CustomField) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", field.ID).Delete(&models.AssetCustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(field).Error
+	})
+}
+
+func (r *customFieldRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.CustomField, error) {
+	var field models.CustomField
+	err := r.db.Where("id = ? AND organization_id = ?", id, organizationID).First(&field).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &field, err
+}
+
+func (r *customFieldRepository) GetByOrganizationID(organizationID string) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := r.db.Where("organization_id = ?", organizationID).
+		Order("position ASC, created_at ASC").
+		Find(&fields).Error
+	return fields, err
+}
+
+func (r *customFieldRepository) GetByKey(organizationID, key string) ([]models.CustomField, error) {
+	var fields []models.CustomField
+	err := r.db.Where("organization_id = ? AND `key` = ?", organizationID, key).Find(&fields).Error
+	return fields, err
+}
+
+// CountValuesOutsideOptions counts stored values an enum field would no longer allow
+func (r *customFieldRepository) CountValuesOutsideOptions(fieldID uuid.UUID, options []string) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.AssetCustomFieldValue{}).Where("field_id = ? AND text_value IS NOT NULL", fieldID)
+	if len(options) > 0 {
+		query = query.Where("text_value NOT IN ?", options)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type DashboardRepository interface {
+	GetTotals(organizationID string) (*AssetStat, error)
+	GetCategoryBreakdown(organizationID string) ([]AssetStat, error)
+	GetLocationBreakdown(organizationID string) ([]AssetStat, error)
+	GetConditionBreakdown(organizationID string) ([]AssetStat, error)
+	GetMonthlyPurchases(organizationID string, since time.Time) ([]AssetStat, error)
+	GetTopAssets(organizationID string, limit int) ([]models.Asset, error)
+	GetExpiringWarranties(organizationID string, from, to time.Time) ([]models.Asset, error)
+}
+
+// AssetStat is a single aggregate row, Key and Name depend on the grouping
+type AssetStat struct {
+	Key   string
+	Name  string
+	Count int64
+	Value float64
+}
+
+type dashboardRepository struct {
+	db *gorm.DB
+}
+
+func NewDashboardRepository(db *gorm.DB) DashboardRepository {
+	return &dashboardRepository{db}
+}
+
+func (r *dashboardRepository) GetTotals(organizationID string) (*AssetStat, error) {
+	var stat AssetStat
+	err := r.db.Model(&models.Asset{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(price), 0) AS value").
+		Where("organization_id = ?", organizationID).
+		Scan(&stat).Error
+	return &stat, err
+}
+
+func (r *dashboardRepository) GetCategoryBreakdown(organizationID string) ([]AssetStat, error) {
+	var stats []AssetStat
+	err := r.db.Model(&models.Asset{}).
+		Select("assets.category_id AS `key`, categories.name AS name, COUNT(*) AS count, COALESCE(SUM(assets.price), 0) AS value").
+		Joins("LEFT JOIN categories ON categories.id = assets.category_id").
+		Where("assets.organization_id = ?", organizationID).
+		Group("assets.category_id, categories.name").
+		Order("value DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *dashboardRepository) GetLocationBreakdown(organizationID string) ([]AssetStat, error) {
+	var stats []AssetStat
+	err := r.db.Model(&models.Asset{}).
+		Select("assets.location_id AS `key`, locations.name AS name, COUNT(*) AS count, COALESCE(SUM(assets.price), 0) AS value").
+		Joins("LEFT JOIN locations ON locations.id = assets.location_id").
+		Where("assets.organization_id = ?", organizationID).
+		Group("assets.location_id, locations.name").
+		Order("value DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *dashboardRepository) GetConditionBreakdown(organizationID string) ([]AssetStat, error) {
+	var stats []AssetStat
+	err := r.db.Model(&models.Asset{}).
+		Select("`condition` AS `key`, `condition` AS name, COUNT(*) AS count, COALESCE(SUM(price), 0) AS value").
+		Where("organization_id = ?", organizationID).
+		Group("`condition`").
+		Order("value DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *dashboardRepository) GetMonthlyPurchases(organizationID string, since time.Time) ([]AssetStat, error) {
+	var stats []AssetStat
+	err := r.db.Model(&models.Asset{}).
+		Select("DATE_FORMAT(purchase_date, '%Y-%m') AS `key`, COUNT(*) AS count, COALESCE(SUM(price), 0) AS value").
+		Where("organization_id = ? AND purchase_date >= ?", organizationID, since).
+		Group("`key`").
+		Order("`key` ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *dashboardRepository) GetTopAssets(organizationID string, limit int) ([]models.Asset, error) {
+	var assets []models.Asset
+	err := r.db.Preload("Category").Preload("Location").
+		Where("organization_id = ?", organizationID).
+		Order("price DESC, name ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
+
+func (r *dashboardRepository) GetExpiringWarranties(organizationID string, from, to time.Time) ([]models.Asset, error) {
+	var assets []models.Asset
+	err := r.db.Preload("Category").Preload("Location").
+		Where("organization_id = ? AND warranty IS NOT NULL AND warranty >= ? AND warranty <= ?", organizationID, from, to).
+		Order("warranty ASC, name ASC").
+		Find(&assets).Error
+	return assets, err
+}
@@ -5,19 +5,45 @@ import (
 )
 
 type Repositories struct {
-	UserRepository     UserRepository
-	AssetRepository    AssetRepository
-	LocationRepository LocationRepository
-	CategoryRepository CategoryRepository
-	// DashboardRepository DashboardRepository
+	UserRepository         UserRepository
+	AssetRepository        AssetRepository
+	LocationRepository     LocationRepository
+	CategoryRepository     CategoryRepository
+	MovementRepository     MovementRepository
+	LoanRepository         LoanRepository
+	DashboardRepository    DashboardRepository
+	NotificationRepository NotificationRepository
+	MaintenanceRepository  MaintenanceRepository
+	AttachmentRepository   AttachmentRepository
+	OrganizationRepository OrganizationRepository
+	AuditRepository        AuditRepository
+	SessionRepository      SessionRepository
+	RecoveryCodeRepository RecoveryCodeRepository
+	AccessTokenRepository  AccessTokenRepository
+	WebhookRepository      WebhookRepository
+	StockTakeRepository    StockTakeRepository
+	CustomFieldRepository  CustomFieldRepository
 }
 
 func InitRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		UserRepository:     NewUserRepository(db),
-		AssetRepository:    NewAssetRepository(db),
-		LocationRepository: NewLocationRepository(db),
-		CategoryRepository: NewCategoryRepository(db),
-		// DashboardRepository: NewDashboardRepository(db),
+		UserRepository:         NewUserRepository(db),
+		AssetRepository:        NewAssetRepository(db),
+		LocationRepository:     NewLocationRepository(db),
+		CategoryRepository:     NewCategoryRepository(db),
+		MovementRepository:     NewMovementRepository(db),
+		LoanRepository:         NewLoanRepository(db),
+		DashboardRepository:    NewDashboardRepository(db),
+		NotificationRepository: NewNotificationRepository(db),
+		MaintenanceRepository:  NewMaintenanceRepository(db),
+		AttachmentRepository:   NewAttachmentRepository(db),
+		OrganizationRepository: NewOrganizationRepository(db),
+		AuditRepository:        NewAuditRepository(db),
+		SessionRepository:      NewSessionRepository(db),
+		RecoveryCodeRepository: NewRecoveryCodeRepository(db),
+		AccessTokenRepository:  NewAccessTokenRepository(db),
+		WebhookRepository:      NewWebhookRepository(db),
+		StockTakeRepository:    NewStockTakeRepository(db),
+		CustomFieldRepository:  NewCustomFieldRepository(db),
 	}
 }
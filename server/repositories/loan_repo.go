@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoanRepository interface {
+	CheckOut(data *models.AssetLoan) (bool, error)
+	CloseLoan(loan *models.AssetLoan, asset *models.Asset) (bool, error)
+	GetByID(id string) (*models.AssetLoan, error)
+	GetActiveByAssetID(assetID string) (*models.AssetLoan, error)
+	GetByAssetID(assetID string) ([]models.AssetLoan, error)
+	GetLoansWithFilter(filter LoanFilter) ([]models.AssetLoan, int, error)
+}
+
+type LoanFilter struct {
+	OrganizationID string
+	Status         string
+	Page           int
+	Limit          int
+}
+
+type loanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &loanRepository{db}
+}
+
+// CheckOut locks the asset row and creates the loan only if the asset has no active loan,
+// returning false when the asset is already checked out
+func (r *loanRepository) CheckOut(data *models.AssetLoan) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var asset models.Asset
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", data.AssetID).
+			First(&asset).Error; err != nil {
+			return err
+		}
+
+		var active int64
+		if err := tx.Model(&models.AssetLoan{}).
+			Where("asset_id = ? AND returned_at IS NULL", data.AssetID).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return nil
+		}
+
+		if err := tx.Omit(clause.Associations).Create(data).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// CloseLoan stores the return of a loan together with the asset's returned condition,
+// returning false when the loan was already returned by a concurrent check-in
+func (r *loanRepository) CloseLoan(loan *models.AssetLoan, asset *models.Asset) (bool, error) {
+	closed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AssetLoan{}).
+			Where("id = ? AND returned_at IS NULL", loan.ID).
+			Updates(map[string]any{
+				"returned_at":  loan.ReturnedAt,
+				"condition_in": loan.ConditionIn,
+				"note_in":      loan.NoteIn,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Model(asset).Update("condition", asset.Condition).Error; err != nil {
+			return err
+		}
+		closed = true
+		return nil
+	})
+	return closed, err
+}
+
+func (r *loanRepository) GetByID(id string) (*models.AssetLoan, error) {
+	var loan models.AssetLoan
+	err := r.db.Preload("Asset").Preload("BorrowerUser").Where("id = ?", id).First(&loan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &loan, err
+}
+
+func (r *loanRepository) GetActiveByAssetID(assetID string) (*models.AssetLoan, error) {
+	var loan models.AssetLoan
+	err := r.db.Preload("BorrowerUser").
+		Where("asset_id = ? AND returned_at IS NULL", assetID).
+		First(&loan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &loan, err
+}
+
+func (r *loanRepository) GetByAssetID(assetID string) ([]models.AssetLoan, error) {
+	var loans []models.AssetLoan
+	err := r.db.Preload("BorrowerUser").
+		Where("asset_id = ?", assetID).
+		Order("checked_out_at DESC").
+		Find(&loans).Error
+	return loans, err
+}
+
+func (r *loanRepository) GetLoansWithFilter(filter LoanFilter) ([]models.AssetLoan, int, error) {
+	var loans []models.AssetLoan
+	var totalCount int64
+
+	organizationAssets := r.db.Model(&models.Asset{}).Select("id").Where("organization_id = ?", filter.OrganizationID)
+	query := r.db.Model(&models.AssetLoan{}).Where("asset_id IN (?)", organizationAssets)
+
+	switch filter.Status {
+	case "active":
+		query = query.Where("returned_at IS NULL")
+	case "overdue":
+		query = query.Where("returned_at IS NULL AND due_at < CURDATE()")
+	case "returned":
+		query = query.Where("returned_at IS NOT NULL")
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Preload("Asset").Preload("BorrowerUser").
+		Order("due_at ASC, checked_out_at DESC").
+		Offset(offset).Limit(filter.Limit).
+		Find(&loans).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return loans, int(totalCount), nil
+}
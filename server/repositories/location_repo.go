@@ -6,6 +6,7 @@ import (
 
 	"github.com/fiqrioemry/asset_management_system_app/server/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LocationRepository interface {
@@ -13,11 +14,18 @@ type LocationRepository interface {
 	Update(data *models.Location) error
 	Delete(data *models.Location) error
 	GetByID(id string) (*models.Location, error)
-	GetByIDAndUserID(id, userID string) (*models.Location, error)
-	GetAllUserLocations(userID string) ([]models.Location, error)
-	CheckNameExists(name, userID string) (bool, error)
-	GetAssetsByLocation(locationID, userID string) ([]models.Asset, error)
-	CountAssetsByLocation(locationID, userID string) (int64, error)
+	GetByIDAndOrganizationID(id, organizationID string) (*models.Location, error)
+	GetAllOrganizationLocations(organizationID string) ([]models.Location, error)
+	CheckNameExists(name, organizationID string, parentID *string) (bool, error)
+	GetAssetsByLocation(locationID, organizationID string) ([]models.Asset, error)
+	CountAssetsByLocation(locationID, organizationID string) (int64, error)
+	CountChildLocations(parentID string) (int64, error)
+
+	// system default locations
+	GetSystemByID(id string) (*models.Location, error)
+	GetSystemLocations() ([]models.Location, error)
+	CheckSystemNameExists(name string, parentID *string) (bool, error)
+	CountAllAssetsByLocation(locationID string) (int64, error)
 }
 
 type locationRepository struct {
@@ -32,8 +40,9 @@ func (r *locationRepository) Create(data *models.Location) error {
 	return r.db.Create(data).Error
 }
 
+// Update leaves associations alone, a preloaded Parent would otherwise win over a changed ParentID
 func (r *locationRepository) Update(data *models.Location) error {
-	return r.db.Save(data).Error
+	return r.db.Omit(clause.Associations).Save(data).Error
 }
 
 func (r *locationRepository) Delete(data *models.Location) error {
@@ -49,43 +58,100 @@ func (r *locationRepository) GetByID(id string) (*models.Location, error) {
 	return &location, err
 }
 
-func (r *locationRepository) GetByIDAndUserID(id, userID string) (*models.Location, error) {
+func (r *locationRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.Location, error) {
 	var location models.Location
-	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&location).Error
+	err := r.db.Where("id = ? AND organization_id = ?", id, organizationID).First(&location).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return &location, err
 }
 
-func (r *locationRepository) GetAllUserLocations(userID string) ([]models.Location, error) {
+func (r *locationRepository) GetAllOrganizationLocations(organizationID string) ([]models.Location, error) {
 	var locations []models.Location
-	err := r.db.Where("user_id IS NULL OR user_id = ?", userID).
+	err := r.db.Where("organization_id IS NULL OR organization_id = ?", organizationID).
 		Order("is_default DESC, name ASC").
 		Find(&locations).Error
 	return locations, err
 }
 
-func (r *locationRepository) CheckNameExists(name, userID string) (bool, error) {
+// CheckNameExists looks for the name among the locations sharing the parent, "Floor 1" can exist in every building
+func (r *locationRepository) CheckNameExists(name, organizationID string, parentID *string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Location{}).
-		Where("LOWER(name) = LOWER(?) AND (user_id IS NULL OR user_id = ?)", name, userID).
-		Count(&count).Error
+	query := r.db.Model(&models.Location{}).
+		Where("LOWER(name) = LOWER(?) AND (organization_id IS NULL OR organization_id = ?)", name, organizationID)
+
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	err := query.Count(&count).Error
 	return count > 0, err
 }
 
-func (r *locationRepository) GetAssetsByLocation(locationID, userID string) ([]models.Asset, error) {
+func (r *locationRepository) GetAssetsByLocation(locationID, organizationID string) ([]models.Asset, error) {
 	var assets []models.Asset
-	err := r.db.Where("location_id = ? AND user_id = ?", locationID, userID).
+	err := r.db.Where("location_id = ? AND organization_id = ?", locationID, organizationID).
 		Order("created_at DESC").
 		Find(&assets).Error
 	return assets, err
 }
 
-func (r *locationRepository) CountAssetsByLocation(locationID, userID string) (int64, error) {
+func (r *locationRepository) CountAssetsByLocation(locationID, organizationID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Asset{}).
+		Where("location_id = ? AND organization_id = ?", locationID, organizationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *locationRepository) CountChildLocations(parentID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Location{}).
+		Where("parent_id = ?", parentID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *locationRepository) GetSystemByID(id string) (*models.Location, error) {
+	var location models.Location
+	err := r.db.Where("id = ? AND organization_id IS NULL", id).First(&location).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &location, err
+}
+
+func (r *locationRepository) GetSystemLocations() ([]models.Location, error) {
+	var locations []models.Location
+	err := r.db.Where("organization_id IS NULL").
+		Order("name ASC").
+		Find(&locations).Error
+	return locations, err
+}
+
+func (r *locationRepository) CheckSystemNameExists(name string, parentID *string) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.Location{}).
+		Where("LOWER(name) = LOWER(?) AND organization_id IS NULL", name)
+
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// CountAllAssetsByLocation counts usage across every organization
+func (r *locationRepository) CountAllAssetsByLocation(locationID string) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Asset{}).
-		Where("location_id = ? AND user_id = ?", locationID, userID).
+		Where("location_id = ?", locationID).
 		Count(&count).Error
 	return count, err
 }
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MaintenanceRepository interface {
+	CreatePlan(plan *models.MaintenancePlan) error
+	GetPlanByIDAndAssetID(id, assetID string) (*models.MaintenancePlan, error)
+	GetPlansByAssetID(assetID string) ([]models.MaintenancePlan, error)
+	GetUpcomingPlans(organizationID string, until time.Time) ([]models.MaintenancePlan, error)
+	CreateRecord(record *models.MaintenanceRecord, plan *models.MaintenancePlan) error
+	GetRecordsByAssetID(assetID string) ([]models.MaintenanceRecord, error)
+	SumCostByAssetID(assetID string) (float64, error)
+}
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db}
+}
+
+func (r *maintenanceRepository) CreatePlan(plan *models.MaintenancePlan) error {
+	return r.db.Omit(clause.Associations).Create(plan).Error
+}
+
+func (r *maintenanceRepository) GetPlanByIDAndAssetID(id, assetID string) (*models.MaintenancePlan, error) {
+	var plan models.MaintenancePlan
+	err := r.db.Where("id = ? AND asset_id = ?", id, assetID).First(&plan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &plan, err
+}
+
+func (r *maintenanceRepository) GetPlansByAssetID(assetID string) ([]models.MaintenancePlan, error) {
+	var plans []models.MaintenancePlan
+	err := r.db.Where("asset_id = ?", assetID).
+		Order("is_active DESC, next_due_at ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+// GetUpcomingPlans returns active plans due until the given date, overdue ones included
+func (r *maintenanceRepository) GetUpcomingPlans(organizationID string, until time.Time) ([]models.MaintenancePlan, error) {
+	var plans []models.MaintenancePlan
+	err := r.db.Preload("Asset").
+		Joins("JOIN assets ON assets.id = maintenance_plans.asset_id AND assets.deleted_at IS NULL").
+		Where("assets.organization_id = ? AND maintenance_plans.is_active = ? AND maintenance_plans.next_due_at <= ?", organizationID, true, until).
+		Order("maintenance_plans.next_due_at ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+// CreateRecord stores a service record with its attachments and advances the plan it fulfils
+func (r *maintenanceRepository) CreateRecord(record *models.MaintenanceRecord, plan *models.MaintenancePlan) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Asset", "Plan", "User").Create(record).Error; err != nil {
+			return err
+		}
+		if plan == nil {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Save(plan).Error
+	})
+}
+
+func (r *maintenanceRepository) GetRecordsByAssetID(assetID string) ([]models.MaintenanceRecord, error) {
+	var records []models.MaintenanceRecord
+	err := r.db.Preload("User").Preload("Attachments").
+		Where("asset_id = ?", assetID).
+		Order("serviced_at DESC, created_at DESC").
+		Find(&records).Error
+	return records, err
+}
+
+func (r *maintenanceRepository) SumCostByAssetID(assetID string) (float64, error) {
+	var total float64
+	err := r.db.Model(&models.MaintenanceRecord{}).
+		Select("COALESCE(SUM(cost), 0)").
+		Where("asset_id = ?", assetID).
+		Scan(&total).Error
+	return total, err
+}
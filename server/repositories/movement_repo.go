@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type MovementRepository interface {
+	Create(data *models.AssetMovement) error
+	GetByAssetID(assetID string, from, to *time.Time) ([]models.AssetMovement, error)
+}
+
+type movementRepository struct {
+	db *gorm.DB
+}
+
+func NewMovementRepository(db *gorm.DB) MovementRepository {
+	return &movementRepository{db}
+}
+
+func (r *movementRepository) Create(data *models.AssetMovement) error {
+	return r.db.Create(data).Error
+}
+
+func (r *movementRepository) GetByAssetID(assetID string, from, to *time.Time) ([]models.AssetMovement, error) {
+	var movements []models.AssetMovement
+
+	// locations may have been deleted since the move, keep them in the history
+	query := r.db.
+		Preload("FromLocation", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("ToLocation", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("User").
+		Where("asset_id = ?", assetID)
+
+	if from != nil {
+		query = query.Where("moved_at >= ?", *from)
+	}
+
+	if to != nil {
+		query = query.Where("moved_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	err := query.Order("moved_at DESC").Find(&movements).Error
+	return movements, err
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	GetPreferenceByUserID(userID string) (*models.NotificationPreference, error)
+	SavePreference(preference *models.NotificationPreference) error
+	GetDueWarrantyReminders(today time.Time) ([]DueWarrantyReminder, error)
+	CreateWarrantyReminders(reminders []models.WarrantyReminder) error
+}
+
+// DueWarrantyReminder is one asset to remind one organization member about
+type DueWarrantyReminder struct {
+	User  models.User
+	Asset models.Asset
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db}
+}
+
+func (r *notificationRepository) GetPreferenceByUserID(userID string) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &preference, err
+}
+
+func (r *notificationRepository) SavePreference(preference *models.NotificationPreference) error {
+	return r.db.Omit(clause.Associations).Save(preference).Error
+}
+
+// GetDueWarrantyReminders returns, for every active member of the asset's organization, the assets whose
+// warranty expires inside that member's lead window and that the member has not been reminded of for that
+// warranty date yet. Members without preferences get the defaults. Results are ordered by recipient.
+func (r *notificationRepository) GetDueWarrantyReminders(today time.Time) ([]DueWarrantyReminder, error) {
+	var pairs []struct {
+		UserID  uuid.UUID
+		AssetID uuid.UUID
+	}
+	err := r.db.Model(&models.Asset{}).
+		Select("organization_members.user_id, assets.id AS asset_id").
+		Joins("JOIN organizations ON organizations.id = assets.organization_id AND organizations.deleted_at IS NULL").
+		Joins("JOIN organization_members ON organization_members.organization_id = assets.organization_id").
+		Joins("JOIN users ON users.id = organization_members.user_id AND users.deleted_at IS NULL AND users.deactivated_at IS NULL").
+		Joins("LEFT JOIN notification_preferences ON notification_preferences.user_id = organization_members.user_id").
+		Where("assets.warranty IS NOT NULL AND assets.warranty >= ?", today).
+		Where("assets.warranty <= DATE_ADD(?, INTERVAL COALESCE(notification_preferences.warranty_lead_days, ?) DAY)", today, models.DefaultWarrantyLeadDays).
+		Where("COALESCE(notification_preferences.warranty_reminders, TRUE)").
+		Where("NOT EXISTS (SELECT 1 FROM warranty_reminders WHERE warranty_reminders.asset_id = assets.id AND warranty_reminders.user_id = organization_members.user_id AND warranty_reminders.warranty = assets.warranty)").
+		Order("organization_members.user_id ASC, assets.warranty ASC").
+		Scan(&pairs).Error
+	if err != nil || len(pairs) == 0 {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(pairs))
+	assetIDs := make([]uuid.UUID, 0, len(pairs))
+	for _, pair := range pairs {
+		userIDs = append(userIDs, pair.UserID)
+		assetIDs = append(assetIDs, pair.AssetID)
+	}
+
+	var users []models.User
+	if err := r.db.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	var assets []models.Asset
+	if err := r.db.Preload("Location").Where("id IN ?", assetIDs).Find(&assets).Error; err != nil {
+		return nil, err
+	}
+
+	usersByID := make(map[uuid.UUID]models.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+	assetsByID := make(map[uuid.UUID]models.Asset, len(assets))
+	for _, asset := range assets {
+		assetsByID[asset.ID] = asset
+	}
+
+	reminders := make([]DueWarrantyReminder, 0, len(pairs))
+	for _, pair := range pairs {
+		user, userFound := usersByID[pair.UserID]
+		asset, assetFound := assetsByID[pair.AssetID]
+		if !userFound || !assetFound {
+			continue
+		}
+		reminders = append(reminders, DueWarrantyReminder{User: user, Asset: asset})
+	}
+	return reminders, nil
+}
+
+func (r *notificationRepository) CreateWarrantyReminders(reminders []models.WarrantyReminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+	// a concurrent run may already have recorded some of them
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders).Error
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository interface {
+	Create(organization *models.Organization, owner *models.OrganizationMember) error
+	CreatePersonal(user *models.User) (*models.Organization, error)
+	Update(organization *models.Organization) error
+	GetByID(id string) (*models.Organization, error)
+	GetPersonalByOwnerID(userID string) (*models.Organization, error)
+	GetMembership(organizationID, userID string) (*models.OrganizationMember, error)
+	GetMembershipsByUserID(userID string) ([]models.OrganizationMember, error)
+	GetMembers(organizationID string) ([]models.OrganizationMember, error)
+	CountOwners(organizationID string) (int64, error)
+	AddMember(member *models.OrganizationMember) error
+	UpdateMember(member *models.OrganizationMember) error
+	RemoveMember(member *models.OrganizationMember) error
+	SetActiveOrganization(userID, organizationID string) error
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db}
+}
+
+// Create stores a new organization together with its first owner
+func (r *organizationRepository) Create(organization *models.Organization, owner *models.OrganizationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(organization).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = organization.ID
+		return tx.Omit(clause.Associations).Create(owner).Error
+	})
+}
+
+// CreatePersonal creates the user's personal organization and makes it active
+func (r *organizationRepository) CreatePersonal(user *models.User) (*models.Organization, error) {
+	organization := &models.Organization{
+		Name:       models.PersonalOrganizationName(user),
+		IsPersonal: true,
+		OwnerID:    user.ID,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(organization).Error; err != nil {
+			return err
+		}
+		member := &models.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         user.ID,
+			Role:           models.OrganizationRoleOwner,
+		}
+		if err := tx.Omit(clause.Associations).Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).
+			Update("active_organization_id", organization.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.ActiveOrganizationID = &organization.ID
+	return organization, nil
+}
+
+func (r *organizationRepository) Update(organization *models.Organization) error {
+	// the tag counter is only moved by ReserveAssetTags, saving a stale copy would hand out tags twice
+	return r.db.Omit(clause.Associations, "AssetTagCounter").Save(organization).Error
+}
+
+func (r *organizationRepository) GetByID(id string) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.Where("id = ?", id).First(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &organization, err
+}
+
+func (r *organizationRepository) GetPersonalByOwnerID(userID string) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.Where("owner_id = ? AND is_personal = ?", userID, true).First(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &organization, err
+}
+
+func (r *organizationRepository) GetMembership(organizationID, userID string) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.Preload("Organization").Preload("User").
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL").
+		Where("organization_members.organization_id = ? AND organization_members.user_id = ?", organizationID, userID).
+		First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &member, err
+}
+
+func (r *organizationRepository) GetMembershipsByUserID(userID string) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("Organization").
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.is_personal DESC, organizations.name ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) GetMembers(organizationID string) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) CountOwners(organizationID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrganizationRoleOwner).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) AddMember(member *models.OrganizationMember) error {
+	return r.db.Omit(clause.Associations).Create(member).Error
+}
+
+func (r *organizationRepository) UpdateMember(member *models.OrganizationMember) error {
+	return r.db.Omit(clause.Associations).Save(member).Error
+}
+
+// RemoveMember deletes the membership and clears it as active organization of that user
+func (r *organizationRepository) RemoveMember(member *models.OrganizationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).
+			Where("id = ? AND active_organization_id = ?", member.UserID, member.OrganizationID).
+			Update("active_organization_id", nil).Error
+	})
+}
+
+func (r *organizationRepository) SetActiveOrganization(userID, organizationID string) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).
+		Update("active_organization_id", organizationID).Error
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository interface {
+	Replace(userID uuid.UUID, codeHashes []string) error
+	Consume(userID, codeHash string) (bool, error)
+	CountUnused(userID string) (int, error)
+	DeleteByUserID(userID string) error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{db}
+}
+
+// Replace invalidates every previous code of the user
+func (r *recoveryCodeRepository) Replace(userID uuid.UUID, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]models.TwoFactorRecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = models.TwoFactorRecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume marks an unused code as used, false means the code doesn't exist or was already used
+func (r *recoveryCodeRepository) Consume(userID, codeHash string) (bool, error) {
+	result := r.db.Model(&models.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *recoveryCodeRepository) CountUnused(userID string) (int, error) {
+	var count int64
+	err := r.db.Model(&models.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (r *recoveryCodeRepository) DeleteByUserID(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCode{}).Error
+}
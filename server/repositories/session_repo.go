@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(data *models.Session) error
+	GetByID(id string) (*models.Session, error)
+	GetActiveByUserID(userID string) ([]models.Session, error)
+	Rotate(session *models.Session, currentTokenID string) (bool, error)
+	Revoke(id string) error
+	RevokeAllByUserID(userID string) error
+	DeleteExpired(before time.Time) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+func (r *sessionRepository) Create(data *models.Session) error {
+	return r.db.Create(data).Error
+}
+
+func (r *sessionRepository) GetByID(id string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Where("id = ?", id).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &session, err
+}
+
+func (r *sessionRepository) GetActiveByUserID(userID string) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Rotate stores the session's new token only if currentTokenID is still the valid one,
+// false means another request already used that token
+func (r *sessionRepository) Rotate(session *models.Session, currentTokenID string) (bool, error) {
+	result := r.db.Model(&models.Session{}).
+		Where("id = ? AND token_id = ? AND revoked_at IS NULL", session.ID, currentTokenID).
+		Updates(map[string]any{
+			"token_id":     session.TokenID,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IPAddress,
+			"last_seen_at": session.LastSeenAt,
+			"expires_at":   session.ExpiresAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *sessionRepository) Revoke(id string) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepository) RevokeAllByUserID(userID string) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpired removes sessions that can no longer be refreshed
+func (r *sessionRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.Session{}).Error
+}
@@ -0,0 +1,302 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepository interface {
+	Create(stockTake *models.StockTake, locationIDs []uuid.UUID) error
+	Update(stockTake *models.StockTake) error
+	Close(stockTake *models.StockTake, corrections []StockTakeCorrection) (bool, error)
+	GetByIDAndOrganizationID(id, organizationID string) (*models.StockTake, error)
+	GetWithFilter(filter StockTakeFilter) ([]models.StockTake, int, error)
+	HasOpenForLocations(organizationID string, locationIDs []uuid.UUID) (bool, error)
+
+	// items
+	CreateItem(item *models.StockTakeItem) error
+	UpdateItem(item *models.StockTakeItem) error
+	GetItemByID(stockTakeID, itemID string) (*models.StockTakeItem, error)
+	GetItemByAssetID(stockTakeID string, assetID uuid.UUID) (*models.StockTakeItem, error)
+	GetItemsWithFilter(filter StockTakeItemFilter) ([]models.StockTakeItem, int, error)
+	GetAllItems(stockTakeID string) ([]models.StockTakeItem, error)
+	CountItems(stockTakeID string) ([]StockTakeItemCount, error)
+}
+
+// StockTakeItemCount is the number of items per status, split by whether they were expected
+type StockTakeItemCount struct {
+	Status           string
+	Unlisted         bool
+	Count            int
+	ConditionChanged int
+}
+
+// StockTakeCorrection writes an observed location and condition back to an asset. Each part only
+// applies while the asset still has the value the stock-take expected, Moved and ConditionChanged
+// report what Close actually changed.
+type StockTakeCorrection struct {
+	AssetID            uuid.UUID
+	ExpectedLocationID uuid.UUID
+	ExpectedCondition  string
+
+	// Movement is set for a found-elsewhere asset and recorded when the location is corrected
+	Movement  *models.AssetMovement
+	Condition string
+
+	Moved            bool
+	ConditionChanged bool
+}
+
+type StockTakeFilter struct {
+	OrganizationID string
+	Status         string
+	Page           int
+	Limit          int
+}
+
+type StockTakeItemFilter struct {
+	StockTakeID string
+	Status      string
+	LocationID  string
+	Page        int
+	Limit       int
+}
+
+type stockTakeRepository struct {
+	db *gorm.DB
+}
+
+func NewStockTakeRepository(db *gorm.DB) StockTakeRepository {
+	return &stockTakeRepository{db}
+}
+
+// Create stores the stock-take with a snapshot of every asset currently at its locations
+func (r *stockTakeRepository) Create(stockTake *models.StockTake, locationIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(stockTake).Error; err != nil {
+			return err
+		}
+
+		locations := make([]models.StockTakeLocation, 0, len(locationIDs))
+		for _, locationID := range locationIDs {
+			locations = append(locations, models.StockTakeLocation{StockTakeID: stockTake.ID, LocationID: locationID})
+		}
+		if err := tx.Omit(clause.Associations).Create(&locations).Error; err != nil {
+			return err
+		}
+
+		var assets []models.Asset
+		if err := tx.Select("id", "location_id", "condition").
+			Where("organization_id = ? AND location_id IN ?", stockTake.OrganizationID, locationIDs).
+			Find(&assets).Error; err != nil {
+			return err
+		}
+		if len(assets) == 0 {
+			return nil
+		}
+
+		items := make([]models.StockTakeItem, 0, len(assets))
+		for _, asset := range assets {
+			items = append(items, models.StockTakeItem{
+				StockTakeID:        stockTake.ID,
+				AssetID:            asset.ID,
+				ExpectedLocationID: asset.LocationID,
+				ExpectedCondition:  asset.Condition,
+				Status:             models.StockTakeItemPending,
+			})
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(&items, 200).Error
+	})
+}
+
+func (r *stockTakeRepository) Update(stockTake *models.StockTake) error {
+	return r.db.Omit(clause.Associations).Save(stockTake).Error
+}
+
+// Close marks unchecked items missing and applies the asset corrections, all or nothing. It returns
+// false without changing anything when the stock-take is no longer open, e.g. closed concurrently.
+func (r *stockTakeRepository) Close(stockTake *models.StockTake, corrections []StockTakeCorrection) (bool, error) {
+	closed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// claim the stock-take first, a concurrent close waits on the row and then matches nothing
+		result := tx.Model(&models.StockTake{}).
+			Where("id = ? AND status = ?", stockTake.ID, models.StockTakeOpen).
+			Updates(map[string]any{
+				"status":              stockTake.Status,
+				"closed_by":           stockTake.ClosedBy,
+				"closed_at":           stockTake.ClosedAt,
+				"corrections_applied": stockTake.CorrectionsApplied,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.StockTakeItem{}).
+			Where("stock_take_id = ? AND status = ?", stockTake.ID, models.StockTakeItemPending).
+			Updates(map[string]any{"status": models.StockTakeItemMissing, "checked_at": now}).Error; err != nil {
+			return err
+		}
+
+		// targeted updates so edits made to the assets during the stock-take are kept
+		for i := range corrections {
+			correction := &corrections[i]
+			correction.Moved, correction.ConditionChanged = false, false
+
+			if correction.Movement != nil {
+				result := tx.Model(&models.Asset{}).
+					Where("id = ? AND location_id = ?", correction.AssetID, correction.ExpectedLocationID).
+					Update("location_id", correction.Movement.ToLocationID)
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected > 0 {
+					if err := tx.Omit(clause.Associations).Create(correction.Movement).Error; err != nil {
+						return err
+					}
+					correction.Moved = true
+				}
+			}
+
+			if correction.Condition != "" {
+				result := tx.Model(&models.Asset{}).
+					Where("id = ? AND `condition` = ?", correction.AssetID, correction.ExpectedCondition).
+					Update("condition", correction.Condition)
+				if result.Error != nil {
+					return result.Error
+				}
+				correction.ConditionChanged = result.RowsAffected > 0
+			}
+		}
+
+		closed = true
+		return nil
+	})
+	return closed && err == nil, err
+}
+
+func (r *stockTakeRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.StockTake, error) {
+	var stockTake models.StockTake
+	err := r.db.Preload("Locations.Location").
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		First(&stockTake).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &stockTake, err
+}
+
+func (r *stockTakeRepository) GetWithFilter(filter StockTakeFilter) ([]models.StockTake, int, error) {
+	var stockTakes []models.StockTake
+	var totalCount int64
+
+	query := r.db.Model(&models.StockTake{}).Where("organization_id = ?", filter.OrganizationID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Preload("Locations.Location").
+		Order("created_at DESC").
+		Offset(offset).Limit(filter.Limit).
+		Find(&stockTakes).Error
+	return stockTakes, int(totalCount), err
+}
+
+func (r *stockTakeRepository) HasOpenForLocations(organizationID string, locationIDs []uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.StockTakeLocation{}).
+		Joins("JOIN stock_takes ON stock_takes.id = stock_take_locations.stock_take_id").
+		Where("stock_takes.organization_id = ? AND stock_takes.status = ? AND stock_take_locations.location_id IN ?",
+			organizationID, models.StockTakeOpen, locationIDs).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *stockTakeRepository) CreateItem(item *models.StockTakeItem) error {
+	return r.db.Omit(clause.Associations).Create(item).Error
+}
+
+func (r *stockTakeRepository) UpdateItem(item *models.StockTakeItem) error {
+	return r.db.Omit(clause.Associations).Save(item).Error
+}
+
+func (r *stockTakeRepository) GetItemByID(stockTakeID, itemID string) (*models.StockTakeItem, error) {
+	var item models.StockTakeItem
+	err := r.itemQuery().Where("id = ? AND stock_take_id = ?", itemID, stockTakeID).First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &item, err
+}
+
+func (r *stockTakeRepository) GetItemByAssetID(stockTakeID string, assetID uuid.UUID) (*models.StockTakeItem, error) {
+	var item models.StockTakeItem
+	err := r.itemQuery().Where("asset_id = ? AND stock_take_id = ?", assetID, stockTakeID).First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &item, err
+}
+
+func (r *stockTakeRepository) GetItemsWithFilter(filter StockTakeItemFilter) ([]models.StockTakeItem, int, error) {
+	var items []models.StockTakeItem
+	var totalCount int64
+
+	query := r.db.Model(&models.StockTakeItem{}).Where("stock_take_id = ?", filter.StockTakeID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.LocationID != "" {
+		query = query.Where("expected_location_id = ? OR observed_location_id = ?", filter.LocationID, filter.LocationID)
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Preload("Asset", unscopedAssets).Preload("ExpectedLocation").Preload("ObservedLocation").
+		Order("created_at ASC, id ASC").
+		Offset(offset).Limit(filter.Limit).
+		Find(&items).Error
+	return items, int(totalCount), err
+}
+
+func (r *stockTakeRepository) GetAllItems(stockTakeID string) ([]models.StockTakeItem, error) {
+	var items []models.StockTakeItem
+	err := r.itemQuery().Where("stock_take_id = ?", stockTakeID).
+		Order("created_at ASC, id ASC").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *stockTakeRepository) CountItems(stockTakeID string) ([]StockTakeItemCount, error) {
+	var counts []StockTakeItemCount
+	err := r.db.Model(&models.StockTakeItem{}).
+		Select("status, unlisted, COUNT(*) AS count, "+
+			"SUM(CASE WHEN observed_condition <> '' AND observed_condition <> expected_condition THEN 1 ELSE 0 END) AS condition_changed").
+		Where("stock_take_id = ?", stockTakeID).
+		Group("status, unlisted").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *stockTakeRepository) itemQuery() *gorm.DB {
+	return r.db.Preload("Asset", unscopedAssets).Preload("ExpectedLocation").Preload("ObservedLocation")
+}
+
+// assets deleted after the stock-take started still show up in its items
+func unscopedAssets(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
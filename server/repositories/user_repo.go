@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/models"
 
@@ -14,6 +15,15 @@ type UserRepository interface {
 	Delete(data *models.User) error
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id string) (*models.User, error)
+	GetByGoogleID(googleID string) (*models.User, error)
+	GetUsersWithFilter(filter UserFilter) ([]models.User, int, error)
+}
+
+type UserFilter struct {
+	Search string
+	Status string
+	Page   int
+	Limit  int
 }
 
 type userRepository struct {
@@ -56,3 +66,39 @@ func (r *userRepository) GetByID(id string) (*models.User, error) {
 	}
 	return &user, err
 }
+
+func (r *userRepository) GetByGoogleID(googleID string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("google_id = ?", googleID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &user, err
+}
+
+func (r *userRepository) GetUsersWithFilter(filter UserFilter) ([]models.User, int, error) {
+	var users []models.User
+	var totalCount int64
+
+	query := r.db.Model(&models.User{})
+
+	if filter.Search != "" {
+		searchTerm := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where("LOWER(fullname) LIKE ? OR LOWER(email) LIKE ?", searchTerm, searchTerm)
+	}
+
+	switch filter.Status {
+	case "active":
+		query = query.Where("deactivated_at IS NULL")
+	case "deactivated":
+		query = query.Where("deactivated_at IS NOT NULL")
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(filter.Limit).Find(&users).Error
+	return users, int(totalCount), err
+}
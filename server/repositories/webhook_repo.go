@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	Create(data *models.Webhook) error
+	Update(data *models.Webhook) error
+	Delete(data *models.Webhook) error
+	GetByIDAndOrganizationID(id, organizationID string) (*models.Webhook, error)
+	GetByOrganizationID(organizationID string) ([]models.Webhook, error)
+	GetActiveByOrganizationID(organizationID string) ([]models.Webhook, error)
+
+	// deliveries
+	CreateDeliveries(deliveries []models.WebhookDelivery) error
+	UpdateDelivery(data *models.WebhookDelivery) error
+	GetDelivery(webhookID, deliveryID string) (*models.WebhookDelivery, error)
+	GetDeliveriesWithFilter(filter WebhookDeliveryFilter) ([]models.WebhookDelivery, int, error)
+	GetDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	ClaimDelivery(delivery *models.WebhookDelivery, until time.Time) (bool, error)
+	DeleteDeliveriesBefore(before time.Time) error
+}
+
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Status    string
+	Page      int
+	Limit     int
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db}
+}
+
+func (r *webhookRepository) Create(data *models.Webhook) error {
+	return r.db.Create(data).Error
+}
+
+func (r *webhookRepository) Update(data *models.Webhook) error {
+	return r.db.Save(data).Error
+}
+
+// Delete removes the webhook with its delivery log
+func (r *webhookRepository) Delete(data *models.Webhook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", data.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(data).Error
+	})
+}
+
+func (r *webhookRepository) GetByIDAndOrganizationID(id, organizationID string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.Where("id = ? AND organization_id = ?", id, organizationID).First(&webhook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &webhook, err
+}
+
+func (r *webhookRepository) GetByOrganizationID(organizationID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetActiveByOrganizationID(organizationID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("organization_id = ? AND is_active = ?", organizationID, true).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Omit("Webhook").Create(&deliveries).Error
+}
+
+func (r *webhookRepository) UpdateDelivery(data *models.WebhookDelivery) error {
+	return r.db.Omit("Webhook").Save(data).Error
+}
+
+func (r *webhookRepository) GetDelivery(webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("id = ? AND webhook_id = ?", deliveryID, webhookID).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &delivery, err
+}
+
+func (r *webhookRepository) GetDeliveriesWithFilter(filter WebhookDeliveryFilter) ([]models.WebhookDelivery, int, error) {
+	var deliveries []models.WebhookDelivery
+	var totalCount int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", filter.WebhookID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("created_at DESC").
+		Offset(offset).Limit(filter.Limit).
+		Find(&deliveries).Error
+	return deliveries, int(totalCount), err
+}
+
+func (r *webhookRepository) GetDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Preload("Webhook").
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDelivery pushes the next attempt to until so other workers skip the delivery while
+// it's being sent, false means another worker claimed it first
+func (r *webhookRepository) ClaimDelivery(delivery *models.WebhookDelivery, until time.Time) (bool, error) {
+	result := r.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, models.WebhookDeliveryPending, delivery.NextAttemptAt).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		delivery.NextAttemptAt = &until
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(before time.Time) error {
+	return r.db.Where("created_at < ? AND status <> ?", before, models.WebhookDeliveryPending).
+		Delete(&models.WebhookDelivery{}).Error
+}
@@ -0,0 +1,20 @@
+// routes/access_token_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AccessTokenRoutes(r *gin.RouterGroup, h *handlers.AccessTokenHandler) {
+	// a token can't be used to create or revoke tokens
+	tokens := r.Group("/users/me/tokens")
+	tokens.Use(middlewares.AuthRequired(), middlewares.RequireSession())
+	{
+		tokens.GET("", h.GetTokens)          // GET /api/v1/users/me/tokens
+		tokens.POST("", h.CreateToken)       // POST /api/v1/users/me/tokens
+		tokens.DELETE("/:id", h.DeleteToken) // DELETE /api/v1/users/me/tokens/:id
+	}
+}
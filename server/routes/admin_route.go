@@ -0,0 +1,40 @@
+// routes/admin_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRoutes manage the members of the active organization
+func AdminRoutes(r *gin.RouterGroup, h *handlers.OrganizationHandler) {
+	admin := r.Group("/admin")
+	admin.Use(middlewares.AuthRequired(), middlewares.RequirePermission(utils.PermissionMembersManage))
+	{
+		admin.GET("/roles", h.GetRoles)                  // GET /api/v1/admin/roles
+		admin.GET("/members", h.GetMembers)              // GET /api/v1/admin/members
+		admin.PUT("/members/:userId/role", h.AssignRole) // PUT /api/v1/admin/members/:userId/role
+	}
+}
+
+// SystemAdminRoutes manage users and the system default catalogs across every organization
+func SystemAdminRoutes(r *gin.RouterGroup, h *handlers.AdminHandler) {
+	admin := r.Group("/admin")
+	admin.Use(middlewares.AuthRequired(), middlewares.RequireSystemAdmin())
+	{
+		admin.GET("/users", h.GetUsers)                         // GET /api/v1/admin/users
+		admin.POST("/users/:id/deactivate", h.DeactivateUser)   // POST /api/v1/admin/users/:id/deactivate
+		admin.POST("/users/:id/reactivate", h.ReactivateUser)   // POST /api/v1/admin/users/:id/reactivate
+		admin.POST("/users/:id/impersonate", h.ImpersonateUser) // POST /api/v1/admin/users/:id/impersonate
+		admin.GET("/categories", h.GetSystemCategories)         // GET /api/v1/admin/categories
+		admin.POST("/categories", h.CreateSystemCategory)       // POST /api/v1/admin/categories
+		admin.PUT("/categories/:id", h.UpdateSystemCategory)    // PUT /api/v1/admin/categories/:id
+		admin.DELETE("/categories/:id", h.DeleteSystemCategory) // DELETE /api/v1/admin/categories/:id
+		admin.GET("/locations", h.GetSystemLocations)           // GET /api/v1/admin/locations
+		admin.POST("/locations", h.CreateSystemLocation)        // POST /api/v1/admin/locations
+		admin.PUT("/locations/:id", h.UpdateSystemLocation)     // PUT /api/v1/admin/locations/:id
+		admin.DELETE("/locations/:id", h.DeleteSystemLocation)  // DELETE /api/v1/admin/locations/:id
+	}
+}
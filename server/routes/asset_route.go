@@ -4,18 +4,31 @@ package routes
 import (
 	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
 	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/gin-gonic/gin"
 )
 
 func AssetRoutes(router *gin.RouterGroup, assetHandler *handlers.AssetHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	verified := middlewares.RequireVerifiedEmail()
+	write := middlewares.RequirePermission(utils.PermissionAssetsWrite)
+	remove := middlewares.RequirePermission(utils.PermissionAssetsDelete)
+
 	// Asset routes with authentication middleware
 	assetRoutes := router.Group("/assets")
 	assetRoutes.Use(middlewares.AuthRequired())
 	{
-		assetRoutes.GET("", assetHandler.GetAssets)
-		assetRoutes.POST("", assetHandler.CreateAsset)
-		assetRoutes.GET("/:id", assetHandler.GetAssetByID)
-		assetRoutes.PUT("/:id", assetHandler.UpdateAsset)
-		assetRoutes.DELETE("/:id", assetHandler.DeleteAsset)
+		assetRoutes.GET("", read, assetHandler.GetAssets)
+		assetRoutes.POST("", verified, write, assetHandler.CreateAsset)
+		assetRoutes.POST("/import", verified, write, assetHandler.ImportAssets)
+		assetRoutes.GET("/export", read, assetHandler.ExportAssets)
+		assetRoutes.GET("/by-tag/:tag", read, assetHandler.GetAssetByTag)
+		assetRoutes.GET("/:id", read, assetHandler.GetAssetByID)
+		assetRoutes.PUT("/:id", verified, write, assetHandler.UpdateAsset)
+		assetRoutes.DELETE("/:id", verified, remove, assetHandler.DeleteAsset)
+
+		// movement history
+		assetRoutes.GET("/:id/history", read, assetHandler.GetAssetHistory)
+		assetRoutes.POST("/:id/move", verified, write, assetHandler.MoveAsset)
 	}
 }
@@ -0,0 +1,25 @@
+// routes/attachment_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func AttachmentRoutes(r *gin.RouterGroup, h *handlers.AttachmentHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	verified := middlewares.RequireVerifiedEmail()
+	write := middlewares.RequirePermission(utils.PermissionAssetsWrite)
+
+	attachments := r.Group("/assets/:id/attachments")
+	attachments.Use(middlewares.AuthRequired())
+	{
+		attachments.GET("", read, h.GetAttachments)                                        // GET /api/v1/assets/:id/attachments
+		attachments.POST("", verified, write, h.UploadAttachments)                         // POST /api/v1/assets/:id/attachments
+		attachments.PUT("/order", verified, write, h.ReorderAttachments)                   // PUT /api/v1/assets/:id/attachments/order
+		attachments.PUT("/:attachmentId/primary", verified, write, h.SetPrimaryAttachment) // PUT /api/v1/assets/:id/attachments/:attachmentId/primary
+		attachments.DELETE("/:attachmentId", verified, write, h.DeleteAttachment)          // DELETE /api/v1/assets/:id/attachments/:attachmentId
+	}
+}
@@ -0,0 +1,23 @@
+// routes/audit_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditRoutes(r *gin.RouterGroup, h *handlers.AuditHandler) {
+	audit := r.Group("/audit")
+	audit.Use(middlewares.AuthRequired(), middlewares.RequirePermission(utils.PermissionAuditRead))
+	{
+		audit.GET("", h.GetAuditLogs) // GET /api/v1/audit
+	}
+
+	system := r.Group("/admin/audit")
+	system.Use(middlewares.AuthRequired(), middlewares.RequireSystemAdmin())
+	{
+		system.GET("", h.GetSystemAuditLogs) // GET /api/v1/admin/audit
+	}
+}
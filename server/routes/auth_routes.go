@@ -15,9 +15,22 @@ func AuthRoutes(r *gin.RouterGroup, h *handlers.UserHandler) {
 		auth.POST("/logout", h.Logout)
 		auth.POST("/refresh-token", h.RefreshSession)
 
+		// Two-factor sign in
+		auth.POST("/2fa/verify", h.VerifyTwoFactor)
+		auth.POST("/2fa/resend", h.ResendTwoFactor)
+
+		// Email verification
+		auth.POST("/verify-email", h.VerifyEmail)
+		auth.POST("/resend-verification", h.ResendVerification)
+
 		// Password reset flow
 		auth.POST("/forgot-password", h.ForgotPassword)
 		auth.GET("/validate-reset-token", h.ValidateResetToken)
 		auth.POST("/reset-password", h.ResetPassword)
+
+		// Google sign in
+		auth.GET("/google", h.GoogleOAuthRedirect)
+		auth.GET("/google/callback", h.GoogleOAuthCallback)
+		auth.POST("/google", h.GoogleSignIn)
 	}
 }
@@ -3,23 +3,27 @@ package routes
 import (
 	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
 	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/gin-gonic/gin"
 )
 
 func CategoryRoutes(r *gin.RouterGroup, h *handlers.CategoryHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	catalog := middlewares.RequirePermission(utils.PermissionCatalogWrite)
+
 	categories := r.Group("/categories")
 	categories.Use(middlewares.AuthRequired())
 	{
-		categories.GET("/tree", h.GetCategoriesTree)
-		categories.GET("/flat", h.GetCategoriesFlat)
-		categories.GET("/parents", h.GetParentCategories) // selected
+		categories.GET("/tree", read, h.GetCategoriesTree)
+		categories.GET("/flat", read, h.GetCategoriesFlat)
+		categories.GET("/parents", read, h.GetParentCategories) // selected
 
-		categories.GET("/:id", h.GetCategoryByID)
-		categories.GET("/:id/children", h.GetChildCategories)
-		categories.GET("/:id/assets", h.GetAssetsByCategory)
+		categories.GET("/:id", read, h.GetCategoryByID)
+		categories.GET("/:id/children", read, h.GetChildCategories)
+		categories.GET("/:id/assets", read, h.GetAssetsByCategory)
 
-		categories.POST("/", h.CreateCategory)
-		categories.PUT("/:id", h.UpdateCategory)
-		categories.DELETE("/:id", h.DeleteCategory)
+		categories.POST("/", catalog, h.CreateCategory)
+		categories.PUT("/:id", catalog, h.UpdateCategory)
+		categories.DELETE("/:id", catalog, h.DeleteCategory)
 	}
 }
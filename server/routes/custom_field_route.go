@@ -0,0 +1,24 @@
+// routes/custom_field_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CustomFieldRoutes(r *gin.RouterGroup, h *handlers.CustomFieldHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	catalog := middlewares.RequirePermission(utils.PermissionCatalogWrite)
+
+	fields := r.Group("/categories/:id/fields")
+	fields.Use(middlewares.AuthRequired())
+	{
+		fields.GET("", read, h.GetCategoryFields)          // GET /api/v1/categories/:id/fields
+		fields.POST("", catalog, h.CreateField)            // POST /api/v1/categories/:id/fields
+		fields.PUT("/:fieldId", catalog, h.UpdateField)    // PUT /api/v1/categories/:id/fields/:fieldId
+		fields.DELETE("/:fieldId", catalog, h.DeleteField) // DELETE /api/v1/categories/:id/fields/:fieldId
+	}
+}
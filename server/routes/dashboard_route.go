@@ -0,0 +1,17 @@
+// routes/dashboard_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func DashboardRoutes(r *gin.RouterGroup, h *handlers.DashboardHandler) {
+	dashboard := r.Group("/dashboard")
+	dashboard.Use(middlewares.AuthRequired(), middlewares.RequirePermission(utils.PermissionAssetsRead))
+	{
+		dashboard.GET("/summary", h.GetSummary) // GET /api/v1/dashboard/summary
+	}
+}
@@ -0,0 +1,17 @@
+// routes/file_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func FileRoutes(r *gin.RouterGroup, h *handlers.FileHandler) {
+	files := r.Group("/files")
+	files.Use(middlewares.AuthRequired(), middlewares.RequirePermission(utils.PermissionAssetsRead))
+	{
+		files.GET("/:key", h.GetFile) // GET /api/v1/files/:key (local storage driver only)
+	}
+}
@@ -21,7 +21,24 @@ func InitRoutes(r *gin.Engine, h *handlers.Handlers) {
 	v1 := r.Group("/api/v1")
 	AuthRoutes(v1, h.UserHandler)
 	UserRoutes(v1, h.UserHandler)
+	TwoFactorRoutes(v1, h.TwoFactorHandler)
+	AccessTokenRoutes(v1, h.AccessTokenHandler)
+	WebhookRoutes(v1, h.WebhookHandler)
+	LabelRoutes(v1, h.LabelHandler)
+	StockTakeRoutes(v1, h.StockTakeHandler)
+	CustomFieldRoutes(v1, h.CustomFieldHandler)
 	CategoryRoutes(v1, h.CategoryHandler)
 	AssetRoutes(v1, h.AssetHandler)
 	LocationRoutes(v1, h.LocationHandler)
+	LoanRoutes(v1, h.LoanHandler)
+	ReportRoutes(v1, h.ReportHandler)
+	DashboardRoutes(v1, h.DashboardHandler)
+	NotificationRoutes(v1, h.NotificationHandler)
+	MaintenanceRoutes(v1, h.MaintenanceHandler)
+	AttachmentRoutes(v1, h.AttachmentHandler)
+	FileRoutes(v1, h.FileHandler)
+	OrganizationRoutes(v1, h.OrganizationHandler)
+	AdminRoutes(v1, h.OrganizationHandler)
+	SystemAdminRoutes(v1, h.AdminHandler)
+	AuditRoutes(v1, h.AuditHandler)
 }
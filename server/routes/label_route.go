@@ -0,0 +1,22 @@
+// routes/label_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LabelRoutes(r *gin.RouterGroup, h *handlers.LabelHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+
+	r.GET("/assets/:id/label", middlewares.AuthRequired(), read, h.GetAssetLabel) // GET /api/v1/assets/:id/label
+
+	labels := r.Group("/labels")
+	labels.Use(middlewares.AuthRequired(), read)
+	{
+		labels.POST("/sheet", h.GetLabelSheet) // POST /api/v1/labels/sheet
+	}
+}
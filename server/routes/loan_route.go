@@ -0,0 +1,30 @@
+// routes/loan_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func LoanRoutes(r *gin.RouterGroup, h *handlers.LoanHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	write := middlewares.RequirePermission(utils.PermissionAssetsWrite)
+	verified := middlewares.RequireVerifiedEmail()
+
+	// check-out / check-in workflow on a single asset
+	assetLoans := r.Group("/assets/:id")
+	assetLoans.Use(middlewares.AuthRequired())
+	{
+		assetLoans.GET("/loans", read, h.GetAssetLoans)                 // GET /api/v1/assets/:id/loans
+		assetLoans.POST("/check-out", verified, write, h.CheckOutAsset) // POST /api/v1/assets/:id/check-out
+		assetLoans.POST("/check-in", verified, write, h.CheckInAsset)   // POST /api/v1/assets/:id/check-in
+	}
+
+	loans := r.Group("/loans")
+	loans.Use(middlewares.AuthRequired())
+	{
+		loans.GET("", read, h.GetLoans) // GET /api/v1/loans?status=overdue
+	}
+}
@@ -4,18 +4,23 @@ package routes
 import (
 	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
 	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 	"github.com/gin-gonic/gin"
 )
 
 func LocationRoutes(r *gin.RouterGroup, h *handlers.LocationHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	catalog := middlewares.RequirePermission(utils.PermissionCatalogWrite)
+
 	locations := r.Group("/locations")
 	locations.Use(middlewares.AuthRequired())
 	{
-		locations.GET("", h.GetLocations)                   // GET /api/v1/locations
-		locations.POST("", h.CreateLocation)                // POST /api/v1/locations
-		locations.GET("/:id", h.GetLocationByID)            // GET /api/v1/locations/:id
-		locations.PUT("/:id", h.UpdateLocation)             // PUT /api/v1/locations/:id
-		locations.DELETE("/:id", h.DeleteLocation)          // DELETE /api/v1/locations/:id
-		locations.GET("/:id/assets", h.GetAssetsByLocation) // GET /api/v1/locations/:id/assets
+		locations.GET("", read, h.GetLocations)                   // GET /api/v1/locations
+		locations.POST("", catalog, h.CreateLocation)             // POST /api/v1/locations
+		locations.GET("/tree", read, h.GetLocationTree)           // GET /api/v1/locations/tree
+		locations.GET("/:id", read, h.GetLocationByID)            // GET /api/v1/locations/:id
+		locations.PUT("/:id", catalog, h.UpdateLocation)          // PUT /api/v1/locations/:id
+		locations.DELETE("/:id", catalog, h.DeleteLocation)       // DELETE /api/v1/locations/:id
+		locations.GET("/:id/assets", read, h.GetAssetsByLocation) // GET /api/v1/locations/:id/assets
 	}
 }
@@ -0,0 +1,30 @@
+// routes/maintenance_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func MaintenanceRoutes(r *gin.RouterGroup, h *handlers.MaintenanceHandler) {
+	read := middlewares.RequirePermission(utils.PermissionAssetsRead)
+	write := middlewares.RequirePermission(utils.PermissionAssetsWrite)
+	verified := middlewares.RequireVerifiedEmail()
+
+	// plans and service log of a single asset
+	assetMaintenance := r.Group("/assets/:id/maintenance")
+	assetMaintenance.Use(middlewares.AuthRequired())
+	{
+		assetMaintenance.GET("", read, h.GetAssetMaintenance)              // GET /api/v1/assets/:id/maintenance
+		assetMaintenance.POST("", verified, write, h.CreatePlan)           // POST /api/v1/assets/:id/maintenance
+		assetMaintenance.POST("/records", verified, write, h.CreateRecord) // POST /api/v1/assets/:id/maintenance/records
+	}
+
+	maintenance := r.Group("/maintenance")
+	maintenance.Use(middlewares.AuthRequired())
+	{
+		maintenance.GET("/upcoming", read, h.GetUpcoming) // GET /api/v1/maintenance/upcoming?days=30
+	}
+}
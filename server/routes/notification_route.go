@@ -0,0 +1,17 @@
+// routes/notification_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func NotificationRoutes(r *gin.RouterGroup, h *handlers.NotificationHandler) {
+	notifications := r.Group("/users/me/notifications")
+	notifications.Use(middlewares.AuthRequired(), middlewares.RequireSession())
+	{
+		notifications.GET("", h.GetPreferences)    // GET /api/v1/users/me/notifications
+		notifications.PUT("", h.UpdatePreferences) // PUT /api/v1/users/me/notifications
+	}
+}
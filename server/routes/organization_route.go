@@ -0,0 +1,22 @@
+// routes/organization_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func OrganizationRoutes(r *gin.RouterGroup, h *handlers.OrganizationHandler) {
+	organizations := r.Group("/organizations")
+	organizations.Use(middlewares.AuthRequired(), middlewares.RequireSession())
+	{
+		organizations.GET("", h.GetOrganizations)                    // GET /api/v1/organizations
+		organizations.POST("", h.CreateOrganization)                 // POST /api/v1/organizations
+		organizations.GET("/:id", h.GetOrganization)                 // GET /api/v1/organizations/:id
+		organizations.PUT("/:id", h.UpdateOrganization)              // PUT /api/v1/organizations/:id
+		organizations.POST("/:id/switch", h.SwitchOrganization)      // POST /api/v1/organizations/:id/switch
+		organizations.POST("/:id/members", h.AddMember)              // POST /api/v1/organizations/:id/members
+		organizations.DELETE("/:id/members/:userId", h.RemoveMember) // DELETE /api/v1/organizations/:id/members/:userId
+	}
+}
@@ -0,0 +1,17 @@
+// routes/report_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func ReportRoutes(r *gin.RouterGroup, h *handlers.ReportHandler) {
+	reports := r.Group("/reports")
+	reports.Use(middlewares.AuthRequired(), middlewares.RequirePermission(utils.PermissionReportsRead))
+	{
+		reports.GET("/depreciation", h.GetDepreciationReport) // GET /api/v1/reports/depreciation?asOf=YYYY-MM-DD
+	}
+}
@@ -0,0 +1,30 @@
+// routes/stock_take_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StockTakeRoutes(r *gin.RouterGroup, h *handlers.StockTakeHandler) {
+	count := middlewares.RequirePermission(utils.PermissionStockTakesCount)
+	manage := middlewares.RequirePermission(utils.PermissionStockTakesManage)
+	verified := middlewares.RequireVerifiedEmail()
+
+	stockTakes := r.Group("/stock-takes")
+	stockTakes.Use(middlewares.AuthRequired())
+	{
+		stockTakes.GET("", count, h.GetStockTakes)                          // GET /api/v1/stock-takes
+		stockTakes.POST("", verified, manage, h.CreateStockTake)            // POST /api/v1/stock-takes
+		stockTakes.GET("/:id", count, h.GetStockTakeByID)                   // GET /api/v1/stock-takes/:id
+		stockTakes.GET("/:id/report", count, h.GetReport)                   // GET /api/v1/stock-takes/:id/report
+		stockTakes.POST("/:id/close", verified, manage, h.CloseStockTake)   // POST /api/v1/stock-takes/:id/close
+		stockTakes.POST("/:id/cancel", verified, manage, h.CancelStockTake) // POST /api/v1/stock-takes/:id/cancel
+		stockTakes.GET("/:id/items", count, h.GetItems)                     // GET /api/v1/stock-takes/:id/items
+		stockTakes.POST("/:id/scan", verified, count, h.ScanItem)           // POST /api/v1/stock-takes/:id/scan
+		stockTakes.PUT("/:id/items/:itemId", verified, count, h.UpdateItem) // PUT /api/v1/stock-takes/:id/items/:itemId
+	}
+}
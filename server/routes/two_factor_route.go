@@ -0,0 +1,22 @@
+// routes/two_factor_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TwoFactorRoutes(r *gin.RouterGroup, h *handlers.TwoFactorHandler) {
+	twoFactor := r.Group("/users/me/2fa")
+	twoFactor.Use(middlewares.AuthRequired(), middlewares.RequireSession())
+	{
+		twoFactor.GET("", h.GetStatus)                               // GET /api/v1/users/me/2fa
+		twoFactor.POST("/setup", h.Setup)                            // POST /api/v1/users/me/2fa/setup
+		twoFactor.POST("/enable", h.Enable)                          // POST /api/v1/users/me/2fa/enable
+		twoFactor.POST("/disable", h.Disable)                        // POST /api/v1/users/me/2fa/disable
+		twoFactor.POST("/email-code", h.SendEmailCode)               // POST /api/v1/users/me/2fa/email-code
+		twoFactor.POST("/recovery-codes", h.RegenerateRecoveryCodes) // POST /api/v1/users/me/2fa/recovery-codes
+	}
+}
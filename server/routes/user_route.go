@@ -10,10 +10,20 @@ import (
 
 func UserRoutes(r *gin.RouterGroup, h *handlers.UserHandler) {
 	users := r.Group("/users")
-	users.Use(middlewares.AuthRequired())
+	users.Use(middlewares.AuthRequired(), middlewares.RequireSession())
 	{
 		users.GET("/me", h.GetMe)
 		users.PUT("/me", h.UpdateMe)
 		users.POST("/change-password", h.ChangePassword)
+
+		// google account linking
+		users.GET("/me/google", h.LinkGoogleRedirect)
+		users.POST("/me/google", h.LinkGoogle)
+		users.DELETE("/me/google", h.UnlinkGoogle)
+
+		// sessions
+		users.GET("/me/sessions", h.GetSessions)
+		users.DELETE("/me/sessions", h.RevokeAllSessions)
+		users.DELETE("/me/sessions/:id", h.RevokeSession)
 	}
 }
@@ -0,0 +1,24 @@
+// routes/webhook_route.go
+package routes
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/handlers"
+	"github.com/fiqrioemry/asset_management_system_app/server/middlewares"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WebhookRoutes(r *gin.RouterGroup, h *handlers.WebhookHandler) {
+	webhooks := r.Group("/webhooks")
+	webhooks.Use(middlewares.AuthRequired(), middlewares.RequireSession(), middlewares.RequirePermission(utils.PermissionWebhooksManage))
+	{
+		webhooks.GET("", h.GetWebhooks)                                     // GET /api/v1/webhooks
+		webhooks.POST("", h.CreateWebhook)                                  // POST /api/v1/webhooks
+		webhooks.GET("/:id", h.GetWebhookByID)                              // GET /api/v1/webhooks/:id
+		webhooks.PUT("/:id", h.UpdateWebhook)                               // PUT /api/v1/webhooks/:id
+		webhooks.DELETE("/:id", h.DeleteWebhook)                            // DELETE /api/v1/webhooks/:id
+		webhooks.GET("/:id/deliveries", h.GetDeliveries)                    // GET /api/v1/webhooks/:id/deliveries
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.Redeliver) // POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver
+	}
+}
@@ -88,6 +88,12 @@ func SeedAssets(db *gorm.DB) error {
 
 	// Create 10 assets for each user
 	for _, user := range users {
+		// assets go to the user's personal organization
+		var organizationID uuid.UUID
+		if user.ActiveOrganizationID != nil {
+			organizationID = *user.ActiveOrganizationID
+		}
+
 		// Shuffle asset templates to get random selection
 		shuffledAssets := make([]AssetTemplate, len(assetTemplates))
 		copy(shuffledAssets, assetTemplates)
@@ -127,20 +133,21 @@ func SeedAssets(db *gorm.DB) error {
 			assetName := fmt.Sprintf("%s (%s)", template.Name, user.Fullname)
 
 			asset := models.Asset{
-				ID:           uuid.New(),
-				Name:         assetName,
-				Description:  template.Description,
-				LocationID:   location.ID,
-				CategoryID:   category.ID,
-				UserID:       user.ID,
-				Image:        generateAssetImageURL(template.Name),
-				PurchaseDate: &purchaseDate,
-				Price:        addPriceVariation(template.Price),
-				Condition:    template.Condition,
-				SerialNumber: fmt.Sprintf("%s-%s", template.SerialNumber, user.ID.String()[:8]),
-				Warranty:     &warrantyDate,
-				CreatedAt:    time.Now(),
-				UpdatedAt:    time.Now(),
+				ID:             uuid.New(),
+				OrganizationID: organizationID,
+				Name:           assetName,
+				Description:    template.Description,
+				LocationID:     location.ID,
+				CategoryID:     category.ID,
+				UserID:         user.ID,
+				Image:          generateAssetImageURL(template.Name),
+				PurchaseDate:   &purchaseDate,
+				Price:          addPriceVariation(template.Price),
+				Condition:      template.Condition,
+				SerialNumber:   fmt.Sprintf("%s-%s", template.SerialNumber, user.ID.String()[:8]),
+				Warranty:       &warrantyDate,
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
 			}
 
 			// Check if asset already exists
@@ -0,0 +1,108 @@
+// seeders/organizations.go
+package seeders
+
+import (
+	"fmt"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MigratePersonalOrganizations gives every user without one a personal organization
+// and moves the user's own assets, locations and categories into it. Safe to run on every start.
+func MigratePersonalOrganizations(db *gorm.DB) error {
+	var users []models.User
+	err := db.Where("NOT EXISTS (?)",
+		db.Model(&models.Organization{}).Select("1").
+			Where("organizations.owner_id = users.id AND organizations.is_personal = ?", true),
+	).Find(&users).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range users {
+		user := &users[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			organization := models.Organization{
+				Name:       models.PersonalOrganizationName(user),
+				IsPersonal: true,
+				OwnerID:    user.ID,
+			}
+			if err := tx.Omit("Owner", "Members").Create(&organization).Error; err != nil {
+				return err
+			}
+
+			member := models.OrganizationMember{
+				OrganizationID: organization.ID,
+				UserID:         user.ID,
+				Role:           models.OrganizationRoleOwner,
+			}
+			if err := tx.Omit("Organization", "User").Create(&member).Error; err != nil {
+				return err
+			}
+
+			return tx.Model(&models.User{}).
+				Where("id = ? AND active_organization_id IS NULL", user.ID).
+				Update("active_organization_id", organization.ID).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// backfill records created before organizations existed
+	for _, table := range []string{"assets", "locations", "categories"} {
+		query := fmt.Sprintf(`UPDATE %[1]s SET organization_id = (
+			SELECT organizations.id FROM organizations
+			WHERE organizations.owner_id = %[1]s.user_id AND organizations.is_personal = true AND organizations.deleted_at IS NULL
+			LIMIT 1
+		) WHERE user_id IS NOT NULL AND (organization_id IS NULL OR organization_id IN ('', ?))`, table)
+		if err := db.Exec(query, uuid.Nil.String()).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateAssetTags tags assets created before asset tags existed, oldest first. Safe to run on every start.
+func MigrateAssetTags(db *gorm.DB) error {
+	var organizationIDs []uuid.UUID
+	if err := db.Unscoped().Model(&models.Asset{}).
+		Where("tag IS NULL AND organization_id IS NOT NULL").
+		Distinct().Pluck("organization_id", &organizationIDs).Error; err != nil {
+		return err
+	}
+
+	for _, organizationID := range organizationIDs {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var assetIDs []uuid.UUID
+			if err := tx.Unscoped().Model(&models.Asset{}).
+				Where("organization_id = ? AND tag IS NULL", organizationID).
+				Order("created_at ASC").
+				Pluck("id", &assetIDs).Error; err != nil {
+				return err
+			}
+
+			tags, err := models.ReserveAssetTags(tx, organizationID, len(assetIDs))
+			if err != nil {
+				return err
+			}
+
+			for i, assetID := range assetIDs {
+				if err := tx.Unscoped().Model(&models.Asset{}).
+					Where("id = ?", assetID).
+					UpdateColumn("tag", tags[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
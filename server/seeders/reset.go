@@ -17,6 +17,27 @@ func ResetDatabase(db *gorm.DB) {
 		&models.Category{},
 		&models.Asset{},
 		&models.Location{},
+		&models.AssetMovement{},
+		&models.AssetLoan{},
+		&models.NotificationPreference{},
+		&models.WarrantyReminder{},
+		&models.MaintenancePlan{},
+		&models.MaintenanceRecord{},
+		&models.MaintenanceAttachment{},
+		&models.AssetAttachment{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.AuditLog{},
+		&models.Session{},
+		&models.TwoFactorRecoveryCode{},
+		&models.PersonalAccessToken{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.StockTake{},
+		&models.StockTakeLocation{},
+		&models.StockTakeItem{},
+		&models.CustomField{},
+		&models.AssetCustomFieldValue{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to drop tables: %v", err)
@@ -31,6 +52,27 @@ func ResetDatabase(db *gorm.DB) {
 		&models.Category{},
 		&models.Asset{},
 		&models.Location{},
+		&models.AssetMovement{},
+		&models.AssetLoan{},
+		&models.NotificationPreference{},
+		&models.WarrantyReminder{},
+		&models.MaintenancePlan{},
+		&models.MaintenanceRecord{},
+		&models.MaintenanceAttachment{},
+		&models.AssetAttachment{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.AuditLog{},
+		&models.Session{},
+		&models.TwoFactorRecoveryCode{},
+		&models.PersonalAccessToken{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.StockTake{},
+		&models.StockTakeLocation{},
+		&models.StockTakeItem{},
+		&models.CustomField{},
+		&models.AssetCustomFieldValue{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate tables: %v", err)
@@ -14,6 +14,9 @@ func RunAllSeeders(db *gorm.DB) error {
 	if err := SeedUsers(db); err != nil {
 		return err
 	}
+	if err := MigratePersonalOrganizations(db); err != nil {
+		return err
+	}
 	if err := SeedSystemCategories(db); err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ func SeedUsers(db *gorm.DB) error {
 			Email:    "john.doe@example.com",
 			Password: string(hashedPassword),
 			Fullname: "John Doe",
+			IsAdmin:  true,
 			Avatar:   "https://images.unsplash.com/photo-1507003211169-0a1dd7228f2d?w=150&h=150&fit=crop&crop=face",
 		},
 		{
@@ -45,8 +46,10 @@ func SeedUsers(db *gorm.DB) error {
 		var existing models.User
 		if err := db.Where("email = ?", user.Email).First(&existing).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
-				user.CreatedAt = time.Now()
-				user.UpdatedAt = time.Now()
+				now := time.Now()
+				user.CreatedAt = now
+				user.UpdatedAt = now
+				user.EmailVerifiedAt = &now
 				if err := db.Create(&user).Error; err != nil {
 					return err
 				}
@@ -58,3 +61,21 @@ func SeedUsers(db *gorm.DB) error {
 
 	return nil
 }
+
+// PromoteAdmins grants system administration to the configured admin emails
+func PromoteAdmins(db *gorm.DB, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	return db.Model(&models.User{}).
+		Where("email IN ? AND is_admin = ?", emails, false).
+		Update("is_admin", true).Error
+}
+
+// ClearOAuthPlaceholderPasswords removes the "-" password older Google sign ups were stored with
+func ClearOAuthPlaceholderPasswords(db *gorm.DB) error {
+	return db.Model(&models.User{}).
+		Where("password = ?", "-").
+		Update("password", "").Error
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AccessTokenService interface {
+	GetTokens(userID string) ([]dto.AccessTokenResponse, error)
+	CreateToken(userID, organizationID, role string, req *dto.CreateAccessTokenRequest) (*dto.CreatedAccessTokenResponse, error)
+	DeleteToken(userID, tokenID string) error
+	Authenticate(token, clientIP string) (*dto.AccessTokenIdentity, error)
+}
+
+type accessTokenService struct {
+	token        repositories.AccessTokenRepository
+	user         repositories.UserRepository
+	organization repositories.OrganizationRepository
+}
+
+func NewAccessTokenService(token repositories.AccessTokenRepository, user repositories.UserRepository, organization repositories.OrganizationRepository) AccessTokenService {
+	return &accessTokenService{token, user, organization}
+}
+
+func (s *accessTokenService) GetTokens(userID string) ([]dto.AccessTokenResponse, error) {
+	tokens, err := s.token.GetByUserID(userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get access tokens", err)
+	}
+
+	now := time.Now()
+	results := make([]dto.AccessTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		results = append(results, convertAccessTokenToResponse(&tokens[i], now))
+	}
+
+	return results, nil
+}
+
+// CreateToken issues a token for the active organization, scopes can't exceed the user's role
+func (s *accessTokenService) CreateToken(userID, organizationID, role string, req *dto.CreateAccessTokenRequest) (*dto.CreatedAccessTokenResponse, error) {
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if scope == utils.ScopeReadOnly {
+			for _, permission := range utils.ReadPermissions {
+				if utils.HasPermission(role, permission) && !slices.Contains(scopes, permission) {
+					scopes = append(scopes, permission)
+				}
+			}
+			continue
+		}
+
+		if !utils.HasPermission(role, scope) {
+			return nil, response.NewBadRequest("Your role doesn't grant the requested scope").WithContext("scope", scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	plain, err := utils.GeneratePersonalAccessToken()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate access token", err)
+	}
+
+	token := models.PersonalAccessToken{
+		UserID:         userUUID,
+		OrganizationID: organizationUUID,
+		Name:           req.Name,
+		TokenHash:      utils.HashToken(plain),
+		Prefix:         plain[:len(utils.PersonalAccessTokenPrefix)+6],
+		Scopes:         strings.Join(scopes, ","),
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.token.Create(&token); err != nil {
+		return nil, response.NewInternalServerError("Failed to create access token", err)
+	}
+
+	return &dto.CreatedAccessTokenResponse{
+		AccessTokenResponse: convertAccessTokenToResponse(&token, time.Now()),
+		Token:               plain,
+	}, nil
+}
+
+func (s *accessTokenService) DeleteToken(userID, tokenID string) error {
+	token, err := s.token.GetByID(tokenID)
+	if err != nil || token == nil || token.UserID.String() != userID {
+		return response.NewNotFound("Access token not found")
+	}
+
+	if err := s.token.Delete(token); err != nil {
+		return response.NewInternalServerError("Failed to delete access token", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a bearer token, the role is read from the current membership
+// so a demoted or removed member loses access right away
+func (s *accessTokenService) Authenticate(plain, clientIP string) (*dto.AccessTokenIdentity, error) {
+	if !strings.HasPrefix(plain, utils.PersonalAccessTokenPrefix) {
+		return nil, response.NewUnauthorized("Invalid access token")
+	}
+
+	token, err := s.token.GetByHash(utils.HashToken(plain))
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check access token", err)
+	}
+	if token == nil {
+		return nil, response.NewUnauthorized("Invalid access token")
+	}
+
+	now := time.Now()
+	if token.IsExpired(now) {
+		return nil, response.NewUnauthorized("Access token has expired")
+	}
+
+	user, err := s.user.GetByID(token.UserID.String())
+	if err != nil || user == nil {
+		return nil, response.NewUnauthorized("Invalid access token")
+	}
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Your account has been deactivated")
+	}
+
+	member, err := s.organization.GetMembership(token.OrganizationID.String(), token.UserID.String())
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check organization membership", err)
+	}
+	if member == nil {
+		return nil, response.NewUnauthorized("Access token's organization is no longer available")
+	}
+
+	if err := s.token.TouchLastUsed(token.ID.String(), clientIP, now); err != nil {
+		utils.GetLogger().Error("failed to record access token usage", zap.String("tokenId", token.ID.String()), zap.Error(err))
+	}
+
+	return &dto.AccessTokenIdentity{
+		TokenID:        token.ID.String(),
+		UserID:         token.UserID.String(),
+		OrganizationID: token.OrganizationID.String(),
+		Role:           member.Role,
+		EmailVerified:  user.IsEmailVerified(),
+		Scopes:         token.ScopeList(),
+	}, nil
+}
+
+func convertAccessTokenToResponse(token *models.PersonalAccessToken, now time.Time) dto.AccessTokenResponse {
+	return dto.AccessTokenResponse{
+		ID:               token.ID.String(),
+		Name:             token.Name,
+		Prefix:           token.Prefix,
+		Scopes:           token.ScopeList(),
+		OrganizationID:   token.OrganizationID.String(),
+		OrganizationName: token.Organization.Name,
+		ExpiresAt:        token.ExpiresAt,
+		LastUsedAt:       token.LastUsedAt,
+		LastUsedIP:       token.LastUsedIP,
+		CreatedAt:        token.CreatedAt,
+		Expired:          token.IsExpired(now),
+	}
+}
@@ -0,0 +1,569 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AdminService is the system administration API, it works across organizations
+type AdminService interface {
+	// users
+	GetUsers(req *dto.GetUsersRequest) ([]dto.AdminUserResponse, int, error)
+	DeactivateUser(actor utils.AuditActor, userID string) (*dto.AdminUserResponse, error)
+	ReactivateUser(actor utils.AuditActor, userID string) (*dto.AdminUserResponse, error)
+	ImpersonateUser(adminID, userID string) (*dto.AuthResponse, error)
+
+	// system categories
+	GetSystemCategories() (*dto.CategoriesTreeResponse, error)
+	CreateSystemCategory(actor utils.AuditActor, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	UpdateSystemCategory(actor utils.AuditActor, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteSystemCategory(actor utils.AuditActor, categoryID string) error
+
+	// system locations
+	GetSystemLocations() (*dto.LocationsResponse, error)
+	CreateSystemLocation(actor utils.AuditActor, req *dto.CreateLocationRequest) (*dto.LocationResponse, error)
+	UpdateSystemLocation(actor utils.AuditActor, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error)
+	DeleteSystemLocation(actor utils.AuditActor, locationID string) error
+}
+
+type adminService struct {
+	userRepo         repositories.UserRepository
+	organizationRepo repositories.OrganizationRepository
+	categoryRepo     repositories.CategoryRepository
+	locationRepo     repositories.LocationRepository
+	auditService     AuditService
+}
+
+func NewAdminService(userRepo repositories.UserRepository, organizationRepo repositories.OrganizationRepository, categoryRepo repositories.CategoryRepository, locationRepo repositories.LocationRepository, auditService AuditService) AdminService {
+	return &adminService{
+		userRepo:         userRepo,
+		organizationRepo: organizationRepo,
+		categoryRepo:     categoryRepo,
+		locationRepo:     locationRepo,
+		auditService:     auditService,
+	}
+}
+
+func (s *adminService) GetUsers(req *dto.GetUsersRequest) ([]dto.AdminUserResponse, int, error) {
+	users, total, err := s.userRepo.GetUsersWithFilter(repositories.UserFilter{
+		Search: strings.TrimSpace(req.Search),
+		Status: req.Status,
+		Page:   req.Page,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get users", err)
+	}
+
+	results := make([]dto.AdminUserResponse, 0, len(users))
+	for i := range users {
+		results = append(results, convertAdminUserToResponse(&users[i]))
+	}
+
+	return results, total, nil
+}
+
+func (s *adminService) DeactivateUser(actor utils.AuditActor, userID string) (*dto.AdminUserResponse, error) {
+	if actor.UserID == userID {
+		return nil, response.NewBadRequest("You cannot deactivate your own account")
+	}
+
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsAdmin {
+		return nil, response.NewForbidden("System administrators cannot be deactivated")
+	}
+
+	if !user.IsDeactivated() {
+		before := *user
+		now := time.Now()
+		user.DeactivatedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, response.NewInternalServerError("Failed to deactivate user", err)
+		}
+		s.auditService.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+	}
+
+	resp := convertAdminUserToResponse(user)
+	return &resp, nil
+}
+
+func (s *adminService) ReactivateUser(actor utils.AuditActor, userID string) (*dto.AdminUserResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeactivated() {
+		before := *user
+		user.DeactivatedAt = nil
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, response.NewInternalServerError("Failed to reactivate user", err)
+		}
+		s.auditService.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+	}
+
+	resp := convertAdminUserToResponse(user)
+	return &resp, nil
+}
+
+// ImpersonateUser issues a short lived access token for the user, the admin's refresh token is left
+// untouched so refreshing the session ends the impersonation
+func (s *adminService) ImpersonateUser(adminID, userID string) (*dto.AuthResponse, error) {
+	if adminID == userID {
+		return nil, response.NewBadRequest("You cannot impersonate yourself")
+	}
+
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsAdmin {
+		return nil, response.NewForbidden("System administrators cannot be impersonated")
+	}
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Deactivated users cannot be impersonated")
+	}
+
+	membership, err := resolveActiveMembership(s.organizationRepo, user)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to resolve active organization", err)
+	}
+
+	accessToken, err := utils.GenerateAccessToken(utils.AccessTokenSubject{
+		UserID:         user.ID.String(),
+		OrganizationID: membership.OrganizationID.String(),
+		Role:           membership.Role,
+		ImpersonatorID: adminID,
+		EmailVerified:  user.IsEmailVerified(),
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate access token", err)
+	}
+
+	utils.GetLogger().Info("admin impersonation started", zap.String("adminId", adminID), zap.String("userId", userID))
+
+	return &dto.AuthResponse{
+		User: dto.UserSession{
+			ID:                   user.ID.String(),
+			Email:                user.Email,
+			Fullname:             user.Fullname,
+			Avatar:               user.Avatar,
+			ActiveOrganizationID: membership.OrganizationID.String(),
+			Role:                 membership.Role,
+			EmailVerified:        user.IsEmailVerified(),
+			ImpersonatorID:       adminID,
+		},
+		AccessToken: accessToken,
+	}, nil
+}
+
+func (s *adminService) GetSystemCategories() (*dto.CategoriesTreeResponse, error) {
+	categories, err := s.categoryRepo.GetSystemCategories()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+
+	tree := utils.NewCategoryTree(categories)
+	categoryResponses := make([]dto.CategoryResponse, 0, len(tree.Roots()))
+	for _, category := range tree.Roots() {
+		categoryResponses = append(categoryResponses, convertCategoryTreeToResponse(tree, category, 0, convertSystemCategoryToResponse))
+	}
+
+	return &dto.CategoriesTreeResponse{
+		Categories: categoryResponses,
+		Total:      len(categories),
+		Parents:    len(tree.Roots()),
+		Children:   len(categories) - len(tree.Roots()),
+	}, nil
+}
+
+func (s *adminService) CreateSystemCategory(actor utils.AuditActor, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	tree, err := s.loadSystemCategoryTree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentUUID, err := validateSystemParent(tree, req.ParentID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	exists, err := s.categoryRepo.CheckSystemNameExists(req.Name, req.ParentID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check category name", err)
+	}
+	if exists {
+		return nil, response.NewConflict("Category name already exists in this scope")
+	}
+
+	category := &models.Category{
+		ParentID:  parentUUID,
+		Name:      req.Name,
+		IsDefault: true,
+
+		DepreciationMethod: req.DepreciationMethod,
+		UsefulLifeYears:    req.UsefulLifeYears,
+		SalvagePercent:     req.SalvagePercent,
+	}
+
+	if err := s.categoryRepo.Create(category); err != nil {
+		return nil, response.NewInternalServerError("Failed to create category", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionCreate, models.AuditEntityCategory, category.ID.String(), nil, category)
+
+	go invalidateSystemCatalogCache("categories")
+
+	resp := convertSystemCategoryToResponse(category, tree.LevelUnder(category.ParentID))
+	return &resp, nil
+}
+
+func (s *adminService) UpdateSystemCategory(actor utils.AuditActor, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := s.categoryRepo.GetSystemByID(categoryID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get category", err)
+	}
+	if category == nil {
+		return nil, response.NewNotFound("Category not found").WithContext("categoryID", categoryID)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	tree, err := s.loadSystemCategoryTree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentUUID, err := validateSystemParent(tree, req.ParentID, category.ID)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	nameChanged := !strings.EqualFold(req.Name, category.Name)
+	parentChanged := (parentUUID == nil) != (category.ParentID == nil) ||
+		(parentUUID != nil && category.ParentID != nil && *parentUUID != *category.ParentID)
+
+	if nameChanged || parentChanged {
+		exists, err := s.categoryRepo.CheckSystemNameExists(req.Name, req.ParentID)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to check category name", err)
+		}
+		if exists {
+			return nil, response.NewConflict("Category name already exists in this scope")
+		}
+	}
+
+	before := *category
+	category.Name = req.Name
+	category.ParentID = parentUUID
+	category.DepreciationMethod = req.DepreciationMethod
+	category.UsefulLifeYears = req.UsefulLifeYears
+	category.SalvagePercent = req.SalvagePercent
+
+	if err := s.categoryRepo.Update(category); err != nil {
+		return nil, response.NewInternalServerError("Failed to update category", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionUpdate, models.AuditEntityCategory, category.ID.String(), &before, category)
+
+	go invalidateSystemCatalogCache("categories")
+
+	resp := convertSystemCategoryToResponse(category, tree.LevelUnder(category.ParentID))
+	return &resp, nil
+}
+
+func (s *adminService) DeleteSystemCategory(actor utils.AuditActor, categoryID string) error {
+	category, err := s.categoryRepo.GetSystemByID(categoryID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get category", err)
+	}
+	if category == nil {
+		return response.NewNotFound("Category not found").WithContext("categoryID", categoryID)
+	}
+
+	childCount, err := s.categoryRepo.CountChildCategories(categoryID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to check child categories", err)
+	}
+	if childCount > 0 {
+		return response.NewConflict("Cannot delete category that has subcategories")
+	}
+
+	assetCount, err := s.categoryRepo.CountAllAssetsByCategory(categoryID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to check category usage", err)
+	}
+	if assetCount > 0 {
+		return response.NewConflict("Cannot delete category that is being used by assets")
+	}
+
+	if err := s.categoryRepo.Delete(category); err != nil {
+		return response.NewInternalServerError("Failed to delete category", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionDelete, models.AuditEntityCategory, category.ID.String(), category, nil)
+
+	go invalidateSystemCatalogCache("categories")
+
+	return nil
+}
+
+func (s *adminService) GetSystemLocations() (*dto.LocationsResponse, error) {
+	locations, err := s.locationRepo.GetSystemLocations()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+
+	tree := utils.NewLocationTree(locations)
+	locationResponses := make([]dto.LocationResponse, 0, len(locations))
+	for _, location := range tree.DepthFirst() {
+		locationResponses = append(locationResponses, convertLocationToResponse(location, tree))
+	}
+
+	return &dto.LocationsResponse{
+		Locations: locationResponses,
+		Total:     len(locationResponses),
+	}, nil
+}
+
+func (s *adminService) CreateSystemLocation(actor utils.AuditActor, req *dto.CreateLocationRequest) (*dto.LocationResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	tree, err := s.loadSystemLocationTree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentUUID, err := validateLocationParent(tree, req.ParentID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	exists, err := s.locationRepo.CheckSystemNameExists(req.Name, req.ParentID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check location name", err)
+	}
+	if exists {
+		return nil, response.NewConflict("Location name already exists")
+	}
+
+	location := &models.Location{
+		ParentID:  parentUUID,
+		Name:      req.Name,
+		IsDefault: true,
+	}
+
+	if err := s.locationRepo.Create(location); err != nil {
+		return nil, response.NewInternalServerError("Failed to create location", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionCreate, models.AuditEntityLocation, location.ID.String(), nil, location)
+
+	go invalidateSystemCatalogCache("locations")
+
+	resp := convertLocationToResponse(location, tree)
+	return &resp, nil
+}
+
+func (s *adminService) UpdateSystemLocation(actor utils.AuditActor, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error) {
+	location, err := s.locationRepo.GetSystemByID(locationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get location", err)
+	}
+	if location == nil {
+		return nil, response.NewNotFound("Location not found").WithContext("locationID", locationID)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	tree, err := s.loadSystemLocationTree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentUUID, err := validateLocationParent(tree, req.ParentID, location.ID)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	nameChanged := !strings.EqualFold(req.Name, location.Name)
+	parentChanged := (parentUUID == nil) != (location.ParentID == nil) ||
+		(parentUUID != nil && location.ParentID != nil && *parentUUID != *location.ParentID)
+
+	if nameChanged || parentChanged {
+		exists, err := s.locationRepo.CheckSystemNameExists(req.Name, req.ParentID)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to check location name", err)
+		}
+		if exists {
+			return nil, response.NewConflict("Location name already exists")
+		}
+	}
+
+	before := *location
+	location.Name = req.Name
+	location.ParentID = parentUUID
+
+	if err := s.locationRepo.Update(location); err != nil {
+		return nil, response.NewInternalServerError("Failed to update location", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionUpdate, models.AuditEntityLocation, location.ID.String(), &before, location)
+
+	go invalidateSystemCatalogCache("locations")
+
+	resp := convertLocationToResponse(location, tree)
+	return &resp, nil
+}
+
+func (s *adminService) DeleteSystemLocation(actor utils.AuditActor, locationID string) error {
+	location, err := s.locationRepo.GetSystemByID(locationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get location", err)
+	}
+	if location == nil {
+		return response.NewNotFound("Location not found").WithContext("locationID", locationID)
+	}
+
+	childCount, err := s.locationRepo.CountChildLocations(locationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to check child locations", err)
+	}
+	if childCount > 0 {
+		return response.NewConflict("Cannot delete location that has sub-locations")
+	}
+
+	assetCount, err := s.locationRepo.CountAllAssetsByLocation(locationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to check location usage", err)
+	}
+	if assetCount > 0 {
+		return response.NewConflict("Cannot delete location that is being used by assets")
+	}
+
+	if err := s.locationRepo.Delete(location); err != nil {
+		return response.NewInternalServerError("Failed to delete location", err)
+	}
+	s.auditService.Record(actor, "", models.AuditActionDelete, models.AuditEntityLocation, location.ID.String(), location, nil)
+
+	go invalidateSystemCatalogCache("locations")
+
+	return nil
+}
+
+// helper methods
+func (s *adminService) getUser(userID string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get user", err)
+	}
+	if user == nil {
+		return nil, response.NewNotFound("User not found").WithContext("userID", userID)
+	}
+	return user, nil
+}
+
+func (s *adminService) loadSystemCategoryTree() (*utils.CategoryTree, error) {
+	categories, err := s.categoryRepo.GetSystemCategories()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+	return utils.NewCategoryTree(categories), nil
+}
+
+func (s *adminService) loadSystemLocationTree() (*utils.LocationTree, error) {
+	locations, err := s.locationRepo.GetSystemLocations()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+	return utils.NewLocationTree(locations), nil
+}
+
+// validateSystemParent makes sure the parent is a system category outside the subtree of the category,
+// categoryID is uuid.Nil for a new category
+func validateSystemParent(tree *utils.CategoryTree, parentID *string, categoryID uuid.UUID) (*uuid.UUID, error) {
+	if parentID == nil || *parentID == "" {
+		return nil, nil
+	}
+
+	parentUUID, err := uuid.Parse(*parentID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid parent ID")
+	}
+	if parentUUID == categoryID {
+		return nil, response.NewBadRequest("Category cannot be its own parent")
+	}
+	if tree.Get(parentUUID) == nil {
+		return nil, response.NewNotFound("Parent category not found")
+	}
+	if categoryID != uuid.Nil && tree.IsDescendant(parentUUID, categoryID) {
+		return nil, response.NewBadRequest("Category cannot be moved under one of its subcategories")
+	}
+
+	return &parentUUID, nil
+}
+
+// invalidateSystemCatalogCache clears the cached catalog of every organization since all of them include system entries
+func invalidateSystemCatalogCache(catalog string) {
+	utils.DeleteKeysByPattern("asset_app:cache:" + catalog + ":*")
+	utils.DeleteKeysByPattern("asset_app:cache:dashboard:*")
+}
+
+func convertAdminUserToResponse(user *models.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:            user.ID.String(),
+		Fullname:      user.Fullname,
+		Email:         user.Email,
+		Avatar:        user.Avatar,
+		IsAdmin:       user.IsAdmin,
+		IsDeactivated: user.IsDeactivated(),
+		DeactivatedAt: user.DeactivatedAt,
+		JoinedAt:      user.CreatedAt,
+	}
+}
+
+func convertSystemCategoryToResponse(category *models.Category, level int) dto.CategoryResponse {
+	resp := dto.CategoryResponse{
+		ID:        category.ID.String(),
+		Name:      category.Name,
+		IsDefault: category.IsDefault,
+		IsCustom:  false,
+		IsParent:  category.ParentID == nil,
+		Level:     level,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+
+		DepreciationMethod: category.DepreciationMethod,
+		UsefulLifeYears:    category.UsefulLifeYears,
+		SalvagePercent:     category.SalvagePercent,
+	}
+
+	if category.ParentID != nil {
+		parentID := category.ParentID.String()
+		resp.ParentID = &parentID
+	}
+
+	return resp
+}
@@ -1,7 +1,13 @@
 package services
 
 import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/dto"
 	"github.com/fiqrioemry/asset_management_system_app/server/models"
@@ -13,35 +19,100 @@ import (
 )
 
 type AssetService interface {
-	DeleteAsset(userID, assetID string) error
-	GetAssetByID(userID, assetID string) (*dto.AssetResponse, error)
-	CreateAsset(userID string, req *dto.CreateAssetRequest) (*dto.AssetResponse, error)
-	UpdateAsset(userID, assetID string, req *dto.UpdateAssetRequest) (*dto.AssetResponse, error)
-	GetAssets(userID string, req *dto.GetAssetsRequest) (*[]dto.AssetResponse, int, error)
+	DeleteAsset(actor utils.AuditActor, organizationID, assetID string) error
+	GetAssetByID(organizationID, assetID string) (*dto.AssetResponse, error)
+	GetAssetByTag(organizationID, tag string) (*dto.AssetResponse, error)
+	CreateAsset(actor utils.AuditActor, organizationID string, req *dto.CreateAssetRequest) (*dto.AssetResponse, error)
+	UpdateAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.UpdateAssetRequest) (*dto.AssetResponse, error)
+	GetAssets(organizationID string, req *dto.GetAssetsRequest) (*[]dto.AssetResponse, int, error)
+
+	// movement history features
+	MoveAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.MoveAssetRequest) (*dto.AssetMovementResponse, error)
+	GetAssetHistory(organizationID, assetID string, req *dto.GetAssetHistoryRequest) (*dto.AssetHistoryResponse, error)
+
+	// bulk import features
+	ImportAssets(actor utils.AuditActor, organizationID string, file io.Reader, dryRun bool) (*dto.ImportAssetsResponse, error)
+
+	// export features
+	ExportAssets(organizationID string, req *dto.ExportAssetsRequest, w io.Writer) error
+}
+
+// exportColumns are written in this order for every export format
+var exportColumns = []utils.ExportColumn{
+	{Key: "id", Title: "ID"},
+	{Key: "tag", Title: "Asset Tag"},
+	{Key: "name", Title: "Name"},
+	{Key: "description", Title: "Description"},
+	{Key: "category", Title: "Category"},
+	{Key: "location", Title: "Location"},
+	{Key: "price", Title: "Price"},
+	{Key: "condition", Title: "Condition"},
+	{Key: "serialNumber", Title: "Serial Number"},
+	{Key: "purchaseDate", Title: "Purchase Date"},
+	{Key: "warranty", Title: "Warranty"},
+	{Key: "createdAt", Title: "Created At"},
+}
+
+// maxImportRows caps a single CSV import
+const maxImportRows = 1000
+
+// customFieldColumnPrefix marks import columns holding custom field values, e.g. "cf:ram_gb"
+const customFieldColumnPrefix = "cf:"
+
+// importColumns maps normalized CSV headers to asset fields
+var importColumns = map[string]string{
+	"name":         "name",
+	"description":  "description",
+	"category":     "category",
+	"location":     "location",
+	"price":        "price",
+	"condition":    "condition",
+	"serial":       "serialNumber",
+	"serialnumber": "serialNumber",
+	"purchasedate": "purchaseDate",
+	"warranty":     "warranty",
 }
 
 type assetService struct {
-	assetRepo    repositories.AssetRepository
-	locationRepo repositories.LocationRepository
-	categoryRepo repositories.CategoryRepository
+	assetRepo       repositories.AssetRepository
+	locationRepo    repositories.LocationRepository
+	categoryRepo    repositories.CategoryRepository
+	movementRepo    repositories.MovementRepository
+	attachmentRepo  repositories.AttachmentRepository
+	customFieldRepo repositories.CustomFieldRepository
+	userRepo        repositories.UserRepository
+	auditService    AuditService
+	webhookService  WebhookService
 }
 
 func NewAssetService(
 	assetRepo repositories.AssetRepository,
 	locationRepo repositories.LocationRepository,
 	categoryRepo repositories.CategoryRepository,
+	movementRepo repositories.MovementRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	customFieldRepo repositories.CustomFieldRepository,
+	userRepo repositories.UserRepository,
+	auditService AuditService,
+	webhookService WebhookService,
 ) AssetService {
 	return &assetService{
-		assetRepo:    assetRepo,
-		locationRepo: locationRepo,
-		categoryRepo: categoryRepo,
+		assetRepo:       assetRepo,
+		locationRepo:    locationRepo,
+		categoryRepo:    categoryRepo,
+		movementRepo:    movementRepo,
+		attachmentRepo:  attachmentRepo,
+		customFieldRepo: customFieldRepo,
+		userRepo:        userRepo,
+		auditService:    auditService,
+		webhookService:  webhookService,
 	}
 }
 
-func (s *assetService) CreateAsset(userID string, req *dto.CreateAssetRequest) (*dto.AssetResponse, error) {
+func (s *assetService) CreateAsset(actor utils.AuditActor, organizationID string, req *dto.CreateAssetRequest) (*dto.AssetResponse, error) {
 
 	// Validate location access
-	location, err := s.locationRepo.GetByIDAndUserID(req.LocationID, userID)
+	location, err := s.locationRepo.GetByIDAndOrganizationID(req.LocationID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to validate location", err)
 	}
@@ -50,7 +121,7 @@ func (s *assetService) CreateAsset(userID string, req *dto.CreateAssetRequest) (
 	}
 
 	// Validate category access
-	category, err := s.categoryRepo.GetByIDAndUserID(req.CategoryID, userID)
+	category, err := s.categoryRepo.GetByIDAndOrganizationID(req.CategoryID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to validate category", err)
 	}
@@ -58,12 +129,26 @@ func (s *assetService) CreateAsset(userID string, req *dto.CreateAssetRequest) (
 		return nil, response.NewNotFound("Category not found or access denied")
 	}
 
+	fields, err := s.loadCategoryFields(organizationID, category.ID)
+	if err != nil {
+		return nil, err
+	}
+	customValues, err := customFieldValues(fields, nil, req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse all string IDs to UUIDs
-	userUUID, err := uuid.Parse(userID)
+	userUUID, err := uuid.Parse(actor.UserID)
 	if err != nil {
 		return nil, response.NewBadRequest("Invalid user ID")
 	}
 
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
 	locationUUID, err := uuid.Parse(req.LocationID)
 	if err != nil {
 		return nil, response.NewBadRequest("Invalid location ID")
@@ -76,51 +161,92 @@ func (s *assetService) CreateAsset(userID string, req *dto.CreateAssetRequest) (
 
 	// Create asset
 	asset := &models.Asset{
-		Name:         strings.TrimSpace(req.Name),
-		Description:  strings.TrimSpace(req.Description),
-		LocationID:   locationUUID,
-		CategoryID:   categoryUUID,
+		Name:           strings.TrimSpace(req.Name),
+		Description:    strings.TrimSpace(req.Description),
+		LocationID:     locationUUID,
+		CategoryID:     categoryUUID,
+		UserID:         userUUID,
+		OrganizationID: organizationUUID,
+		Image:          req.ImageURL,
+		PurchaseDate:   req.PurchaseDate,
+		Price:          req.Price,
+		Condition:      req.Condition,
+		SerialNumber:   strings.TrimSpace(req.SerialNumber),
+		Warranty:       req.Warranty,
+
+		CustomFieldValues: customValues,
+	}
+
+	// the uploaded image starts the attachment list as primary image
+	if req.ImageURL != "" {
+		attachment := newImageAttachment(uuid.Nil, userUUID, req.ImageURL, req.Image.Filename, 0)
+		attachment.IsPrimary = true
+		asset.Attachments = append(asset.Attachments, attachment)
+	}
+
+	// record initial placement so the history starts at creation
+	movement := &models.AssetMovement{
+		ToLocationID: locationUUID,
 		UserID:       userUUID,
-		Image:        req.ImageURL,
-		PurchaseDate: req.PurchaseDate,
-		Price:        req.Price,
-		Condition:    req.Condition,
-		SerialNumber: strings.TrimSpace(req.SerialNumber),
-		Warranty:     req.Warranty,
+		Note:         "Initial placement",
 	}
 
-	if err := s.assetRepo.Create(asset); err != nil {
+	if err := s.assetRepo.CreateWithMovement(asset, movement); err != nil {
 		return nil, response.NewInternalServerError("Failed to create asset", err)
 	}
+	invalidateDashboardCache(organizationID)
+	s.auditService.Record(actor, organizationID, models.AuditActionCreate, models.AuditEntityAsset, asset.ID.String(), nil, asset)
 
 	// Load relationships for response
 	asset.Location = *location
 
 	asset.Category = *category
+	attachCustomFields(asset.CustomFieldValues, fields)
+
+	policies, err := s.loadDepreciationPolicies(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToResponse(asset, policies)
+	s.webhookService.Emit(organizationID, models.WebhookEventAssetCreated, response)
 
-	response := s.convertToResponse(asset)
 	return &response, nil
 }
 
-func (s *assetService) GetAssets(userID string, req *dto.GetAssetsRequest) (*[]dto.AssetResponse, int, error) {
+func (s *assetService) GetAssets(organizationID string, req *dto.GetAssetsRequest) (*[]dto.AssetResponse, int, error) {
 
 	// validate price range
 	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
 		return nil, 0, response.NewBadRequest("Min price cannot be greater than max price")
 	}
 
+	customFilters, customSort, err := s.buildCustomFieldFilters(organizationID, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	filter := repositories.AssetFilter{
-		UserID:     userID,
-		Search:     strings.TrimSpace(req.Search),
-		CategoryID: req.CategoryID,
-		LocationID: req.LocationID,
-		Condition:  req.Condition,
-		MinPrice:   req.MinPrice,
-		MaxPrice:   req.MaxPrice,
-		SortBy:     req.SortBy,
-		SortOrder:  req.SortOrder,
-		Page:       req.Page,
-		Limit:      req.Limit,
+		OrganizationID: organizationID,
+		Search:         strings.TrimSpace(req.Search),
+		CategoryID:     req.CategoryID,
+		LocationID:     req.LocationID,
+		Condition:      req.Condition,
+		MinPrice:       req.MinPrice,
+		MaxPrice:       req.MaxPrice,
+		Availability:   req.Availability,
+		SortBy:         req.SortBy,
+		SortOrder:      req.SortOrder,
+		Page:           req.Page,
+		Limit:          req.Limit,
+
+		CustomFields:    customFilters,
+		CustomFieldSort: customSort,
+	}
+
+	filter.LocationIDs, err = subLocationFilter(s.locationRepo, organizationID, req.LocationID, req.IncludeSubLocations)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// get assets and total count
@@ -129,17 +255,105 @@ func (s *assetService) GetAssets(userID string, req *dto.GetAssetsRequest) (*[]d
 		return nil, 0, response.NewInternalServerError("Failed to get assets", err)
 	}
 
+	policies, err := s.loadDepreciationPolicies(organizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// convert assert to response
 	var assetResponses []dto.AssetResponse
 	for _, asset := range assets {
-		assetResponses = append(assetResponses, s.convertToResponse(&asset))
+		assetResponses = append(assetResponses, s.convertToResponse(&asset, policies))
 	}
 
 	return &assetResponses, int(total), nil
 }
 
-func (s *assetService) GetAssetByID(userID, assetID string) (*dto.AssetResponse, error) {
-	asset, err := s.assetRepo.GetByIDAndUserID(assetID, userID)
+func (s *assetService) ExportAssets(organizationID string, req *dto.ExportAssetsRequest, w io.Writer) error {
+	// validate price range
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return response.NewBadRequest("Min price cannot be greater than max price")
+	}
+
+	customFilters, customSort, err := s.buildCustomFieldFilters(organizationID, &req.GetAssetsRequest)
+	if err != nil {
+		return err
+	}
+
+	filter := repositories.AssetFilter{
+		OrganizationID: organizationID,
+		Search:         strings.TrimSpace(req.Search),
+		CategoryID:     req.CategoryID,
+		LocationID:     req.LocationID,
+		Condition:      req.Condition,
+		MinPrice:       req.MinPrice,
+		MaxPrice:       req.MaxPrice,
+		Availability:   req.Availability,
+		SortBy:         req.SortBy,
+		SortOrder:      req.SortOrder,
+
+		CustomFields:    customFilters,
+		CustomFieldSort: customSort,
+	}
+
+	filter.LocationIDs, err = subLocationFilter(s.locationRepo, organizationID, req.LocationID, req.IncludeSubLocations)
+	if err != nil {
+		return err
+	}
+
+	writer, err := utils.NewTableWriter(req.Format, w, exportColumns)
+	if err != nil {
+		return response.NewBadRequest(err.Error())
+	}
+
+	// category and location columns hold the full paths so the file can be imported again
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get categories", err)
+	}
+	categoryTree := utils.NewCategoryTree(categories)
+
+	locations, err := s.locationRepo.GetAllOrganizationLocations(organizationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get locations", err)
+	}
+	locationTree := utils.NewLocationTree(locations)
+
+	err = s.assetRepo.ExportAssets(filter, 500, func(batch []models.Asset) error {
+		for _, asset := range batch {
+			row := []any{
+				asset.ID.String(),
+				asset.TagValue(),
+				asset.Name,
+				asset.Description,
+				categoryTree.Path(asset.CategoryID),
+				locationTree.Path(asset.LocationID),
+				asset.Price,
+				asset.Condition,
+				asset.SerialNumber,
+				formatDate(asset.PurchaseDate),
+				formatDate(asset.Warranty),
+				asset.CreatedAt.Format(time.RFC3339),
+			}
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return response.NewInternalServerError("Failed to export assets", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return response.NewInternalServerError("Failed to export assets", err)
+	}
+
+	return nil
+}
+
+func (s *assetService) GetAssetByID(organizationID, assetID string) (*dto.AssetResponse, error) {
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get asset", err)
 	}
@@ -147,35 +361,61 @@ func (s *assetService) GetAssetByID(userID, assetID string) (*dto.AssetResponse,
 		return nil, response.NewNotFound("Asset not found")
 	}
 
-	response := s.convertToResponse(asset)
+	policies, err := s.loadDepreciationPolicies(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToResponse(asset, policies)
 	return &response, nil
 }
 
-func (s *assetService) UpdateAsset(userID, assetID string, req *dto.UpdateAssetRequest) (*dto.AssetResponse, error) {
+// GetAssetByTag resolves a scanned label, tags are matched case-insensitively
+func (s *assetService) GetAssetByTag(organizationID, tag string) (*dto.AssetResponse, error) {
+	asset, err := s.assetRepo.GetByTagAndOrganizationID(strings.ToUpper(strings.TrimSpace(tag)), organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	policies, err := s.loadDepreciationPolicies(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToResponse(asset, policies)
+	return &response, nil
+}
+
+func (s *assetService) UpdateAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.UpdateAssetRequest) (*dto.AssetResponse, error) {
 	// Get asset and check ownership
-	asset, err := s.assetRepo.GetByIDAndUserID(assetID, userID)
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get asset", err)
 	}
 	if asset == nil {
 		return nil, response.NewNotFound("Asset not found or you don't have permission to update it")
 	}
+	before := *asset
 
 	// Validate location if provided
+	var movement *models.AssetMovement
 	if req.LocationID != "" {
-		location, err := s.locationRepo.GetByID(req.LocationID)
+		location, err := s.getAccessibleLocation(organizationID, req.LocationID)
 		if err != nil {
-			return nil, response.NewInternalServerError("Failed to validate location", err)
-		}
-		if location == nil {
-			return nil, response.NewNotFound("Location not found or access denied")
+			return nil, err
 		}
 
-		locationUUID, err := uuid.Parse(req.LocationID)
-		if err != nil {
-			return nil, response.NewBadRequest("Invalid location ID")
+		if location.ID != asset.LocationID {
+			movement, err = s.buildMovement(actor.UserID, asset, location, "")
+			if err != nil {
+				return nil, err
+			}
+			asset.LocationID = location.ID
+			asset.Location = *location
 		}
-		asset.LocationID = locationUUID
 	}
 
 	// Validate category if provided
@@ -184,7 +424,7 @@ func (s *assetService) UpdateAsset(userID, assetID string, req *dto.UpdateAssetR
 		if err != nil {
 			return nil, response.NewInternalServerError("Failed to validate category", err)
 		}
-		if category == nil {
+		if category == nil || (category.OrganizationID != nil && category.OrganizationID.String() != organizationID) {
 			return nil, response.NewNotFound("Category not found or access denied")
 		}
 
@@ -195,6 +435,20 @@ func (s *assetService) UpdateAsset(userID, assetID string, req *dto.UpdateAssetR
 		asset.CategoryID = categoryUUID
 	}
 
+	// values are checked against the schema of the new category when it changes
+	var customValues []models.AssetCustomFieldValue
+	if req.CustomFields != nil || asset.CategoryID != before.CategoryID {
+		fields, err := s.loadCategoryFields(organizationID, asset.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		customValues, err = customFieldValues(fields, asset.CustomFieldValues, req.CustomFields)
+		if err != nil {
+			return nil, err
+		}
+		attachCustomFields(customValues, fields)
+	}
+
 	// Update fields
 	if req.Name != "" {
 		asset.Name = strings.TrimSpace(req.Name)
@@ -221,17 +475,129 @@ func (s *assetService) UpdateAsset(userID, assetID string, req *dto.UpdateAssetR
 		asset.Warranty = req.Warranty
 	}
 
-	if err := s.assetRepo.Update(asset); err != nil {
+	if err := s.assetRepo.UpdateWithCustomFields(asset, movement, customValues); err != nil {
 		return nil, response.NewInternalServerError("Failed to update asset", err)
 	}
+	if customValues != nil {
+		asset.CustomFieldValues = customValues
+	}
+	invalidateDashboardCache(organizationID)
+	s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityAsset, asset.ID.String(), &before, asset)
+
+	// a newly uploaded image is added to the attachments and becomes primary
+	if req.ImageURL != "" {
+		if err := s.addPrimaryImage(asset, req.ImageURL, req.Image.Filename); err != nil {
+			return nil, err
+		}
+	}
+
+	policies, err := s.loadDepreciationPolicies(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToResponse(asset, policies)
+	s.webhookService.Emit(organizationID, models.WebhookEventAssetUpdated, response)
+	if movement != nil {
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetMoved, convertMovementToResponse(movement))
+	}
+	if before.Condition != asset.Condition {
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetConditionChanged, dto.AssetConditionChangedEvent{
+			AssetID:           asset.ID.String(),
+			Name:              asset.Name,
+			PreviousCondition: before.Condition,
+			Condition:         asset.Condition,
+		})
+	}
 
-	response := s.convertToResponse(asset)
 	return &response, nil
 }
 
-func (s *assetService) DeleteAsset(userID, assetID string) error {
+func (s *assetService) MoveAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.MoveAssetRequest) (*dto.AssetMovementResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to move it")
+	}
+
+	location, err := s.getAccessibleLocation(organizationID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if location.ID == asset.LocationID {
+		return nil, response.NewBadRequest("Asset is already at this location")
+	}
+
+	movement, err := s.buildMovement(actor.UserID, asset, location, strings.TrimSpace(req.Note))
+	if err != nil {
+		return nil, err
+	}
+
+	before := *asset
+	fromLocation := asset.Location
+	asset.LocationID = location.ID
+	asset.Location = *location
+
+	if err := s.assetRepo.UpdateWithMovement(asset, movement); err != nil {
+		return nil, response.NewInternalServerError("Failed to move asset", err)
+	}
+	invalidateDashboardCache(organizationID)
+	s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityAsset, asset.ID.String(), &before, asset)
+
+	// Load relationships for response
+	if fromLocation.ID != uuid.Nil {
+		movement.FromLocation = &fromLocation
+	}
+	movement.ToLocation = *location
+	// the movement is made by the acting user, asset.User is whoever created the asset
+	if user, err := s.userRepo.GetByID(actor.UserID); err == nil && user != nil {
+		movement.User = *user
+	}
+
+	resp := convertMovementToResponse(movement)
+	s.webhookService.Emit(organizationID, models.WebhookEventAssetMoved, resp)
+
+	return &resp, nil
+}
+
+func (s *assetService) GetAssetHistory(organizationID, assetID string, req *dto.GetAssetHistoryRequest) (*dto.AssetHistoryResponse, error) {
+	// validate date range
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, response.NewBadRequest("From date cannot be after to date")
+	}
+
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	movements, err := s.movementRepo.GetByAssetID(assetID, req.From, req.To)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset history", err)
+	}
+
+	movementResponses := make([]dto.AssetMovementResponse, 0, len(movements))
+	for _, movement := range movements {
+		movementResponses = append(movementResponses, convertMovementToResponse(&movement))
+	}
+
+	return &dto.AssetHistoryResponse{
+		AssetID:   asset.ID.String(),
+		Movements: movementResponses,
+		Total:     len(movementResponses),
+	}, nil
+}
+
+func (s *assetService) DeleteAsset(actor utils.AuditActor, organizationID, assetID string) error {
 	// Get asset and check ownership
-	asset, err := s.assetRepo.GetByIDAndUserID(assetID, userID)
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
 	if err != nil {
 		return response.NewInternalServerError("Failed to get asset", err)
 	}
@@ -239,34 +605,551 @@ func (s *assetService) DeleteAsset(userID, assetID string) error {
 		return response.NewNotFound("Asset not found or you don't have permission to delete it")
 	}
 
+	// collect stored files before their rows are removed
+	files, err := s.attachmentRepo.GetStoredFileURLs(assetID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get asset files", err)
+	}
+	if asset.Image != "" && !slices.Contains(files, asset.Image) {
+		files = append(files, asset.Image)
+	}
+
 	if err := s.assetRepo.Delete(asset); err != nil {
 		return response.NewInternalServerError("Failed to delete asset", err)
 	}
+	invalidateDashboardCache(organizationID)
+	s.auditService.Record(actor, organizationID, models.AuditActionDelete, models.AuditEntityAsset, asset.ID.String(), asset, nil)
+	s.webhookService.Emit(organizationID, models.WebhookEventAssetDeleted, s.convertToResponse(asset, nil))
+
+	// cleanup every stored file of the asset
+	go utils.CleanupImagesOnError(files)
 
-	// cleanup image if exists
-	if asset.Image != "" {
-		go utils.CleanupImageOnError(asset.Image)
+	return nil
+}
+
+func (s *assetService) ImportAssets(actor utils.AuditActor, organizationID string, file io.Reader, dryRun bool) (*dto.ImportAssetsResponse, error) {
+	userUUID, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
 	}
 
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	// Map header columns
+	header, err := reader.Read()
+	if err != nil {
+		return nil, response.NewBadRequest("CSV file is empty or unreadable")
+	}
+
+	columns := make(map[string]int)
+	customColumns := make(map[string]int)
+	for i, name := range header {
+		if key, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(name)), customFieldColumnPrefix); ok {
+			customColumns[strings.TrimSpace(key)] = i
+			continue
+		}
+		key := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+		if field, ok := importColumns[key]; ok {
+			columns[field] = i
+		}
+	}
+
+	for _, required := range []string{"name", "category", "location", "price", "condition"} {
+		if _, ok := columns[required]; !ok {
+			return nil, response.NewBadRequest(fmt.Sprintf("CSV header is missing required column: %s", required))
+		}
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, response.NewBadRequest(fmt.Sprintf("Invalid CSV format: %v", err))
+	}
+	if len(records) == 0 {
+		return nil, response.NewBadRequest("CSV file has no data rows")
+	}
+	if len(records) > maxImportRows {
+		return nil, response.NewBadRequest(fmt.Sprintf("CSV file exceeds the maximum of %d rows", maxImportRows))
+	}
+
+	// Resolve categories and locations visible to the organization
+	categoryPaths, err := s.getCategoryPaths(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	locationPaths, err := s.getLocationPaths(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// custom field schemas are resolved once per category
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+	allFields, err := s.customFieldRepo.GetByOrganizationID(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get custom fields", err)
+	}
+	categoryFields := make(map[uuid.UUID][]models.CustomField)
+
+	result := &dto.ImportAssetsResponse{
+		DryRun:    dryRun,
+		TotalRows: len(records),
+		Errors:    []dto.ImportRowError{},
+	}
+
+	var assets []models.Asset
+	var movements []models.AssetMovement
+
+	for i, record := range records {
+		// header is row 1
+		rowNumber := i + 2
+
+		get := func(field string) string {
+			if idx, ok := columns[field]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		rowErrors := make(map[string]string)
+
+		asset := models.Asset{
+			Name:           get("name"),
+			Description:    get("description"),
+			UserID:         userUUID,
+			OrganizationID: organizationUUID,
+			Condition:      strings.ToLower(get("condition")),
+			SerialNumber:   get("serialNumber"),
+		}
+
+		if asset.Name == "" {
+			rowErrors["name"] = "name is required"
+		} else if len(asset.Name) > 100 {
+			rowErrors["name"] = "name must be at most 100 characters"
+		}
+
+		if len(asset.Description) > 255 {
+			rowErrors["description"] = "description must be at most 255 characters"
+		}
+
+		if len(asset.SerialNumber) > 100 {
+			rowErrors["serialNumber"] = "serialNumber must be at most 100 characters"
+		}
+
+		switch asset.Condition {
+		case "new", "good", "fair", "poor":
+		case "":
+			rowErrors["condition"] = "condition is required"
+		default:
+			rowErrors["condition"] = "condition must be one of new, good, fair, poor"
+		}
+
+		if price, err := strconv.ParseFloat(get("price"), 64); err != nil {
+			rowErrors["price"] = "price must be a number"
+		} else if price < 0 {
+			rowErrors["price"] = "price must be at least 0"
+		} else {
+			asset.Price = price
+		}
+
+		if category, ok := categoryPaths[normalizeTreePath(get("category"))]; ok {
+			asset.CategoryID = category.ID
+		} else {
+			rowErrors["category"] = "category not found or access denied"
+		}
+
+		if location, ok := locationPaths[normalizeTreePath(get("location"))]; ok {
+			asset.LocationID = location.ID
+		} else {
+			rowErrors["location"] = "location not found or access denied"
+		}
+
+		if asset.CategoryID != uuid.Nil {
+			fields, ok := categoryFields[asset.CategoryID]
+			if !ok {
+				fields = utils.ResolveCategoryFields(categories, allFields, asset.CategoryID)
+				categoryFields[asset.CategoryID] = fields
+			}
+			asset.CustomFieldValues = importCustomFieldValues(fields, customColumns, record, rowErrors)
+		}
+
+		if date, err := parseImportDate(get("purchaseDate")); err != nil {
+			rowErrors["purchaseDate"] = "purchaseDate must be in YYYY-MM-DD format"
+		} else {
+			asset.PurchaseDate = date
+		}
+
+		if date, err := parseImportDate(get("warranty")); err != nil {
+			rowErrors["warranty"] = "warranty must be in YYYY-MM-DD format"
+		} else {
+			asset.Warranty = date
+		}
+
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, dto.ImportRowError{
+				Row:    rowNumber,
+				Name:   asset.Name,
+				Errors: rowErrors,
+			})
+			continue
+		}
+
+		assets = append(assets, asset)
+		movements = append(movements, models.AssetMovement{
+			ToLocationID: asset.LocationID,
+			UserID:       userUUID,
+			Note:         "Imported from CSV",
+		})
+	}
+
+	result.ValidRows = len(assets)
+
+	if dryRun {
+		return result, nil
+	}
+
+	// Real imports are all-or-nothing
+	if len(result.Errors) > 0 {
+		return nil, response.NewBadRequest("Import validation failed, no assets were imported").
+			WithContext("errors", result.Errors)
+	}
+
+	if err := s.assetRepo.BulkCreateWithMovements(assets, movements); err != nil {
+		return nil, response.NewInternalServerError("Failed to import assets", err)
+	}
+	invalidateDashboardCache(organizationID)
+	for i := range assets {
+		s.auditService.Record(actor, organizationID, models.AuditActionCreate, models.AuditEntityAsset, assets[i].ID.String(), nil, &assets[i])
+	}
+
+	// the import already succeeded, events fall back to default depreciation if settings can't be loaded
+	policies, _ := s.loadDepreciationPolicies(organizationID)
+	for i := range assets {
+		attachCustomFields(assets[i].CustomFieldValues, categoryFields[assets[i].CategoryID])
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetCreated, s.convertToResponse(&assets[i], policies))
+	}
+
+	result.Imported = len(assets)
+	return result, nil
+}
+
+// importCustomFieldValues parses the cf:<key> columns of a row against the category's fields. A file
+// can mix categories, so a column of a field the category doesn't have must be left empty.
+func importCustomFieldValues(fields []models.CustomField, columns map[string]int, record []string, rowErrors map[string]string) []models.AssetCustomFieldValue {
+	cell := func(key string) string {
+		if idx, ok := columns[key]; ok && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	var values []models.AssetCustomFieldValue
+	for i := range fields {
+		field := &fields[i]
+		column := customFieldColumnPrefix + field.Key
+
+		raw := cell(field.Key)
+		if raw == "" {
+			if field.Required {
+				rowErrors[column] = field.Label + " is required"
+			}
+			continue
+		}
+
+		value, err := utils.ParseCustomFieldValue(field, raw)
+		if err != nil {
+			rowErrors[column] = err.Error()
+			continue
+		}
+		if value != nil {
+			values = append(values, *value)
+		}
+	}
+
+	for key := range columns {
+		if cell(key) != "" && !slices.ContainsFunc(fields, func(f models.CustomField) bool { return f.Key == key }) {
+			rowErrors[customFieldColumnPrefix+key] = "not a custom field of this category"
+		}
+	}
+	return values
+}
+
+// getCategoryPaths indexes the organization's visible categories by their full path
+func (s *assetService) getCategoryPaths(organizationID string) (map[string]*models.Category, error) {
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+
+	tree := utils.NewCategoryTree(categories)
+	paths := make(map[string]*models.Category)
+	for i := range categories {
+		category := &categories[i]
+
+		key := normalizeTreePath(tree.Path(category.ID))
+		// organization's own category wins over a system default with the same path
+		if existing, ok := paths[key]; !ok || existing.OrganizationID == nil {
+			paths[key] = category
+		}
+	}
+
+	return paths, nil
+}
+
+// getLocationPaths indexes the organization's visible locations by their full path, for a top level
+// location that is just its name
+func (s *assetService) getLocationPaths(organizationID string) (map[string]*models.Location, error) {
+	locations, err := s.locationRepo.GetAllOrganizationLocations(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+
+	tree := utils.NewLocationTree(locations)
+	paths := make(map[string]*models.Location)
+	for i := range locations {
+		location := &locations[i]
+
+		key := normalizeTreePath(tree.Path(location.ID))
+		// organization's own location wins over a system default with the same path
+		if existing, ok := paths[key]; !ok || existing.OrganizationID == nil {
+			paths[key] = location
+		}
+	}
+
+	return paths, nil
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// normalizeTreePath makes "Technology>Laptops" and "technology > laptops" the same key
+func normalizeTreePath(path string) string {
+	parts := strings.Split(path, ">")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, " > ")
+}
+
+func parseImportDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// getAccessibleLocation returns a system default location or one owned by the organization
+func (s *assetService) getAccessibleLocation(organizationID, locationID string) (*models.Location, error) {
+	location, err := s.locationRepo.GetByID(locationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to validate location", err)
+	}
+	if location == nil || (location.OrganizationID != nil && location.OrganizationID.String() != organizationID) {
+		return nil, response.NewNotFound("Location not found or access denied")
+	}
+	return location, nil
+}
+
+func (s *assetService) buildMovement(userID string, asset *models.Asset, to *models.Location, note string) (*models.AssetMovement, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	fromLocationID := asset.LocationID
+	return &models.AssetMovement{
+		AssetID:        asset.ID,
+		FromLocationID: &fromLocationID,
+		ToLocationID:   to.ID,
+		UserID:         userUUID,
+		Note:           note,
+	}, nil
+}
+
+func convertMovementToResponse(movement *models.AssetMovement) dto.AssetMovementResponse {
+	resp := dto.AssetMovementResponse{
+		ID:      movement.ID.String(),
+		AssetID: movement.AssetID.String(),
+		ToLocation: dto.LocationResponse{
+			ID:   movement.ToLocationID.String(),
+			Name: movement.ToLocation.Name,
+		},
+		MovedBy: dto.ActorResponse{
+			ID:       movement.UserID.String(),
+			Fullname: movement.User.Fullname,
+			Email:    movement.User.Email,
+		},
+		Note:    movement.Note,
+		MovedAt: movement.MovedAt,
+	}
+
+	if movement.FromLocationID != nil {
+		resp.FromLocation = &dto.LocationResponse{ID: movement.FromLocationID.String()}
+		if movement.FromLocation != nil {
+			resp.FromLocation.Name = movement.FromLocation.Name
+		}
+	}
+
+	return resp
+}
+
+func (s *assetService) addPrimaryImage(asset *models.Asset, url, fileName string) error {
+	position, err := s.attachmentRepo.GetNextPosition(asset.ID.String())
+	if err != nil {
+		return response.NewInternalServerError("Failed to get attachment position", err)
+	}
+
+	attachments := []models.AssetAttachment{newImageAttachment(asset.ID, asset.UserID, url, fileName, position)}
+	if err := s.attachmentRepo.Create(attachments); err != nil {
+		return response.NewInternalServerError("Failed to save image", err)
+	}
+
+	if err := s.attachmentRepo.SetPrimary(asset.ID.String(), &attachments[0]); err != nil {
+		return response.NewInternalServerError("Failed to set primary image", err)
+	}
+
+	for i := range asset.Attachments {
+		asset.Attachments[i].IsPrimary = false
+	}
+	attachments[0].IsPrimary = true
+	asset.Attachments = append(asset.Attachments, attachments[0])
 	return nil
 }
 
-func (s *assetService) convertToResponse(asset *models.Asset) dto.AssetResponse {
+// loadCategoryFields returns the custom fields of the category including the inherited ones
+func (s *assetService) loadCategoryFields(organizationID string, categoryID uuid.UUID) ([]models.CustomField, error) {
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+	fields, err := s.customFieldRepo.GetByOrganizationID(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get custom fields", err)
+	}
+	return utils.ResolveCategoryFields(categories, fields, categoryID), nil
+}
+
+// buildCustomFieldFilters resolves the cf, cfMin, cfMax and sortField keys of the request. The same
+// key can be defined on unrelated categories, those fields are matched together when their types agree.
+func (s *assetService) buildCustomFieldFilters(organizationID string, req *dto.GetAssetsRequest) ([]repositories.CustomFieldFilter, *repositories.CustomFieldSort, error) {
+	resolved := make(map[string][]models.CustomField)
+	resolve := func(key string) ([]models.CustomField, []string, error) {
+		fields, ok := resolved[key]
+		if !ok {
+			var err error
+			if fields, err = s.customFieldRepo.GetByKey(organizationID, key); err != nil {
+				return nil, nil, response.NewInternalServerError("Failed to get custom fields", err)
+			}
+			resolved[key] = fields
+		}
+		if len(fields) == 0 {
+			return nil, nil, response.NewBadRequest("Unknown custom field").WithContext("field", key)
+		}
+
+		ids := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if field.Type != fields[0].Type {
+				return nil, nil, response.NewBadRequest("Custom field has different types across categories, filter by category instead").WithContext("field", key)
+			}
+			ids = append(ids, field.ID.String())
+		}
+		return fields, ids, nil
+	}
+
+	var filters []repositories.CustomFieldFilter
+	add := func(values map[string]string, operator string) error {
+		for key, raw := range values {
+			fields, ids, err := resolve(key)
+			if err != nil {
+				return err
+			}
+			field := &fields[0]
+			if operator != "=" && field.Type != models.CustomFieldNumber && field.Type != models.CustomFieldDate {
+				return response.NewBadRequest("Range filters only work on number and date fields").WithContext("field", key)
+			}
+
+			value, err := utils.ParseCustomFieldValue(field, raw)
+			if err != nil {
+				return response.NewBadRequest(err.Error()).WithContext("field", key)
+			}
+			if value == nil {
+				continue
+			}
+			filters = append(filters, repositories.CustomFieldFilter{
+				FieldIDs: ids,
+				Column:   field.ValueColumn(),
+				Operator: operator,
+				Value:    value.Value(),
+			})
+		}
+		return nil
+	}
+
+	if err := add(req.CustomFields, "="); err != nil {
+		return nil, nil, err
+	}
+	if err := add(req.CustomFieldsMin, ">="); err != nil {
+		return nil, nil, err
+	}
+	if err := add(req.CustomFieldsMax, "<="); err != nil {
+		return nil, nil, err
+	}
+
+	var sort *repositories.CustomFieldSort
+	if req.SortBy == "customField" {
+		fields, ids, err := resolve(strings.ToLower(strings.TrimSpace(req.SortField)))
+		if err != nil {
+			return nil, nil, err
+		}
+		sort = &repositories.CustomFieldSort{FieldIDs: ids, Column: fields[0].ValueColumn()}
+	}
+
+	return filters, sort, nil
+}
+
+// loadDepreciationPolicies resolves the effective depreciation policy of every category visible to the organization
+func (s *assetService) loadDepreciationPolicies(organizationID string) (map[uuid.UUID]utils.DepreciationPolicy, error) {
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get depreciation settings", err)
+	}
+	return utils.ResolveDepreciationPolicies(categories), nil
+}
+
+func (s *assetService) convertToResponse(asset *models.Asset, policies map[uuid.UUID]utils.DepreciationPolicy) dto.AssetResponse {
 	response := dto.AssetResponse{
-		ID:           asset.ID.String(),
-		Name:         asset.Name,
-		Description:  asset.Description,
-		LocationID:   asset.LocationID.String(),
-		CategoryID:   asset.CategoryID.String(),
-		UserID:       asset.UserID.String(),
-		Image:        asset.Image,
-		PurchaseDate: asset.PurchaseDate,
-		Price:        asset.Price,
-		Condition:    asset.Condition,
-		SerialNumber: asset.SerialNumber,
-		Warranty:     asset.Warranty,
-		CreatedAt:    asset.CreatedAt,
-		UpdatedAt:    asset.UpdatedAt,
+		ID:             asset.ID.String(),
+		Tag:            asset.TagValue(),
+		Name:           asset.Name,
+		Description:    asset.Description,
+		LocationID:     asset.LocationID.String(),
+		CategoryID:     asset.CategoryID.String(),
+		UserID:         asset.UserID.String(),
+		OrganizationID: asset.OrganizationID.String(),
+		Image:          asset.Image,
+		PurchaseDate:   asset.PurchaseDate,
+		Price:          asset.Price,
+		Condition:      asset.Condition,
+		SerialNumber:   asset.SerialNumber,
+		Warranty:       asset.Warranty,
+		CreatedAt:      asset.CreatedAt,
+		UpdatedAt:      asset.UpdatedAt,
 	}
 
 	// Add location if preloaded
@@ -285,5 +1168,35 @@ func (s *assetService) convertToResponse(asset *models.Asset) dto.AssetResponse
 		}
 	}
 
+	// Add current book value
+	policy, ok := policies[asset.CategoryID]
+	if !ok {
+		policy = utils.DefaultDepreciationPolicy()
+	}
+	depreciation := utils.CalculateDepreciation(asset.Price, asset.PurchaseDate, time.Now(), policy)
+	response.BookValue = depreciation.BookValue
+	response.AccumulatedDepreciation = depreciation.Accumulated
+	response.DepreciationMethod = policy.Method
+
+	// Add current loan if preloaded
+	for _, loan := range asset.Loans {
+		if loan.IsActive() {
+			currentLoan := convertLoanToResponse(&loan)
+			response.CurrentLoan = &currentLoan
+			break
+		}
+	}
+
+	for i := range asset.Attachments {
+		response.Attachments = append(response.Attachments, convertAttachmentToResponse(&asset.Attachments[i]))
+	}
+
+	response.CustomFields = make(map[string]any, len(asset.CustomFieldValues))
+	for i := range asset.CustomFieldValues {
+		if key := asset.CustomFieldValues[i].Field.Key; key != "" {
+			response.CustomFields[key] = asset.CustomFieldValues[i].Value()
+		}
+	}
+
 	return response
 }
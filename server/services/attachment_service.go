@@ -0,0 +1,267 @@
+package services
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+)
+
+// UploadedFile is a file already written to storage by the handler
+type UploadedFile struct {
+	URL      string
+	FileName string
+	MimeType string
+	Size     int64
+}
+
+type AttachmentService interface {
+	GetAttachments(organizationID, assetID string) (*dto.AssetAttachmentsResponse, error)
+	AddAttachments(organizationID, assetID, kind string, files []UploadedFile) (*dto.AssetAttachmentsResponse, error)
+	ReorderAttachments(organizationID, assetID string, req *dto.ReorderAttachmentsRequest) (*dto.AssetAttachmentsResponse, error)
+	SetPrimaryAttachment(organizationID, assetID, attachmentID string) (*dto.AssetAttachmentsResponse, error)
+	DeleteAttachment(organizationID, assetID, attachmentID string) error
+}
+
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepository
+	assetRepo      repositories.AssetRepository
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, assetRepo repositories.AssetRepository) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		assetRepo:      assetRepo,
+	}
+}
+
+func (s *attachmentService) GetAttachments(organizationID, assetID string) (*dto.AssetAttachmentsResponse, error) {
+	if _, err := s.getOrganizationAsset(organizationID, assetID); err != nil {
+		return nil, err
+	}
+	return s.buildResponse(assetID)
+}
+
+func (s *attachmentService) AddAttachments(organizationID, assetID, kind string, files []UploadedFile) (*dto.AssetAttachmentsResponse, error) {
+	asset, err := s.getOrganizationAsset(organizationID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.attachmentRepo.GetNextPosition(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get attachment position", err)
+	}
+
+	hasPrimary := false
+	for _, attachment := range asset.Attachments {
+		if attachment.IsPrimary {
+			hasPrimary = true
+			break
+		}
+	}
+
+	attachments := make([]models.AssetAttachment, 0, len(files))
+	for i, file := range files {
+		attachment := models.AssetAttachment{
+			AssetID:  asset.ID,
+			UserID:   asset.UserID,
+			URL:      file.URL,
+			FileName: file.FileName,
+			MimeType: file.MimeType,
+			Kind:     kind,
+			Size:     file.Size,
+			Position: position + i,
+		}
+
+		// default kind follows the file type
+		if attachment.Kind == "" {
+			attachment.Kind = "other"
+			if attachment.IsImage() {
+				attachment.Kind = "image"
+			}
+		}
+		if attachment.Kind == "image" && !attachment.IsImage() {
+			return nil, response.NewBadRequest("Only image files can be uploaded as kind image")
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	if err := s.attachmentRepo.Create(attachments); err != nil {
+		return nil, response.NewInternalServerError("Failed to save attachments", err)
+	}
+
+	// the first image of an asset becomes its primary image
+	if !hasPrimary {
+		for i := range attachments {
+			if attachments[i].IsImage() {
+				if err := s.attachmentRepo.SetPrimary(assetID, &attachments[i]); err != nil {
+					return nil, response.NewInternalServerError("Failed to set primary image", err)
+				}
+				break
+			}
+		}
+	}
+
+	return s.buildResponse(assetID)
+}
+
+func (s *attachmentService) ReorderAttachments(organizationID, assetID string, req *dto.ReorderAttachmentsRequest) (*dto.AssetAttachmentsResponse, error) {
+	asset, err := s.getOrganizationAsset(organizationID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the new order must list every attachment exactly once
+	existing := make(map[string]bool, len(asset.Attachments))
+	for _, attachment := range asset.Attachments {
+		existing[attachment.ID.String()] = true
+	}
+	if len(req.AttachmentIDs) != len(existing) {
+		return nil, response.NewBadRequest("attachmentIds must contain every attachment of the asset")
+	}
+	seen := make(map[string]bool, len(req.AttachmentIDs))
+	for _, id := range req.AttachmentIDs {
+		if !existing[id] || seen[id] {
+			return nil, response.NewBadRequest("attachmentIds must contain every attachment of the asset exactly once")
+		}
+		seen[id] = true
+	}
+
+	if err := s.attachmentRepo.UpdatePositions(assetID, req.AttachmentIDs); err != nil {
+		return nil, response.NewInternalServerError("Failed to reorder attachments", err)
+	}
+
+	return s.buildResponse(assetID)
+}
+
+func (s *attachmentService) SetPrimaryAttachment(organizationID, assetID, attachmentID string) (*dto.AssetAttachmentsResponse, error) {
+	if _, err := s.getOrganizationAsset(organizationID, assetID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByIDAndAssetID(attachmentID, assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get attachment", err)
+	}
+	if attachment == nil {
+		return nil, response.NewNotFound("Attachment not found")
+	}
+	if !attachment.IsImage() {
+		return nil, response.NewBadRequest("Only images can be the primary image")
+	}
+
+	if err := s.attachmentRepo.SetPrimary(assetID, attachment); err != nil {
+		return nil, response.NewInternalServerError("Failed to set primary image", err)
+	}
+
+	return s.buildResponse(assetID)
+}
+
+func (s *attachmentService) DeleteAttachment(organizationID, assetID, attachmentID string) error {
+	asset, err := s.getOrganizationAsset(organizationID, assetID)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.attachmentRepo.GetByIDAndAssetID(attachmentID, assetID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get attachment", err)
+	}
+	if attachment == nil {
+		return response.NewNotFound("Attachment not found")
+	}
+
+	if err := s.attachmentRepo.Delete(attachment); err != nil {
+		return response.NewInternalServerError("Failed to delete attachment", err)
+	}
+
+	// promote the next image when the primary one is removed
+	if attachment.IsPrimary {
+		var next *models.AssetAttachment
+		for i := range asset.Attachments {
+			candidate := &asset.Attachments[i]
+			if candidate.ID != attachment.ID && candidate.IsImage() {
+				next = candidate
+				break
+			}
+		}
+		if err := s.attachmentRepo.SetPrimary(assetID, next); err != nil {
+			return response.NewInternalServerError("Failed to update primary image", err)
+		}
+	}
+
+	go utils.CleanupImageOnError(attachment.URL)
+
+	return nil
+}
+
+func (s *attachmentService) getOrganizationAsset(organizationID, assetID string) (*models.Asset, error) {
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to manage its attachments")
+	}
+	return asset, nil
+}
+
+func (s *attachmentService) buildResponse(assetID string) (*dto.AssetAttachmentsResponse, error) {
+	attachments, err := s.attachmentRepo.GetByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get attachments", err)
+	}
+
+	resp := &dto.AssetAttachmentsResponse{
+		AssetID:     assetID,
+		Attachments: make([]dto.AttachmentResponse, 0, len(attachments)),
+		Total:       len(attachments),
+	}
+	for i := range attachments {
+		resp.Attachments = append(resp.Attachments, convertAttachmentToResponse(&attachments[i]))
+	}
+
+	return resp, nil
+}
+
+// newImageAttachment wraps an image uploaded through the asset form as an attachment
+func newImageAttachment(assetID, userID uuid.UUID, url, fileName string, position int) models.AssetAttachment {
+	mimeType := mime.TypeByExtension(path.Ext(url))
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType = "image/webp"
+	}
+
+	return models.AssetAttachment{
+		AssetID:  assetID,
+		UserID:   userID,
+		URL:      url,
+		FileName: fileName,
+		MimeType: mimeType,
+		Kind:     "image",
+		Position: position,
+	}
+}
+
+func convertAttachmentToResponse(attachment *models.AssetAttachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:        attachment.ID.String(),
+		AssetID:   attachment.AssetID.String(),
+		URL:       attachment.URL,
+		FileName:  attachment.FileName,
+		MimeType:  attachment.MimeType,
+		Kind:      attachment.Kind,
+		Size:      attachment.Size,
+		Position:  attachment.Position,
+		IsPrimary: attachment.IsPrimary,
+		CreatedAt: attachment.CreatedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AuditService interface {
+	// Record appends an entry with the changed fields, before is nil for creates and after is nil for deletes
+	Record(actor utils.AuditActor, organizationID, action, entity, entityID string, before, after any)
+	GetAuditLogs(organizationID string, req *dto.GetAuditLogsRequest) ([]dto.AuditLogResponse, int, error)
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record never fails the mutation it describes, errors are logged instead
+func (s *auditService) Record(actor utils.AuditActor, organizationID, action, entity, entityID string, before, after any) {
+	beforeJSON, afterJSON, err := utils.AuditDiff(before, after)
+	if err != nil {
+		utils.GetLogger().Error("failed to diff audit entry", zap.String("entity", entity), zap.String("entityId", entityID), zap.Error(err))
+		return
+	}
+
+	// nothing changed
+	if action == models.AuditActionUpdate && string(afterJSON) == "{}" {
+		return
+	}
+
+	entry := &models.AuditLog{
+		OrganizationID: parseOptionalUUID(organizationID),
+		ActorID:        parseOptionalUUID(actor.UserID),
+		ImpersonatorID: parseOptionalUUID(actor.ImpersonatorID),
+		Action:         action,
+		Entity:         entity,
+		EntityID:       entityID,
+		Before:         beforeJSON,
+		After:          afterJSON,
+		IPAddress:      actor.IPAddress,
+		UserAgent:      truncate(actor.UserAgent, 255),
+		RequestID:      actor.RequestID,
+	}
+
+	if err := s.auditRepo.Create(entry); err != nil {
+		utils.GetLogger().Error("failed to write audit entry", zap.String("entity", entity), zap.String("entityId", entityID), zap.Error(err))
+	}
+}
+
+func (s *auditService) GetAuditLogs(organizationID string, req *dto.GetAuditLogsRequest) ([]dto.AuditLogResponse, int, error) {
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, 0, response.NewBadRequest("From date cannot be after to date")
+	}
+
+	logs, total, err := s.auditRepo.GetAuditLogsWithFilter(repositories.AuditFilter{
+		OrganizationID: organizationID,
+		Entity:         req.Entity,
+		EntityID:       req.EntityID,
+		ActorID:        req.Actor,
+		From:           req.From,
+		To:             req.To,
+		Page:           req.Page,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get audit log", err)
+	}
+
+	results := make([]dto.AuditLogResponse, 0, len(logs))
+	for i := range logs {
+		results = append(results, convertAuditLogToResponse(&logs[i]))
+	}
+
+	return results, total, nil
+}
+
+func convertAuditLogToResponse(log *models.AuditLog) dto.AuditLogResponse {
+	resp := dto.AuditLogResponse{
+		ID:        log.ID.String(),
+		Action:    log.Action,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Before:    log.Before,
+		After:     log.After,
+		IPAddress: log.IPAddress,
+		UserAgent: log.UserAgent,
+		RequestID: log.RequestID,
+		CreatedAt: log.CreatedAt,
+	}
+
+	if log.OrganizationID != nil {
+		organizationID := log.OrganizationID.String()
+		resp.OrganizationID = &organizationID
+	}
+	if log.ImpersonatorID != nil {
+		impersonatorID := log.ImpersonatorID.String()
+		resp.ImpersonatorID = &impersonatorID
+	}
+	if log.Actor != nil {
+		resp.Actor = &dto.ActorResponse{
+			ID:       log.Actor.ID.String(),
+			Fullname: log.Actor.Fullname,
+			Email:    log.Actor.Email,
+		}
+	}
+
+	return resp
+}
+
+func parseOptionalUUID(value string) *uuid.UUID {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
@@ -14,29 +14,33 @@ import (
 )
 
 type CategoryService interface {
-	DeleteCategory(userID, categoryID string) error
-	GetCategoriesTree(userID string) (*dto.CategoriesTreeResponse, error)
-	GetCategoriesFlat(userID string) (*dto.CategoriesFlatResponse, error)
-	GetParentCategories(userID string) (*dto.CategoriesTreeResponse, error)
-	GetCategoryByID(userID, categoryID string) (*dto.CategoryResponse, error)
-	GetChildCategories(parentID, userID string) (*dto.CategoriesTreeResponse, error)
-	GetAssetsByCategory(userID, categoryID string) (*dto.CategoryWithAssetsResponse, error)
-	CreateCategory(userID string, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
-	UpdateCategory(userID, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteCategory(actor utils.AuditActor, organizationID, categoryID string) error
+	GetCategoriesTree(organizationID string) (*dto.CategoriesTreeResponse, error)
+	GetCategoriesFlat(organizationID string) (*dto.CategoriesFlatResponse, error)
+	GetParentCategories(organizationID string) (*dto.CategoriesTreeResponse, error)
+	GetCategoryByID(organizationID, categoryID string) (*dto.CategoryResponse, error)
+	GetChildCategories(parentID, organizationID string) (*dto.CategoriesTreeResponse, error)
+	GetAssetsByCategory(organizationID, categoryID string, includeDescendants bool) (*dto.CategoryWithAssetsResponse, error)
+	CreateCategory(actor utils.AuditActor, organizationID string, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	UpdateCategory(actor utils.AuditActor, organizationID, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
 }
 
 type categoryService struct {
-	categoryRepo repositories.CategoryRepository
+	categoryRepo   repositories.CategoryRepository
+	auditService   AuditService
+	webhookService WebhookService
 }
 
-func NewCategoryService(categoryRepo repositories.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepo repositories.CategoryRepository, auditService AuditService, webhookService WebhookService) CategoryService {
 	return &categoryService{
-		categoryRepo: categoryRepo,
+		categoryRepo:   categoryRepo,
+		auditService:   auditService,
+		webhookService: webhookService,
 	}
 }
 
-func (s *categoryService) GetCategoriesTree(userID string) (*dto.CategoriesTreeResponse, error) {
-	cacheKey := fmt.Sprintf("asset_app:cache:categories:tree:%s", userID)
+func (s *categoryService) GetCategoriesTree(organizationID string) (*dto.CategoriesTreeResponse, error) {
+	cacheKey := fmt.Sprintf("asset_app:cache:categories:tree:%s", organizationID)
 
 	// Try cache first
 	var cachedResponse dto.CategoriesTreeResponse
@@ -44,36 +48,24 @@ func (s *categoryService) GetCategoriesTree(userID string) (*dto.CategoriesTreeR
 		return &cachedResponse, nil
 	}
 
-	// Get category tree from database
-	categories, err := s.categoryRepo.GetCategoryTree(userID)
+	// Get all categories and nest them
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get categories", err)
 	}
+	tree := utils.NewCategoryTree(categories)
 
 	// Convert to response format
-	var categoryResponses []dto.CategoryResponse
-	parentCount := 0
-	childCount := 0
-
-	for _, category := range categories {
-		parentCount++
-		categoryResponse := s.convertToResponse(&category, 0)
-
-		// Convert children
-		for _, child := range category.Children {
-			childCount++
-			childResponse := s.convertToResponse(&child, 1)
-			categoryResponse.Children = append(categoryResponse.Children, childResponse)
-		}
-
-		categoryResponses = append(categoryResponses, categoryResponse)
+	categoryResponses := make([]dto.CategoryResponse, 0, len(tree.Roots()))
+	for _, category := range tree.Roots() {
+		categoryResponses = append(categoryResponses, convertCategoryTreeToResponse(tree, category, 0, s.convertToResponse))
 	}
 
 	response := &dto.CategoriesTreeResponse{
 		Categories: categoryResponses,
-		Total:      parentCount + childCount,
-		Parents:    parentCount,
-		Children:   childCount,
+		Total:      len(categories),
+		Parents:    len(tree.Roots()),
+		Children:   len(categories) - len(tree.Roots()),
 	}
 
 	// Cache for 15 minutes
@@ -82,8 +74,8 @@ func (s *categoryService) GetCategoriesTree(userID string) (*dto.CategoriesTreeR
 	return response, nil
 }
 
-func (s *categoryService) GetCategoriesFlat(userID string) (*dto.CategoriesFlatResponse, error) {
-	cacheKey := fmt.Sprintf("asset_app:cache:categories:flat:%s", userID)
+func (s *categoryService) GetCategoriesFlat(organizationID string) (*dto.CategoriesFlatResponse, error) {
+	cacheKey := fmt.Sprintf("asset_app:cache:categories:flat:%s", organizationID)
 
 	// Try cache first
 	var cachedResponse dto.CategoriesFlatResponse
@@ -92,22 +84,23 @@ func (s *categoryService) GetCategoriesFlat(userID string) (*dto.CategoriesFlatR
 	}
 
 	// Get all categories
-	categories, err := s.categoryRepo.GetAllUserCategories(userID)
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get categories", err)
 	}
 
-	// Convert to flat response
-	var categoryResponses []dto.CategoryFlatResponse
-	for _, category := range categories {
+	// Convert to flat response, every category is followed by its subcategories
+	tree := utils.NewCategoryTree(categories)
+	categoryResponses := make([]dto.CategoryFlatResponse, 0, len(categories))
+	for _, category := range tree.DepthFirst() {
 		flatResponse := dto.CategoryFlatResponse{
 			ID:        category.ID.String(),
 			Name:      category.Name,
-			FullName:  s.getFullCategoryName(&category),
+			FullName:  tree.Path(category.ID),
 			IsDefault: category.IsDefault,
-			IsCustom:  category.UserID != nil,
+			IsCustom:  category.OrganizationID != nil,
 			IsParent:  category.ParentID == nil,
-			Level:     s.getCategoryLevel(&category),
+			Level:     tree.Level(category.ID),
 			CreatedAt: category.CreatedAt,
 			UpdatedAt: category.UpdatedAt,
 		}
@@ -131,8 +124,8 @@ func (s *categoryService) GetCategoriesFlat(userID string) (*dto.CategoriesFlatR
 }
 
 // GetParentCategories returns only parent categories
-func (s *categoryService) GetParentCategories(userID string) (*dto.CategoriesTreeResponse, error) {
-	categories, err := s.categoryRepo.GetParentCategories(userID)
+func (s *categoryService) GetParentCategories(organizationID string) (*dto.CategoriesTreeResponse, error) {
+	categories, err := s.categoryRepo.GetParentCategories(organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get parent categories", err)
 	}
@@ -151,15 +144,20 @@ func (s *categoryService) GetParentCategories(userID string) (*dto.CategoriesTre
 }
 
 // GetChildCategories returns children of specific parent
-func (s *categoryService) GetChildCategories(parentID, userID string) (*dto.CategoriesTreeResponse, error) {
-	categories, err := s.categoryRepo.GetChildCategories(parentID, userID)
+func (s *categoryService) GetChildCategories(parentID, organizationID string) (*dto.CategoriesTreeResponse, error) {
+	parent, tree, err := s.getCategory(organizationID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.GetChildCategories(parentID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get child categories", err)
 	}
 
 	var categoryResponses []dto.CategoryResponse
 	for _, category := range categories {
-		categoryResponses = append(categoryResponses, s.convertToResponse(&category, 1))
+		categoryResponses = append(categoryResponses, s.convertToResponse(&category, tree.Level(parent.ID)+1))
 	}
 
 	return &dto.CategoriesTreeResponse{
@@ -171,7 +169,7 @@ func (s *categoryService) GetChildCategories(parentID, userID string) (*dto.Cate
 }
 
 // CreateCategory creates new category (parent or child)
-func (s *categoryService) CreateCategory(userID string, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *categoryService) CreateCategory(actor utils.AuditActor, organizationID string, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
 	// Normalize name
 	req.Name = strings.TrimSpace(req.Name)
 
@@ -179,7 +177,7 @@ func (s *categoryService) CreateCategory(userID string, req *dto.CreateCategoryR
 	var parentUUID *uuid.UUID
 	if req.ParentID != nil && *req.ParentID != "" {
 		// Check if parent exists and user has access
-		hasAccess, err := s.categoryRepo.ValidateParentAccess(*req.ParentID, userID)
+		hasAccess, err := s.categoryRepo.ValidateParentAccess(*req.ParentID, organizationID)
 		if err != nil {
 			return nil, response.NewInternalServerError("Failed to validate parent access", err)
 		}
@@ -195,7 +193,7 @@ func (s *categoryService) CreateCategory(userID string, req *dto.CreateCategoryR
 	}
 
 	// Check if name already exists in same scope (same parent)
-	exists, err := s.categoryRepo.CheckNameExists(req.Name, userID, req.ParentID)
+	exists, err := s.categoryRepo.CheckNameExists(req.Name, organizationID, req.ParentID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to check category name", err)
 	}
@@ -203,39 +201,52 @@ func (s *categoryService) CreateCategory(userID string, req *dto.CreateCategoryR
 		return nil, response.NewConflict("Category name already exists in this scope")
 	}
 
-	// Parse userID
-	userUUID, err := uuid.Parse(userID)
+	// Parse IDs
+	userUUID, err := uuid.Parse(actor.UserID)
 	if err != nil {
 		return nil, response.NewBadRequest("Invalid user ID")
 	}
 
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	tree, err := s.loadCategoryTree(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create category
 	category := &models.Category{
-		ParentID:  parentUUID,
-		Name:      req.Name,
-		UserID:    &userUUID,
-		IsDefault: false,
+		ParentID:       parentUUID,
+		Name:           req.Name,
+		UserID:         &userUUID,
+		OrganizationID: &organizationUUID,
+		IsDefault:      false,
+
+		DepreciationMethod: req.DepreciationMethod,
+		UsefulLifeYears:    req.UsefulLifeYears,
+		SalvagePercent:     req.SalvagePercent,
 	}
 
 	if err := s.categoryRepo.Create(category); err != nil {
 		return nil, response.NewInternalServerError("Failed to create category", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionCreate, models.AuditEntityCategory, category.ID.String(), nil, category)
 
 	// Invalidate cache
-	go s.invalidateUserCache(userID)
+	go s.invalidateOrganizationCache(organizationID)
 
-	level := 0
-	if category.ParentID != nil {
-		level = 1
-	}
+	response := s.convertToResponse(category, tree.LevelUnder(category.ParentID))
+	s.webhookService.Emit(organizationID, models.WebhookEventCategoryCreated, response)
 
-	response := s.convertToResponse(category, level)
 	return &response, nil
 }
 
-func (s *categoryService) UpdateCategory(userID, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *categoryService) UpdateCategory(actor utils.AuditActor, organizationID, categoryID string, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
 	// Get category and check ownership
-	category, err := s.categoryRepo.GetByIDAndUserID(categoryID, userID)
+	category, err := s.categoryRepo.GetByIDAndOrganizationID(categoryID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get category", err)
 	}
@@ -250,7 +261,7 @@ func (s *categoryService) UpdateCategory(userID, categoryID string, req *dto.Upd
 	var parentUUID *uuid.UUID
 	if req.ParentID != nil && *req.ParentID != "" {
 		// Check if parent exists and user has access
-		hasAccess, err := s.categoryRepo.ValidateParentAccess(*req.ParentID, userID)
+		hasAccess, err := s.categoryRepo.ValidateParentAccess(*req.ParentID, organizationID)
 		if err != nil {
 			return nil, response.NewInternalServerError("Failed to validate parent access", err)
 		}
@@ -270,6 +281,16 @@ func (s *categoryService) UpdateCategory(userID, categoryID string, req *dto.Upd
 		}
 	}
 
+	tree, err := s.loadCategoryTree(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cannot move a category below one of its own subcategories
+	if parentUUID != nil && tree.IsDescendant(*parentUUID, category.ID) {
+		return nil, response.NewBadRequest("Category cannot be moved under one of its subcategories")
+	}
+
 	// Check name uniqueness in new scope if name or parent changed
 	nameChanged := !strings.EqualFold(req.Name, category.Name)
 	parentChanged := (req.ParentID == nil && category.ParentID != nil) ||
@@ -277,7 +298,7 @@ func (s *categoryService) UpdateCategory(userID, categoryID string, req *dto.Upd
 		(req.ParentID != nil && category.ParentID != nil && *req.ParentID != category.ParentID.String())
 
 	if nameChanged || parentChanged {
-		exists, err := s.categoryRepo.CheckNameExists(req.Name, userID, req.ParentID)
+		exists, err := s.categoryRepo.CheckNameExists(req.Name, organizationID, req.ParentID)
 		if err != nil {
 			return nil, response.NewInternalServerError("Failed to check category name", err)
 		}
@@ -287,28 +308,31 @@ func (s *categoryService) UpdateCategory(userID, categoryID string, req *dto.Upd
 	}
 
 	// Update category
+	before := *category
 	category.Name = req.Name
 	category.ParentID = parentUUID
+	category.DepreciationMethod = req.DepreciationMethod
+	category.UsefulLifeYears = req.UsefulLifeYears
+	category.SalvagePercent = req.SalvagePercent
 
 	if err := s.categoryRepo.Update(category); err != nil {
 		return nil, response.NewInternalServerError("Failed to update category", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityCategory, category.ID.String(), &before, category)
 
 	// Invalidate cache
-	go s.invalidateUserCache(userID)
+	go s.invalidateOrganizationCache(organizationID)
 
-	level := 0
-	if category.ParentID != nil {
-		level = 1
-	}
+	// the new parent isn't below the category, so its level is unaffected by the move
+	response := s.convertToResponse(category, tree.LevelUnder(category.ParentID))
+	s.webhookService.Emit(organizationID, models.WebhookEventCategoryUpdated, response)
 
-	response := s.convertToResponse(category, level)
 	return &response, nil
 }
 
-func (s *categoryService) DeleteCategory(userID, categoryID string) error {
+func (s *categoryService) DeleteCategory(actor utils.AuditActor, organizationID, categoryID string) error {
 	// Get category and check ownership
-	category, err := s.categoryRepo.GetByIDAndUserID(categoryID, userID)
+	category, err := s.categoryRepo.GetByIDAndOrganizationID(categoryID, organizationID)
 	if err != nil {
 		return response.NewInternalServerError("Failed to get category", err)
 	}
@@ -331,7 +355,7 @@ func (s *categoryService) DeleteCategory(userID, categoryID string) error {
 	}
 
 	// Check if category is being used by assets
-	assetCount, err := s.categoryRepo.CountAssetsByCategory(categoryID, userID)
+	assetCount, err := s.categoryRepo.CountAssetsByCategory(categoryID, organizationID)
 	if err != nil {
 		return response.NewInternalServerError("Failed to check category usage", err)
 	}
@@ -339,50 +363,49 @@ func (s *categoryService) DeleteCategory(userID, categoryID string) error {
 		return response.NewConflict("Cannot delete category that is being used by assets")
 	}
 
+	tree, err := s.loadCategoryTree(organizationID)
+	if err != nil {
+		return err
+	}
+
 	// Delete category
 	if err := s.categoryRepo.Delete(category); err != nil {
 		return response.NewInternalServerError("Failed to delete category", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionDelete, models.AuditEntityCategory, category.ID.String(), category, nil)
+	s.webhookService.Emit(organizationID, models.WebhookEventCategoryDeleted, s.convertToResponse(category, tree.Level(category.ID)))
 
 	// Invalidate cache
-	go s.invalidateUserCache(userID)
+	go s.invalidateOrganizationCache(organizationID)
 
 	return nil
 }
 
-func (s *categoryService) GetCategoryByID(userID, categoryID string) (*dto.CategoryResponse, error) {
-	category, err := s.categoryRepo.GetByID(categoryID)
+func (s *categoryService) GetCategoryByID(organizationID, categoryID string) (*dto.CategoryResponse, error) {
+	category, tree, err := s.getCategory(organizationID, categoryID)
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to get category", err)
-	}
-	if category == nil {
-		return nil, response.NewNotFound("Category not found")
-	}
-
-	// Check if user can access this category
-	if category.UserID != nil && category.UserID.String() != userID {
-		return nil, response.NewNotFound("Category not found")
-	}
-
-	level := 0
-	if category.ParentID != nil {
-		level = 1
+		return nil, err
 	}
 
 	// Convert to response format
-	resp := s.convertToResponse(category, level)
+	resp := s.convertToResponse(category, tree.Level(category.ID))
 	return &resp, nil
 }
 
-func (s *categoryService) GetAssetsByCategory(userID, categoryID string) (*dto.CategoryWithAssetsResponse, error) {
+func (s *categoryService) GetAssetsByCategory(organizationID, categoryID string, includeDescendants bool) (*dto.CategoryWithAssetsResponse, error) {
 	// Get category first
-	categoryResponse, err := s.GetCategoryByID(userID, categoryID)
+	category, tree, err := s.getCategory(organizationID, categoryID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get assets for this category
-	assets, err := s.categoryRepo.GetAssetsByCategory(categoryID, userID)
+	// Get assets for this category, optionally with the ones of every subcategory below it
+	categoryIDs := []uuid.UUID{category.ID}
+	if includeDescendants {
+		categoryIDs = append(categoryIDs, tree.Descendants(category.ID)...)
+	}
+
+	assets, err := s.categoryRepo.GetAssetsByCategories(categoryIDs, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get assets", err)
 	}
@@ -392,6 +415,7 @@ func (s *categoryService) GetAssetsByCategory(userID, categoryID string) (*dto.C
 	for _, asset := range assets {
 		assetResponses = append(assetResponses, dto.CategoryAssetResponse{
 			ID:           asset.ID.String(),
+			CategoryID:   asset.CategoryID.String(),
 			Name:         asset.Name,
 			Description:  asset.Description,
 			Price:        asset.Price,
@@ -402,7 +426,7 @@ func (s *categoryService) GetAssetsByCategory(userID, categoryID string) (*dto.C
 	}
 
 	resp := &dto.CategoryWithAssetsResponse{
-		Category: *categoryResponse,
+		Category: s.convertToResponse(category, tree.Level(category.ID)),
 		Assets:   assetResponses,
 		Total:    len(assetResponses),
 	}
@@ -416,11 +440,15 @@ func (s *categoryService) convertToResponse(category *models.Category, level int
 		ID:        category.ID.String(),
 		Name:      category.Name,
 		IsDefault: category.IsDefault,
-		IsCustom:  category.UserID != nil,
+		IsCustom:  category.OrganizationID != nil,
 		IsParent:  category.ParentID == nil,
 		Level:     level,
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
+
+		DepreciationMethod: category.DepreciationMethod,
+		UsefulLifeYears:    category.UsefulLifeYears,
+		SalvagePercent:     category.SalvagePercent,
 	}
 
 	if category.ParentID != nil {
@@ -430,24 +458,47 @@ func (s *categoryService) convertToResponse(category *models.Category, level int
 	return resp
 }
 
-func (s *categoryService) getFullCategoryName(category *models.Category) string {
-	if category.Parent != nil {
-		return category.Parent.Name + " > " + category.Name
+// loadCategoryTree indexes every category the organization can see, system defaults included
+func (s *categoryService) loadCategoryTree(organizationID string) (*utils.CategoryTree, error) {
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get categories", err)
 	}
-	return category.Name
+	return utils.NewCategoryTree(categories), nil
 }
 
-func (s *categoryService) getCategoryLevel(category *models.Category) int {
-	if category.ParentID == nil {
-		return 0
+// getCategory returns a category visible to the organization together with the tree it belongs to
+func (s *categoryService) getCategory(organizationID, categoryID string) (*models.Category, *utils.CategoryTree, error) {
+	tree, err := s.loadCategoryTree(organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := uuid.Parse(categoryID)
+	if err != nil {
+		return nil, nil, response.NewNotFound("Category not found")
 	}
-	return 1
+	category := tree.Get(id)
+	if category == nil {
+		return nil, nil, response.NewNotFound("Category not found")
+	}
+	return category, tree, nil
+}
+
+// convertCategoryTreeToResponse converts the category with all of its subcategories nested below it
+func convertCategoryTreeToResponse(tree *utils.CategoryTree, category *models.Category, level int, convert func(*models.Category, int) dto.CategoryResponse) dto.CategoryResponse {
+	resp := convert(category, level)
+	for _, child := range tree.Children(category.ID) {
+		resp.Children = append(resp.Children, convertCategoryTreeToResponse(tree, child, level+1, convert))
+	}
+	return resp
 }
 
-func (s *categoryService) invalidateUserCache(userID string) {
+func (s *categoryService) invalidateOrganizationCache(organizationID string) {
 	cacheKeys := []string{
-		fmt.Sprintf("asset_app:cache:categories:tree:%s", userID),
-		fmt.Sprintf("asset_app:cache:categories:flat:%s", userID),
+		fmt.Sprintf("asset_app:cache:categories:tree:%s", organizationID),
+		fmt.Sprintf("asset_app:cache:categories:flat:%s", organizationID),
 	}
 	utils.DeleteKeys(cacheKeys...)
+	invalidateDashboardCache(organizationID)
 }
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+)
+
+// customFieldKeyPattern keeps keys usable as cf[key] query parameters and JSON keys
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type CustomFieldService interface {
+	GetCategoryFields(organizationID, categoryID string) (*dto.CategoryFieldsResponse, error)
+	CreateField(organizationID, categoryID string, req *dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error)
+	UpdateField(organizationID, categoryID, fieldID string, req *dto.UpdateCustomFieldRequest) (*dto.CustomFieldResponse, error)
+	DeleteField(organizationID, categoryID, fieldID string) error
+}
+
+type customFieldService struct {
+	customFieldRepo repositories.CustomFieldRepository
+	categoryRepo    repositories.CategoryRepository
+}
+
+func NewCustomFieldService(customFieldRepo repositories.CustomFieldRepository, categoryRepo repositories.CategoryRepository) CustomFieldService {
+	return &customFieldService{
+		customFieldRepo: customFieldRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+func (s *customFieldService) GetCategoryFields(organizationID, categoryID string) (*dto.CategoryFieldsResponse, error) {
+	category, categories, err := s.getCategory(organizationID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := s.customFieldRepo.GetByOrganizationID(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get custom fields", err)
+	}
+
+	resolved := utils.ResolveCategoryFields(categories, fields, category.ID)
+	responses := make([]dto.CustomFieldResponse, 0, len(resolved))
+	for i := range resolved {
+		responses = append(responses, convertCustomFieldToResponse(&resolved[i], category.ID))
+	}
+
+	return &dto.CategoryFieldsResponse{
+		CategoryID: category.ID.String(),
+		Fields:     responses,
+		Total:      len(responses),
+	}, nil
+}
+
+func (s *customFieldService) CreateField(organizationID, categoryID string, req *dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	category, categories, err := s.getCategory(organizationID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !customFieldKeyPattern.MatchString(key) {
+		return nil, response.NewBadRequest("Key must start with a letter and contain only lowercase letters, digits and underscores")
+	}
+
+	options, err := normalizeCustomFieldOptions(req.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// a key must be unique along every path of the tree, otherwise an asset would get two fields with the same key
+	existing, err := s.customFieldRepo.GetByKey(organizationID, key)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check custom field key", err)
+	}
+	tree := utils.NewCategoryTree(categories)
+	related := append(tree.Ancestry(category.ID), tree.Descendants(category.ID)...)
+	for _, field := range existing {
+		if slices.Contains(related, field.CategoryID) {
+			return nil, response.NewConflict("A custom field with this key already exists on this category, a parent or a subcategory")
+		}
+	}
+
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	field := &models.CustomField{
+		OrganizationID: organizationUUID,
+		CategoryID:     category.ID,
+		Key:            key,
+		Label:          strings.TrimSpace(req.Label),
+		Type:           req.Type,
+		Options:        options,
+		Required:       req.Required,
+	}
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	if err := s.customFieldRepo.Create(field); err != nil {
+		return nil, response.NewInternalServerError("Failed to create custom field", err)
+	}
+
+	resp := convertCustomFieldToResponse(field, category.ID)
+	return &resp, nil
+}
+
+func (s *customFieldService) UpdateField(organizationID, categoryID, fieldID string, req *dto.UpdateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	field, err := s.getField(organizationID, categoryID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := normalizeCustomFieldOptions(field.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// removing an option would leave assets with a value the field no longer allows
+	if field.Type == models.CustomFieldEnum {
+		inUse, err := s.customFieldRepo.CountValuesOutsideOptions(field.ID, options)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to check custom field values", err)
+		}
+		if inUse > 0 {
+			return nil, response.NewConflict("Cannot remove options that are still used by assets").WithContext("assets", inUse)
+		}
+	}
+
+	field.Label = strings.TrimSpace(req.Label)
+	field.Options = options
+	field.Required = req.Required
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	if err := s.customFieldRepo.Update(field); err != nil {
+		return nil, response.NewInternalServerError("Failed to update custom field", err)
+	}
+
+	resp := convertCustomFieldToResponse(field, field.CategoryID)
+	return &resp, nil
+}
+
+func (s *customFieldService) DeleteField(organizationID, categoryID, fieldID string) error {
+	field, err := s.getField(organizationID, categoryID, fieldID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.customFieldRepo.Delete(field); err != nil {
+		return response.NewInternalServerError("Failed to delete custom field", err)
+	}
+	return nil
+}
+
+// getCategory returns the category together with every category the organization can see
+func (s *customFieldService) getCategory(organizationID, categoryID string) (*models.Category, []models.Category, error) {
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, nil, response.NewInternalServerError("Failed to get categories", err)
+	}
+
+	for i := range categories {
+		if categories[i].ID.String() == categoryID {
+			return &categories[i], categories, nil
+		}
+	}
+	return nil, nil, response.NewNotFound("Category not found")
+}
+
+// getField only returns fields defined on the category itself, inherited fields are changed on their parent
+func (s *customFieldService) getField(organizationID, categoryID, fieldID string) (*models.CustomField, error) {
+	field, err := s.customFieldRepo.GetByIDAndOrganizationID(fieldID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get custom field", err)
+	}
+	if field == nil || field.CategoryID.String() != categoryID {
+		return nil, response.NewNotFound("Custom field not found")
+	}
+	return field, nil
+}
+
+func normalizeCustomFieldOptions(fieldType string, options []string) ([]string, error) {
+	if fieldType != models.CustomFieldEnum {
+		if len(options) > 0 {
+			return nil, response.NewBadRequest("Options are only allowed for enum fields")
+		}
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option != "" && !slices.Contains(normalized, option) {
+			normalized = append(normalized, option)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, response.NewBadRequest("Enum fields need at least one option")
+	}
+	return normalized, nil
+}
+
+// customFieldValues merges submitted values into the asset's current ones. Values of fields outside
+// the schema are dropped, which happens when an asset changes category. The result is never nil so
+// it always replaces the stored values.
+func customFieldValues(fields []models.CustomField, current []models.AssetCustomFieldValue, submitted map[string]any) ([]models.AssetCustomFieldValue, error) {
+	byKey := make(map[string]*models.CustomField, len(fields))
+	values := make(map[uuid.UUID]models.AssetCustomFieldValue, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	for _, value := range current {
+		if slices.ContainsFunc(fields, func(f models.CustomField) bool { return f.ID == value.FieldID }) {
+			values[value.FieldID] = value
+		}
+	}
+
+	for key, raw := range submitted {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, response.NewBadRequest("Unknown custom field for this category").WithContext("field", key)
+		}
+		delete(values, field.ID)
+		if raw == nil {
+			continue
+		}
+
+		value, err := utils.ParseCustomFieldValue(field, raw)
+		if err != nil {
+			return nil, response.NewBadRequest(err.Error()).WithContext("field", key)
+		}
+		if value != nil {
+			values[field.ID] = *value
+		}
+	}
+
+	result := make([]models.AssetCustomFieldValue, 0, len(values))
+	for _, field := range fields {
+		value, ok := values[field.ID]
+		if !ok {
+			if field.Required {
+				return nil, response.NewBadRequest(field.Label+" is required").WithContext("field", field.Key)
+			}
+			continue
+		}
+		value.Field = models.CustomField{}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func convertCustomFieldToResponse(field *models.CustomField, categoryID uuid.UUID) dto.CustomFieldResponse {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	return dto.CustomFieldResponse{
+		ID:         field.ID.String(),
+		CategoryID: field.CategoryID.String(),
+		Key:        field.Key,
+		Label:      field.Label,
+		Type:       field.Type,
+		Options:    options,
+		Required:   field.Required,
+		Position:   field.Position,
+		Inherited:  field.CategoryID != categoryID,
+		CreatedAt:  field.CreatedAt,
+		UpdatedAt:  field.UpdatedAt,
+	}
+}
+
+// attachCustomFields sets the field of every value so responses can use the keys
+func attachCustomFields(values []models.AssetCustomFieldValue, fields []models.CustomField) {
+	for i := range values {
+		for _, field := range fields {
+			if field.ID == values[i].FieldID {
+				values[i].Field = field
+				break
+			}
+		}
+	}
+}
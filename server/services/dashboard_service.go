@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+)
+
+const (
+	dashboardTopAssetsLimit = 10
+	dashboardMonths         = 12
+)
+
+type DashboardService interface {
+	GetSummary(organizationID string) (*dto.DashboardSummaryResponse, error)
+}
+
+type dashboardService struct {
+	dashboardRepo repositories.DashboardRepository
+}
+
+func NewDashboardService(dashboardRepo repositories.DashboardRepository) DashboardService {
+	return &dashboardService{
+		dashboardRepo: dashboardRepo,
+	}
+}
+
+func (s *dashboardService) GetSummary(organizationID string) (*dto.DashboardSummaryResponse, error) {
+	cacheKey := dashboardCacheKey(organizationID)
+
+	// Try cache first
+	var cachedResponse dto.DashboardSummaryResponse
+	if err := utils.GetKey(cacheKey, &cachedResponse); err == nil {
+		return &cachedResponse, nil
+	}
+
+	totals, err := s.dashboardRepo.GetTotals(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset totals", err)
+	}
+
+	byCategory, err := s.dashboardRepo.GetCategoryBreakdown(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get category breakdown", err)
+	}
+
+	byLocation, err := s.dashboardRepo.GetLocationBreakdown(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get location breakdown", err)
+	}
+
+	byCondition, err := s.dashboardRepo.GetConditionBreakdown(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get condition breakdown", err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	firstMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(dashboardMonths - 1), 0)
+
+	monthly, err := s.dashboardRepo.GetMonthlyPurchases(organizationID, firstMonth)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get monthly purchases", err)
+	}
+
+	topAssets, err := s.dashboardRepo.GetTopAssets(organizationID, dashboardTopAssetsLimit)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get top assets", err)
+	}
+
+	expiring, err := s.dashboardRepo.GetExpiringWarranties(organizationID, today, today.AddDate(0, 0, 90))
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get expiring warranties", err)
+	}
+
+	summary := &dto.DashboardSummaryResponse{
+		TotalAssets:      totals.Count,
+		TotalValue:       totals.Value,
+		ByCategory:       convertBreakdown(byCategory),
+		ByLocation:       convertBreakdown(byLocation),
+		ByCondition:      convertBreakdown(byCondition),
+		MonthlyPurchases: make([]dto.DashboardMonthlyItem, 0, dashboardMonths),
+		TopAssets:        make([]dto.DashboardAssetItem, 0, len(topAssets)),
+		ExpiringWarranties: dto.DashboardWarrantyResponse{
+			Assets: make([]dto.DashboardAssetItem, 0, len(expiring)),
+		},
+		GeneratedAt: now,
+	}
+
+	// fill months without purchases so the chart always has 12 points
+	monthlyByKey := make(map[string]repositories.AssetStat, len(monthly))
+	for _, stat := range monthly {
+		monthlyByKey[stat.Key] = stat
+	}
+	for i := 0; i < dashboardMonths; i++ {
+		month := firstMonth.AddDate(0, i, 0).Format("2006-01")
+		stat := monthlyByKey[month]
+		summary.MonthlyPurchases = append(summary.MonthlyPurchases, dto.DashboardMonthlyItem{
+			Month: month,
+			Count: stat.Count,
+			Value: stat.Value,
+		})
+	}
+
+	for i := range topAssets {
+		summary.TopAssets = append(summary.TopAssets, convertDashboardAsset(&topAssets[i]))
+	}
+
+	for i := range expiring {
+		item := convertDashboardAsset(&expiring[i])
+		daysLeft := int(expiring[i].Warranty.Sub(today).Hours() / 24)
+		item.DaysLeft = &daysLeft
+
+		switch {
+		case daysLeft <= 30:
+			summary.ExpiringWarranties.Within30++
+			fallthrough
+		case daysLeft <= 60:
+			summary.ExpiringWarranties.Within60++
+			fallthrough
+		default:
+			summary.ExpiringWarranties.Within90++
+		}
+
+		summary.ExpiringWarranties.Assets = append(summary.ExpiringWarranties.Assets, item)
+	}
+
+	go utils.AddKeys(cacheKey, summary, 15*time.Minute)
+
+	return summary, nil
+}
+
+func dashboardCacheKey(organizationID string) string {
+	return fmt.Sprintf("asset_app:cache:dashboard:summary:%s", organizationID)
+}
+
+// invalidateDashboardCache must be called after any write that changes an organization's assets
+func invalidateDashboardCache(organizationID string) {
+	utils.DeleteKeys(dashboardCacheKey(organizationID))
+}
+
+func convertBreakdown(stats []repositories.AssetStat) []dto.DashboardBreakdownItem {
+	items := make([]dto.DashboardBreakdownItem, 0, len(stats))
+	for _, stat := range stats {
+		items = append(items, dto.DashboardBreakdownItem{
+			ID:    stat.Key,
+			Name:  stat.Name,
+			Count: stat.Count,
+			Value: stat.Value,
+		})
+	}
+	return items
+}
+
+func convertDashboardAsset(asset *models.Asset) dto.DashboardAssetItem {
+	return dto.DashboardAssetItem{
+		ID:           asset.ID.String(),
+		Name:         asset.Name,
+		Category:     asset.Category.Name,
+		Location:     asset.Location.Name,
+		Price:        asset.Price,
+		Condition:    asset.Condition,
+		PurchaseDate: asset.PurchaseDate,
+		Warranty:     asset.Warranty,
+	}
+}
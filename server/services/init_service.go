@@ -1,23 +1,58 @@
 package services
 
 import (
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
 	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
 )
 
 type Services struct {
-	UserService     UserService
-	AssetService    AssetService
-	LocationService LocationService
-	CategoryService CategoryService
-	// DashboardService DashboardService
+	UserService         UserService
+	AssetService        AssetService
+	LocationService     LocationService
+	CategoryService     CategoryService
+	LoanService         LoanService
+	ReportService       ReportService
+	DashboardService    DashboardService
+	NotificationService NotificationService
+	MaintenanceService  MaintenanceService
+	AttachmentService   AttachmentService
+	OrganizationService OrganizationService
+	AdminService        AdminService
+	AuditService        AuditService
+	TwoFactorService    TwoFactorService
+	AccessTokenService  AccessTokenService
+	WebhookService      WebhookService
+	LabelService        LabelService
+	StockTakeService    StockTakeService
+	CustomFieldService  CustomFieldService
 }
 
 func InitServices(r *repositories.Repositories) *Services {
+	auditService := NewAuditService(r.AuditRepository)
+	twoFactorService := NewTwoFactorService(r.UserRepository, r.RecoveryCodeRepository, auditService)
+	webhookSender := utils.NewWebhookSender(utils.NewWebhookHTTPClient(config.AppConfig.WebhookAllowPrivateNetworks), config.AppConfig.AppName+" Webhooks")
+	webhookService := NewWebhookService(r.WebhookRepository, webhookSender)
+
 	return &Services{
-		UserService:     NewUserService(r.UserRepository),
-		AssetService:    NewAssetService(r.AssetRepository, r.LocationRepository, r.CategoryRepository),
-		LocationService: NewLocationService(r.LocationRepository),
-		CategoryService: NewCategoryService(r.CategoryRepository),
-		// DashboardService: NewDashboardService(r.DashboardRepository),
+		UserService:         NewUserService(r.UserRepository, r.OrganizationRepository, r.SessionRepository, twoFactorService, auditService),
+		AssetService:        NewAssetService(r.AssetRepository, r.LocationRepository, r.CategoryRepository, r.MovementRepository, r.AttachmentRepository, r.CustomFieldRepository, r.UserRepository, auditService, webhookService),
+		LocationService:     NewLocationService(r.LocationRepository, auditService, webhookService),
+		CategoryService:     NewCategoryService(r.CategoryRepository, auditService, webhookService),
+		LoanService:         NewLoanService(r.LoanRepository, r.AssetRepository, r.OrganizationRepository, auditService, webhookService),
+		ReportService:       NewReportService(r.AssetRepository, r.CategoryRepository),
+		DashboardService:    NewDashboardService(r.DashboardRepository),
+		NotificationService: NewNotificationService(r.NotificationRepository),
+		MaintenanceService:  NewMaintenanceService(r.MaintenanceRepository, r.AssetRepository, r.UserRepository),
+		AttachmentService:   NewAttachmentService(r.AttachmentRepository, r.AssetRepository),
+		OrganizationService: NewOrganizationService(r.OrganizationRepository, r.UserRepository),
+		AdminService:        NewAdminService(r.UserRepository, r.OrganizationRepository, r.CategoryRepository, r.LocationRepository, auditService),
+		AuditService:        auditService,
+		TwoFactorService:    twoFactorService,
+		AccessTokenService:  NewAccessTokenService(r.AccessTokenRepository, r.UserRepository, r.OrganizationRepository),
+		WebhookService:      webhookService,
+		LabelService:        NewLabelService(r.AssetRepository, r.LocationRepository),
+		StockTakeService:    NewStockTakeService(r.StockTakeRepository, r.AssetRepository, r.LocationRepository, auditService, webhookService),
+		CustomFieldService:  NewCustomFieldService(r.CustomFieldRepository, r.CategoryRepository),
 	}
 }
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+)
+
+// maxLabelSheetAssets caps a single printable sheet
+const maxLabelSheetAssets = 500
+
+type LabelService interface {
+	GetAssetLabel(organizationID, assetID string, req *dto.GetAssetLabelRequest) ([]byte, error)
+	GetLabelSheet(organizationID string, req *dto.LabelSheetRequest) ([]byte, error)
+}
+
+type labelService struct {
+	assetRepo    repositories.AssetRepository
+	locationRepo repositories.LocationRepository
+}
+
+func NewLabelService(assetRepo repositories.AssetRepository, locationRepo repositories.LocationRepository) LabelService {
+	return &labelService{assetRepo: assetRepo, locationRepo: locationRepo}
+}
+
+func (s *labelService) GetAssetLabel(organizationID, assetID string, req *dto.GetAssetLabelRequest) ([]byte, error) {
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	code, err := encodeAssetLabel(asset, req.Type, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if req.Format == "svg" {
+		err = utils.RenderLabelSVG(&buf, code, asset.TagValue())
+	} else {
+		err = utils.RenderLabelPNG(&buf, code, asset.TagValue())
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to render label", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *labelService) GetLabelSheet(organizationID string, req *dto.LabelSheetRequest) ([]byte, error) {
+	layout, ok := utils.LabelSheetLayouts[req.Layout]
+	if !ok {
+		layout = utils.LabelSheetLayouts["a4-3x8"]
+	}
+
+	locationIDs, err := subLocationFilter(s.locationRepo, organizationID, req.LocationID, req.IncludeSubLocations)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, total, err := s.assetRepo.GetAssetsWithFilter(repositories.AssetFilter{
+		OrganizationID: organizationID,
+		IDs:            req.AssetIDs,
+		Search:         strings.TrimSpace(req.Search),
+		CategoryID:     req.CategoryID,
+		LocationID:     req.LocationID,
+		LocationIDs:    locationIDs,
+		Condition:      req.Condition,
+		Availability:   req.Availability,
+		SortBy:         req.SortBy,
+		SortOrder:      req.SortOrder,
+		Page:           1,
+		Limit:          maxLabelSheetAssets,
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get assets", err)
+	}
+	if total == 0 {
+		return nil, response.NewBadRequest("No assets match the selection")
+	}
+	if total > maxLabelSheetAssets {
+		return nil, response.NewBadRequest("Too many assets for one label sheet, narrow the selection").
+			WithContext("max", maxLabelSheetAssets)
+	}
+
+	items := make([]utils.LabelSheetItem, 0, len(assets))
+	for i := range assets {
+		code, err := encodeAssetLabel(&assets[i], req.Type, req.Content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, utils.LabelSheetItem{
+			Code:  code,
+			Title: assets[i].Name,
+			Tag:   assets[i].TagValue(),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := utils.WriteLabelSheet(&buf, layout, items); err != nil {
+		return nil, response.NewInternalServerError("Failed to render label sheet", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeAssetLabel encodes a deep link to the asset by default, barcodes always carry the tag
+// since a link is too long to scan reliably
+func encodeAssetLabel(asset *models.Asset, symbology, content string) (*utils.LabelCode, error) {
+	if symbology == "" {
+		symbology = utils.LabelSymbologyQR
+	}
+
+	value := strings.TrimRight(config.AppConfig.FrontendURL, "/") + "/assets/" + asset.ID.String()
+	if content == "tag" || symbology == utils.LabelSymbologyCode128 {
+		value = asset.TagValue()
+	}
+	if value == "" {
+		return nil, response.NewConflict("Asset has no tag yet").WithContext("assetId", asset.ID.String())
+	}
+
+	code, err := utils.EncodeLabel(symbology, value)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to encode label", err)
+	}
+	return code, nil
+}
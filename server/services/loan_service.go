@@ -0,0 +1,265 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+)
+
+type LoanService interface {
+	CheckOutAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.CheckOutAssetRequest) (*dto.LoanResponse, error)
+	CheckInAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.CheckInAssetRequest) (*dto.LoanResponse, error)
+	GetAssetLoans(organizationID, assetID string) (*dto.AssetLoansResponse, error)
+	GetLoans(organizationID string, req *dto.GetLoansRequest) (*[]dto.LoanResponse, int, error)
+}
+
+type loanService struct {
+	loanRepo         repositories.LoanRepository
+	assetRepo        repositories.AssetRepository
+	organizationRepo repositories.OrganizationRepository
+	auditService     AuditService
+	webhookService   WebhookService
+}
+
+func NewLoanService(
+	loanRepo repositories.LoanRepository,
+	assetRepo repositories.AssetRepository,
+	organizationRepo repositories.OrganizationRepository,
+	auditService AuditService,
+	webhookService WebhookService,
+) LoanService {
+	return &loanService{
+		loanRepo:         loanRepo,
+		assetRepo:        assetRepo,
+		organizationRepo: organizationRepo,
+		auditService:     auditService,
+		webhookService:   webhookService,
+	}
+}
+
+func (s *loanService) CheckOutAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.CheckOutAssetRequest) (*dto.LoanResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to lend it")
+	}
+
+	dueAt, err := time.Parse("2006-01-02", req.DueDate)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid due date")
+	}
+
+	now := time.Now()
+	if dueAt.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, response.NewBadRequest("Due date cannot be in the past")
+	}
+
+	userUUID, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	loan := &models.AssetLoan{
+		AssetID:      asset.ID,
+		UserID:       userUUID,
+		BorrowerName: strings.TrimSpace(req.BorrowerName),
+		DueAt:        dueAt,
+		ConditionOut: asset.Condition,
+		NoteOut:      strings.TrimSpace(req.Note),
+	}
+
+	if req.Condition != "" {
+		loan.ConditionOut = req.Condition
+	}
+
+	// Resolve registered borrower among organization members, free-text name is used otherwise
+	if req.BorrowerUserID != "" {
+		membership, err := s.organizationRepo.GetMembership(organizationID, req.BorrowerUserID)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to get borrower", err)
+		}
+		if membership == nil {
+			return nil, response.NewNotFound("Borrower not found in this organization")
+		}
+
+		borrower := &membership.User
+
+		loan.BorrowerUserID = &borrower.ID
+		loan.BorrowerUser = borrower
+		if loan.BorrowerName == "" {
+			loan.BorrowerName = borrower.Fullname
+		}
+	}
+
+	if loan.BorrowerName == "" {
+		return nil, response.NewBadRequest("Either borrower user ID or borrower name is required")
+	}
+
+	// Asset can only be lent to one borrower at a time
+	created, err := s.loanRepo.CheckOut(loan)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check out asset", err)
+	}
+	if !created {
+		return nil, response.NewConflict("Asset is already checked out")
+	}
+	s.auditService.Record(actor, organizationID, models.AuditActionCreate, models.AuditEntityLoan, loan.ID.String(), nil, loan)
+
+	loan.Asset = *asset
+
+	resp := convertLoanToResponse(loan)
+	s.webhookService.Emit(organizationID, models.WebhookEventAssetCheckedOut, resp)
+	return &resp, nil
+}
+
+func (s *loanService) CheckInAsset(actor utils.AuditActor, organizationID, assetID string, req *dto.CheckInAssetRequest) (*dto.LoanResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to check it in")
+	}
+
+	loan, err := s.loanRepo.GetActiveByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get active loan", err)
+	}
+	if loan == nil {
+		return nil, response.NewConflict("Asset is not checked out")
+	}
+
+	now := time.Now()
+	loan.ReturnedAt = &now
+	loan.ConditionIn = req.Condition
+	loan.NoteIn = strings.TrimSpace(req.Note)
+
+	// returned condition becomes the asset's current condition
+	before := *asset
+	asset.Condition = req.Condition
+
+	closed, err := s.loanRepo.CloseLoan(loan, asset)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check in asset", err)
+	}
+	if !closed {
+		return nil, response.NewConflict("Asset is not checked out")
+	}
+	invalidateDashboardCache(organizationID)
+
+	if before.Condition != asset.Condition {
+		s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityAsset, asset.ID.String(), &before, asset)
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetConditionChanged, dto.AssetConditionChangedEvent{
+			AssetID:           asset.ID.String(),
+			Name:              asset.Name,
+			PreviousCondition: before.Condition,
+			Condition:         asset.Condition,
+		})
+	}
+
+	loan.Asset = *asset
+
+	resp := convertLoanToResponse(loan)
+	return &resp, nil
+}
+
+func (s *loanService) GetAssetLoans(organizationID, assetID string) (*dto.AssetLoansResponse, error) {
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	loans, err := s.loanRepo.GetByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset loans", err)
+	}
+
+	loanResponses := make([]dto.LoanResponse, 0, len(loans))
+	for _, loan := range loans {
+		loanResponses = append(loanResponses, convertLoanToResponse(&loan))
+	}
+
+	return &dto.AssetLoansResponse{
+		AssetID: asset.ID.String(),
+		Loans:   loanResponses,
+		Total:   len(loanResponses),
+	}, nil
+}
+
+func (s *loanService) GetLoans(organizationID string, req *dto.GetLoansRequest) (*[]dto.LoanResponse, int, error) {
+	filter := repositories.LoanFilter{
+		OrganizationID: organizationID,
+		Status:         req.Status,
+		Page:           req.Page,
+		Limit:          req.Limit,
+	}
+
+	loans, total, err := s.loanRepo.GetLoansWithFilter(filter)
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get loans", err)
+	}
+
+	loanResponses := make([]dto.LoanResponse, 0, len(loans))
+	for _, loan := range loans {
+		loanResponses = append(loanResponses, convertLoanToResponse(&loan))
+	}
+
+	return &loanResponses, total, nil
+}
+
+// convertLoanToResponse is shared with the asset service for the current loan
+func convertLoanToResponse(loan *models.AssetLoan) dto.LoanResponse {
+	resp := dto.LoanResponse{
+		ID:           loan.ID.String(),
+		AssetID:      loan.AssetID.String(),
+		BorrowerName: loan.BorrowerName,
+		CheckedOutAt: loan.CheckedOutAt,
+		DueAt:        loan.DueAt,
+		ReturnedAt:   loan.ReturnedAt,
+		ConditionOut: loan.ConditionOut,
+		ConditionIn:  loan.ConditionIn,
+		NoteOut:      loan.NoteOut,
+		NoteIn:       loan.NoteIn,
+		Status:       "active",
+	}
+
+	switch {
+	case !loan.IsActive():
+		resp.Status = "returned"
+	case loan.IsOverdue():
+		resp.Status = "overdue"
+	}
+
+	if loan.BorrowerUserID != nil {
+		resp.BorrowerUserID = &[]string{loan.BorrowerUserID.String()}[0]
+	}
+
+	if loan.BorrowerUser != nil {
+		resp.BorrowerEmail = loan.BorrowerUser.Email
+	}
+
+	// Add asset if preloaded
+	if loan.Asset.ID != uuid.Nil {
+		resp.Asset = &dto.LoanAssetResponse{
+			ID:           loan.Asset.ID.String(),
+			Name:         loan.Asset.Name,
+			SerialNumber: loan.Asset.SerialNumber,
+		}
+	}
+
+	return resp
+}
@@ -15,26 +15,31 @@ import (
 )
 
 type LocationService interface {
-	DeleteLocation(userID, locationID string) error
-	GetLocations(userID string) (*dto.LocationsResponse, error)
-	GetLocationByID(userID, locationID string) (*dto.LocationResponse, error)
-	GetAssetsByLocation(userID, locationID string) (*dto.LocationWithAssetsResponse, error)
-	CreateLocation(userID string, req *dto.CreateLocationRequest) (*dto.LocationResponse, error)
-	UpdateLocation(userID, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error)
+	DeleteLocation(actor utils.AuditActor, organizationID, locationID string) error
+	GetLocations(organizationID string) (*dto.LocationsResponse, error)
+	GetLocationTree(organizationID string) (*dto.LocationsTreeResponse, error)
+	GetLocationByID(organizationID, locationID string) (*dto.LocationResponse, error)
+	GetAssetsByLocation(organizationID, locationID string) (*dto.LocationWithAssetsResponse, error)
+	CreateLocation(actor utils.AuditActor, organizationID string, req *dto.CreateLocationRequest) (*dto.LocationResponse, error)
+	UpdateLocation(actor utils.AuditActor, organizationID, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error)
 }
 
 type locationService struct {
-	locationRepo repositories.LocationRepository
+	locationRepo   repositories.LocationRepository
+	auditService   AuditService
+	webhookService WebhookService
 }
 
-func NewLocationService(locationRepo repositories.LocationRepository) LocationService {
+func NewLocationService(locationRepo repositories.LocationRepository, auditService AuditService, webhookService WebhookService) LocationService {
 	return &locationService{
-		locationRepo: locationRepo,
+		locationRepo:   locationRepo,
+		auditService:   auditService,
+		webhookService: webhookService,
 	}
 }
 
-func (s *locationService) GetLocations(userID string) (*dto.LocationsResponse, error) {
-	cacheKey := fmt.Sprintf("asset_app:cache:locations:all:%s", userID)
+func (s *locationService) GetLocations(organizationID string) (*dto.LocationsResponse, error) {
+	cacheKey := fmt.Sprintf("asset_app:cache:locations:all:%s", organizationID)
 
 	// Try cache first
 	var cachedResponse dto.LocationsResponse
@@ -43,22 +48,16 @@ func (s *locationService) GetLocations(userID string) (*dto.LocationsResponse, e
 	}
 
 	// Cache miss - get from database
-	locations, err := s.locationRepo.GetAllUserLocations(userID)
+	locations, err := s.locationRepo.GetAllOrganizationLocations(organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get locations", err)
 	}
 
-	// Convert to response format
-	var locationResp []dto.LocationResponse
-	for _, location := range locations {
-		locationResp = append(locationResp, dto.LocationResponse{
-			ID:        location.ID.String(),
-			Name:      location.Name,
-			IsDefault: location.IsDefault,
-			IsCustom:  location.UserID != nil,
-			CreatedAt: location.CreatedAt,
-			UpdatedAt: location.UpdatedAt,
-		})
+	// Convert to response format, every location is followed by the locations inside it
+	tree := utils.NewLocationTree(locations)
+	locationResp := make([]dto.LocationResponse, 0, len(locations))
+	for _, location := range tree.DepthFirst() {
+		locationResp = append(locationResp, convertLocationToResponse(location, tree))
 	}
 
 	response := &dto.LocationsResponse{
@@ -72,12 +71,58 @@ func (s *locationService) GetLocations(userID string) (*dto.LocationsResponse, e
 	return response, nil
 }
 
-func (s *locationService) CreateLocation(userID string, req *dto.CreateLocationRequest) (*dto.LocationResponse, error) {
+func (s *locationService) GetLocationTree(organizationID string) (*dto.LocationsTreeResponse, error) {
+	cacheKey := fmt.Sprintf("asset_app:cache:locations:tree:%s", organizationID)
+
+	// Try cache first
+	var cachedResponse dto.LocationsTreeResponse
+	if err := utils.GetKey(cacheKey, &cachedResponse); err == nil {
+		return &cachedResponse, nil
+	}
+
+	locations, err := s.locationRepo.GetAllOrganizationLocations(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+	tree := utils.NewLocationTree(locations)
+
+	locationResp := make([]dto.LocationResponse, 0, len(tree.Roots()))
+	for _, location := range tree.Roots() {
+		locationResp = append(locationResp, convertLocationTreeToResponse(tree, location))
+	}
+
+	response := &dto.LocationsTreeResponse{
+		Locations: locationResp,
+		Total:     len(locations),
+		Roots:     len(tree.Roots()),
+	}
+
+	// Cache for 15 minutes
+	go utils.AddKeys(cacheKey, response, 15*time.Minute)
+
+	return response, nil
+}
+
+func (s *locationService) CreateLocation(actor utils.AuditActor, organizationID string, req *dto.CreateLocationRequest) (*dto.LocationResponse, error) {
 	// Normalize name
 	req.Name = strings.TrimSpace(req.Name)
 
-	// Check if name already exists for this user
-	exists, err := s.locationRepo.CheckNameExists(req.Name, userID)
+	tree, err := s.loadLocationTree(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate parent if provided
+	parentUUID, err := validateLocationParent(tree, req.ParentID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	// Check if name already exists next to the new location
+	exists, err := s.locationRepo.CheckNameExists(req.Name, organizationID, req.ParentID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to check location name", err)
 	}
@@ -86,41 +131,43 @@ func (s *locationService) CreateLocation(userID string, req *dto.CreateLocationR
 		return nil, response.NewConflict("Location name already exists")
 	}
 
-	// Parse userID to UUID
-	userUUID, err := uuid.Parse(userID)
+	// Parse IDs to UUID
+	userUUID, err := uuid.Parse(actor.UserID)
 	if err != nil {
 		return nil, response.NewBadRequest("Invalid user ID")
 	}
 
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
 	// Create location
 	location := &models.Location{
-		Name:      req.Name,
-		UserID:    &userUUID,
-		IsDefault: false,
+		ParentID:       parentUUID,
+		Name:           req.Name,
+		UserID:         &userUUID,
+		OrganizationID: &organizationUUID,
+		IsDefault:      false,
 	}
 
 	if err := s.locationRepo.Create(location); err != nil {
 		return nil, response.NewInternalServerError("Failed to create location", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionCreate, models.AuditEntityLocation, location.ID.String(), nil, location)
 
 	// Invalidate cache
-	go s.invalidateUserCache(userID)
+	go s.invalidateOrganizationCache(organizationID)
 
-	resp := &dto.LocationResponse{
-		ID:        location.ID.String(),
-		Name:      location.Name,
-		IsDefault: location.IsDefault,
-		IsCustom:  true,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
-	}
+	resp := convertLocationToResponse(location, tree)
+	s.webhookService.Emit(organizationID, models.WebhookEventLocationCreated, resp)
 
-	return resp, nil
+	return &resp, nil
 }
 
-func (s *locationService) UpdateLocation(userID, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error) {
-	// Get location and check ownership (only user's own locations can be updated)
-	location, err := s.locationRepo.GetByIDAndUserID(locationID, userID)
+func (s *locationService) UpdateLocation(actor utils.AuditActor, organizationID, locationID string, req *dto.UpdateLocationRequest) (*dto.LocationResponse, error) {
+	// Get location and check ownership (only the organization's own locations can be updated)
+	location, err := s.locationRepo.GetByIDAndOrganizationID(locationID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get location", err)
 	}
@@ -132,9 +179,27 @@ func (s *locationService) UpdateLocation(userID, locationID string, req *dto.Upd
 	// Normalize name
 	req.Name = strings.TrimSpace(req.Name)
 
-	// Check if new name already exists
-	if !strings.EqualFold(req.Name, location.Name) {
-		exists, err := s.locationRepo.CheckNameExists(req.Name, userID)
+	tree, err := s.loadLocationTree(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate parent if changed, a location cannot move inside itself
+	parentUUID, err := validateLocationParent(tree, req.ParentID, location.ID)
+	if err != nil {
+		return nil, err
+	}
+	if parentUUID == nil {
+		req.ParentID = nil
+	}
+
+	// Check name uniqueness next to the location if name or parent changed
+	nameChanged := !strings.EqualFold(req.Name, location.Name)
+	parentChanged := (parentUUID == nil) != (location.ParentID == nil) ||
+		(parentUUID != nil && location.ParentID != nil && *parentUUID != *location.ParentID)
+
+	if nameChanged || parentChanged {
+		exists, err := s.locationRepo.CheckNameExists(req.Name, organizationID, req.ParentID)
 		if err != nil {
 			return nil, response.NewInternalServerError("Failed to check location name", err)
 		}
@@ -145,30 +210,28 @@ func (s *locationService) UpdateLocation(userID, locationID string, req *dto.Upd
 	}
 
 	// Update location
+	before := *location
 	location.Name = req.Name
+	location.ParentID = parentUUID
 
 	if err := s.locationRepo.Update(location); err != nil {
 		return nil, response.NewInternalServerError("Failed to update location", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityLocation, location.ID.String(), &before, location)
 
 	// Invalidate cache
-	s.invalidateUserCache(userID)
+	s.invalidateOrganizationCache(organizationID)
 
-	response := &dto.LocationResponse{
-		ID:        location.ID.String(),
-		Name:      location.Name,
-		IsDefault: location.IsDefault,
-		IsCustom:  true,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
-	}
+	// the new parent isn't inside the location, so its path is unaffected by the move
+	response := convertLocationToResponse(location, tree)
+	s.webhookService.Emit(organizationID, models.WebhookEventLocationUpdated, response)
 
-	return response, nil
+	return &response, nil
 }
 
-func (s *locationService) DeleteLocation(userID, locationID string) error {
+func (s *locationService) DeleteLocation(actor utils.AuditActor, organizationID, locationID string) error {
 	// Get location and check ownership
-	location, err := s.locationRepo.GetByIDAndUserID(locationID, userID)
+	location, err := s.locationRepo.GetByIDAndOrganizationID(locationID, organizationID)
 	if err != nil {
 		return response.NewInternalServerError("Failed to get location", err)
 	}
@@ -182,8 +245,18 @@ func (s *locationService) DeleteLocation(userID, locationID string) error {
 		return response.NewForbidden("Cannot delete system default location")
 	}
 
+	// Check if location has sub-locations
+	childCount, err := s.locationRepo.CountChildLocations(locationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to check child locations", err)
+	}
+
+	if childCount > 0 {
+		return response.NewConflict("Cannot delete location that has sub-locations")
+	}
+
 	// Check if location is being used by assets
-	assetCount, err := s.locationRepo.CountAssetsByLocation(locationID, userID)
+	assetCount, err := s.locationRepo.CountAssetsByLocation(locationID, organizationID)
 	if err != nil {
 		return response.NewInternalServerError("Failed to check location usage", err)
 	}
@@ -192,54 +265,44 @@ func (s *locationService) DeleteLocation(userID, locationID string) error {
 		return response.NewConflict("Cannot delete location that is being used by assets")
 	}
 
+	tree, err := s.loadLocationTree(organizationID)
+	if err != nil {
+		return err
+	}
+
 	// Delete location
 	if err := s.locationRepo.Delete(location); err != nil {
 		return response.NewInternalServerError("Failed to delete location", err)
 	}
+	s.auditService.Record(actor, organizationID, models.AuditActionDelete, models.AuditEntityLocation, location.ID.String(), location, nil)
+	s.webhookService.Emit(organizationID, models.WebhookEventLocationDeleted, convertLocationToResponse(location, tree))
 
 	// Invalidate cache
-	go s.invalidateUserCache(userID)
+	go s.invalidateOrganizationCache(organizationID)
 
 	return nil
 }
 
-func (s *locationService) GetLocationByID(userID, locationID string) (*dto.LocationResponse, error) {
-	// Get location by ID
-	location, err := s.locationRepo.GetByID(locationID)
+func (s *locationService) GetLocationByID(organizationID, locationID string) (*dto.LocationResponse, error) {
+	// Get location among the ones the organization can access
+	location, tree, err := s.getLocation(organizationID, locationID)
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to get location", err)
-	}
-
-	if location == nil {
-		return nil, response.NewNotFound("Location not found")
-	}
-
-	// Check if user can access this location
-	if location.UserID != nil && location.UserID.String() != userID {
-		return nil, response.NewNotFound("Location not found")
-	}
-
-	resp := &dto.LocationResponse{
-		ID:        location.ID.String(),
-		Name:      location.Name,
-		IsDefault: location.IsDefault,
-		IsCustom:  location.UserID != nil,
-		CreatedAt: location.CreatedAt,
-		UpdatedAt: location.UpdatedAt,
+		return nil, err
 	}
 
-	return resp, nil
+	resp := convertLocationToResponse(location, tree)
+	return &resp, nil
 }
 
-func (s *locationService) GetAssetsByLocation(userID, locationID string) (*dto.LocationWithAssetsResponse, error) {
+func (s *locationService) GetAssetsByLocation(organizationID, locationID string) (*dto.LocationWithAssetsResponse, error) {
 	// Get location first
-	locationResponse, err := s.GetLocationByID(userID, locationID)
+	locationResponse, err := s.GetLocationByID(organizationID, locationID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get assets for this location
-	assets, err := s.locationRepo.GetAssetsByLocation(locationID, userID)
+	assets, err := s.locationRepo.GetAssetsByLocation(locationID, organizationID)
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to get assets", err)
 	}
@@ -267,7 +330,118 @@ func (s *locationService) GetAssetsByLocation(userID, locationID string) (*dto.L
 	return response, nil
 }
 
-func (s *locationService) invalidateUserCache(userID string) {
-	cacheKey := fmt.Sprintf("asset_app:cache:locations:all:%s", userID)
-	utils.DeleteKeys(cacheKey)
+func (s *locationService) invalidateOrganizationCache(organizationID string) {
+	cacheKeys := []string{
+		fmt.Sprintf("asset_app:cache:locations:all:%s", organizationID),
+		fmt.Sprintf("asset_app:cache:locations:tree:%s", organizationID),
+	}
+	utils.DeleteKeys(cacheKeys...)
+	invalidateDashboardCache(organizationID)
+}
+
+// loadLocationTree indexes every location the organization can see, system defaults included
+func (s *locationService) loadLocationTree(organizationID string) (*utils.LocationTree, error) {
+	locations, err := s.locationRepo.GetAllOrganizationLocations(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+	return utils.NewLocationTree(locations), nil
+}
+
+// getLocation returns a location visible to the organization together with the tree it belongs to
+func (s *locationService) getLocation(organizationID, locationID string) (*models.Location, *utils.LocationTree, error) {
+	tree, err := s.loadLocationTree(organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := uuid.Parse(locationID)
+	if err != nil {
+		return nil, nil, response.NewNotFound("Location not found")
+	}
+	location := tree.Get(id)
+	if location == nil {
+		return nil, nil, response.NewNotFound("Location not found")
+	}
+	return location, tree, nil
+}
+
+// subLocationFilter returns the location followed by every location below it, for AssetFilter.LocationIDs.
+// It is nil unless sub-locations are included, so the filter falls back to LocationID.
+func subLocationFilter(locationRepo repositories.LocationRepository, organizationID, locationID string, includeSubLocations bool) ([]string, error) {
+	if locationID == "" || !includeSubLocations {
+		return nil, nil
+	}
+
+	locations, err := locationRepo.GetAllOrganizationLocations(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get locations", err)
+	}
+
+	// an unknown location still filters by itself and matches nothing
+	locationIDs := []string{locationID}
+	if id, err := uuid.Parse(locationID); err == nil {
+		for _, descendant := range utils.NewLocationTree(locations).Descendants(id) {
+			locationIDs = append(locationIDs, descendant.String())
+		}
+	}
+	return locationIDs, nil
+}
+
+// validateLocationParent makes sure the parent is in the tree and outside the subtree of the location,
+// locationID is uuid.Nil for a new location
+func validateLocationParent(tree *utils.LocationTree, parentID *string, locationID uuid.UUID) (*uuid.UUID, error) {
+	if parentID == nil || *parentID == "" {
+		return nil, nil
+	}
+
+	parentUUID, err := uuid.Parse(*parentID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid parent ID")
+	}
+	if parentUUID == locationID {
+		return nil, response.NewBadRequest("Location cannot be its own parent")
+	}
+	if tree.Get(parentUUID) == nil {
+		return nil, response.NewNotFound("Parent location not found or access denied")
+	}
+	if locationID != uuid.Nil && tree.IsDescendant(parentUUID, locationID) {
+		return nil, response.NewBadRequest("Location cannot be moved inside one of its sub-locations")
+	}
+
+	return &parentUUID, nil
+}
+
+// convertLocationToResponse builds the breadcrumb from the parent, so it also works for a location
+// created or moved after the tree was loaded. System locations have no organization and come out
+// as not custom.
+func convertLocationToResponse(location *models.Location, tree *utils.LocationTree) dto.LocationResponse {
+	resp := dto.LocationResponse{
+		ID:        location.ID.String(),
+		Name:      location.Name,
+		Path:      location.Name,
+		IsDefault: location.IsDefault,
+		IsCustom:  location.OrganizationID != nil,
+		CreatedAt: location.CreatedAt,
+		UpdatedAt: location.UpdatedAt,
+	}
+
+	if location.ParentID != nil {
+		parentID := location.ParentID.String()
+		resp.ParentID = &parentID
+		if tree.Get(*location.ParentID) != nil {
+			resp.Path = tree.Path(*location.ParentID) + utils.TreePathSeparator + location.Name
+		}
+	}
+
+	return resp
+}
+
+// convertLocationTreeToResponse converts the location with every location inside it nested below
+func convertLocationTreeToResponse(tree *utils.LocationTree, location *models.Location) dto.LocationResponse {
+	resp := convertLocationToResponse(location, tree)
+	for _, child := range tree.Children(location.ID) {
+		resp.Children = append(resp.Children, convertLocationTreeToResponse(tree, child))
+	}
+	return resp
 }
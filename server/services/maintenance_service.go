@@ -0,0 +1,296 @@
+package services
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+)
+
+const defaultUpcomingMaintenanceDays = 30
+
+type MaintenanceService interface {
+	CreatePlan(organizationID, userID, assetID string, req *dto.CreateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error)
+	CreateRecord(organizationID, userID, assetID string, req *dto.CreateMaintenanceRecordRequest) (*dto.MaintenanceRecordResponse, error)
+	GetAssetMaintenance(organizationID, assetID string) (*dto.AssetMaintenanceResponse, error)
+	GetUpcoming(organizationID string, req *dto.GetUpcomingMaintenanceRequest) (*dto.UpcomingMaintenanceResponse, error)
+}
+
+type maintenanceService struct {
+	maintenanceRepo repositories.MaintenanceRepository
+	assetRepo       repositories.AssetRepository
+	userRepo        repositories.UserRepository
+}
+
+func NewMaintenanceService(maintenanceRepo repositories.MaintenanceRepository, assetRepo repositories.AssetRepository, userRepo repositories.UserRepository) MaintenanceService {
+	return &maintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		assetRepo:       assetRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (s *maintenanceService) CreatePlan(organizationID, userID, assetID string, req *dto.CreateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to schedule its maintenance")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	// first due date defaults to one interval from today
+	today := startOfDay(time.Now())
+	var nextDueAt time.Time
+	if req.NextDueDate != "" {
+		nextDueAt, err = time.Parse("2006-01-02", req.NextDueDate)
+		if err != nil {
+			return nil, response.NewBadRequest("Invalid next due date")
+		}
+	} else {
+		nextDueAt = today.AddDate(0, 0, *req.IntervalDays)
+	}
+
+	plan := &models.MaintenancePlan{
+		AssetID:       asset.ID,
+		UserID:        userUUID,
+		Title:         strings.TrimSpace(req.Title),
+		IntervalDays:  req.IntervalDays,
+		NextDueAt:     nextDueAt,
+		Vendor:        strings.TrimSpace(req.Vendor),
+		EstimatedCost: req.EstimatedCost,
+		IsActive:      true,
+	}
+
+	if err := s.maintenanceRepo.CreatePlan(plan); err != nil {
+		return nil, response.NewInternalServerError("Failed to create maintenance plan", err)
+	}
+
+	resp := s.convertPlanToResponse(plan)
+	return &resp, nil
+}
+
+func (s *maintenanceService) CreateRecord(organizationID, userID, assetID string, req *dto.CreateMaintenanceRecordRequest) (*dto.MaintenanceRecordResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found or you don't have permission to log its maintenance")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	servicedAt := startOfDay(time.Now())
+	if req.ServicedDate != "" {
+		servicedAt, err = time.Parse("2006-01-02", req.ServicedDate)
+		if err != nil {
+			return nil, response.NewBadRequest("Invalid serviced date")
+		}
+	}
+
+	record := &models.MaintenanceRecord{
+		AssetID:    asset.ID,
+		UserID:     userUUID,
+		Title:      strings.TrimSpace(req.Title),
+		ServicedAt: servicedAt,
+		Vendor:     strings.TrimSpace(req.Vendor),
+		Cost:       req.Cost,
+		Notes:      strings.TrimSpace(req.Notes),
+	}
+
+	// a record fulfilling a plan moves the plan to its next due date
+	var plan *models.MaintenancePlan
+	if req.PlanID != "" {
+		plan, err = s.maintenanceRepo.GetPlanByIDAndAssetID(req.PlanID, assetID)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to get maintenance plan", err)
+		}
+		if plan == nil {
+			return nil, response.NewNotFound("Maintenance plan not found for this asset")
+		}
+
+		record.PlanID = &plan.ID
+		if record.Title == "" {
+			record.Title = plan.Title
+		}
+		if record.Vendor == "" {
+			record.Vendor = plan.Vendor
+		}
+
+		plan.LastServicedAt = &servicedAt
+		if plan.IntervalDays != nil {
+			plan.NextDueAt = servicedAt.AddDate(0, 0, *plan.IntervalDays)
+		} else {
+			plan.IsActive = false
+		}
+	}
+
+	for i, url := range req.AttachmentURLs {
+		fileName := path.Base(url)
+		if i < len(req.Attachments) && req.Attachments[i] != nil {
+			fileName = req.Attachments[i].Filename
+		}
+		record.Attachments = append(record.Attachments, models.MaintenanceAttachment{
+			URL:      url,
+			FileName: fileName,
+		})
+	}
+
+	if err := s.maintenanceRepo.CreateRecord(record, plan); err != nil {
+		return nil, response.NewInternalServerError("Failed to log maintenance", err)
+	}
+
+	// the record is logged by the acting user, asset.User is whoever created the asset
+	if user, err := s.userRepo.GetByID(userID); err == nil && user != nil {
+		record.User = *user
+	}
+
+	resp := s.convertRecordToResponse(record)
+	return &resp, nil
+}
+
+func (s *maintenanceService) GetAssetMaintenance(organizationID, assetID string) (*dto.AssetMaintenanceResponse, error) {
+	// Get asset and check ownership
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(assetID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	plans, err := s.maintenanceRepo.GetPlansByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get maintenance plans", err)
+	}
+
+	records, err := s.maintenanceRepo.GetRecordsByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get maintenance records", err)
+	}
+
+	maintenanceCost, err := s.maintenanceRepo.SumCostByAssetID(assetID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get maintenance cost", err)
+	}
+
+	resp := &dto.AssetMaintenanceResponse{
+		AssetID:              asset.ID.String(),
+		Plans:                make([]dto.MaintenancePlanResponse, 0, len(plans)),
+		Records:              make([]dto.MaintenanceRecordResponse, 0, len(records)),
+		PurchasePrice:        asset.Price,
+		MaintenanceCost:      maintenanceCost,
+		TotalCostOfOwnership: asset.Price + maintenanceCost,
+	}
+
+	for i := range plans {
+		resp.Plans = append(resp.Plans, s.convertPlanToResponse(&plans[i]))
+	}
+	for i := range records {
+		resp.Records = append(resp.Records, s.convertRecordToResponse(&records[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *maintenanceService) GetUpcoming(organizationID string, req *dto.GetUpcomingMaintenanceRequest) (*dto.UpcomingMaintenanceResponse, error) {
+	days := req.Days
+	if days == 0 {
+		days = defaultUpcomingMaintenanceDays
+	}
+	until := startOfDay(time.Now()).AddDate(0, 0, days)
+
+	plans, err := s.maintenanceRepo.GetUpcomingPlans(organizationID, until)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get upcoming maintenance", err)
+	}
+
+	resp := &dto.UpcomingMaintenanceResponse{
+		Until: until.Format("2006-01-02"),
+		Plans: make([]dto.MaintenancePlanResponse, 0, len(plans)),
+		Total: len(plans),
+	}
+
+	for i := range plans {
+		item := s.convertPlanToResponse(&plans[i])
+		item.Asset = &dto.LoanAssetResponse{
+			ID:           plans[i].Asset.ID.String(),
+			Name:         plans[i].Asset.Name,
+			SerialNumber: plans[i].Asset.SerialNumber,
+		}
+		resp.Plans = append(resp.Plans, item)
+	}
+
+	return resp, nil
+}
+
+func (s *maintenanceService) convertPlanToResponse(plan *models.MaintenancePlan) dto.MaintenancePlanResponse {
+	return dto.MaintenancePlanResponse{
+		ID:             plan.ID.String(),
+		AssetID:        plan.AssetID.String(),
+		Title:          plan.Title,
+		IntervalDays:   plan.IntervalDays,
+		NextDueAt:      plan.NextDueAt,
+		Vendor:         plan.Vendor,
+		EstimatedCost:  plan.EstimatedCost,
+		IsActive:       plan.IsActive,
+		IsOverdue:      plan.IsOverdue(),
+		LastServicedAt: plan.LastServicedAt,
+		CreatedAt:      plan.CreatedAt,
+	}
+}
+
+func (s *maintenanceService) convertRecordToResponse(record *models.MaintenanceRecord) dto.MaintenanceRecordResponse {
+	resp := dto.MaintenanceRecordResponse{
+		ID:         record.ID.String(),
+		AssetID:    record.AssetID.String(),
+		Title:      record.Title,
+		ServicedAt: record.ServicedAt,
+		Vendor:     record.Vendor,
+		Cost:       record.Cost,
+		Notes:      record.Notes,
+		LoggedBy: dto.ActorResponse{
+			ID:       record.UserID.String(),
+			Fullname: record.User.Fullname,
+			Email:    record.User.Email,
+		},
+		Attachments: make([]dto.MaintenanceAttachmentResponse, 0, len(record.Attachments)),
+		CreatedAt:   record.CreatedAt,
+	}
+
+	if record.PlanID != nil {
+		planID := record.PlanID.String()
+		resp.PlanID = &planID
+	}
+
+	for _, attachment := range record.Attachments {
+		resp.Attachments = append(resp.Attachments, dto.MaintenanceAttachmentResponse{
+			ID:       attachment.ID.String(),
+			URL:      attachment.URL,
+			FileName: attachment.FileName,
+		})
+	}
+
+	return resp
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
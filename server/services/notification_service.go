@@ -0,0 +1,163 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotificationService interface {
+	GetPreferences(userID string) (*dto.NotificationPreferenceResponse, error)
+	UpdatePreferences(userID string, req *dto.UpdateNotificationPreferenceRequest) (*dto.NotificationPreferenceResponse, error)
+
+	// scheduled jobs
+	SendWarrantyReminders(now time.Time) error
+}
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repositories.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *notificationService) GetPreferences(userID string) (*dto.NotificationPreferenceResponse, error) {
+	preference, err := s.getOrDefaultPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.convertToResponse(preference)
+	return &resp, nil
+}
+
+func (s *notificationService) UpdatePreferences(userID string, req *dto.UpdateNotificationPreferenceRequest) (*dto.NotificationPreferenceResponse, error) {
+	preference, err := s.getOrDefaultPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WarrantyReminders != nil {
+		preference.WarrantyReminders = *req.WarrantyReminders
+	}
+	if req.WarrantyLeadDays != nil {
+		preference.WarrantyLeadDays = *req.WarrantyLeadDays
+	}
+
+	if err := s.notificationRepo.SavePreference(preference); err != nil {
+		return nil, response.NewInternalServerError("Failed to update notification preferences", err)
+	}
+
+	resp := s.convertToResponse(preference)
+	return &resp, nil
+}
+
+// SendWarrantyReminders emails every organization member one digest of the assets with an expiring warranty.
+// Reminders are recorded per recipient, asset and warranty date, so a failed email is retried on the next run.
+func (s *notificationService) SendWarrantyReminders(now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	due, err := s.notificationRepo.GetDueWarrantyReminders(today)
+	if err != nil {
+		return fmt.Errorf("failed to get due warranty reminders: %w", err)
+	}
+
+	// group per recipient, reminders come ordered by recipient
+	byUser := make(map[uuid.UUID][]repositories.DueWarrantyReminder)
+	var userIDs []uuid.UUID
+	for _, reminder := range due {
+		if _, exists := byUser[reminder.User.ID]; !exists {
+			userIDs = append(userIDs, reminder.User.ID)
+		}
+		byUser[reminder.User.ID] = append(byUser[reminder.User.ID], reminder)
+	}
+
+	failed := 0
+	for _, userID := range userIDs {
+		if err := s.sendUserWarrantyReminder(byUser[userID], today); err != nil {
+			failed++
+			utils.GetLogger().Error("failed to send warranty reminder", zap.String("userId", userID.String()), zap.Error(err))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d warranty reminders failed", failed, len(userIDs))
+	}
+	return nil
+}
+
+func (s *notificationService) sendUserWarrantyReminder(due []repositories.DueWarrantyReminder, today time.Time) error {
+	user := due[0].User
+
+	preference, err := s.getOrDefaultPreference(user.ID.String())
+	if err != nil {
+		return err
+	}
+
+	items := make([]utils.WarrantyReminderItem, 0, len(due))
+	reminders := make([]models.WarrantyReminder, 0, len(due))
+	for _, reminder := range due {
+		asset := reminder.Asset
+		items = append(items, utils.WarrantyReminderItem{
+			Name:         asset.Name,
+			SerialNumber: asset.SerialNumber,
+			Location:     asset.Location.Name,
+			Warranty:     asset.Warranty.Format("2006-01-02"),
+			DaysLeft:     int(asset.Warranty.Sub(today).Hours() / 24),
+		})
+		reminders = append(reminders, models.WarrantyReminder{
+			AssetID:  asset.ID,
+			UserID:   user.ID,
+			Warranty: *asset.Warranty,
+		})
+	}
+
+	dashboardURL := strings.TrimRight(config.AppConfig.FrontendURL, "/") + "/dashboard/assets"
+	if err := utils.SendWarrantyReminderEmail(user.Email, user.Fullname, dashboardURL, preference.WarrantyLeadDays, items); err != nil {
+		return err
+	}
+
+	return s.notificationRepo.CreateWarrantyReminders(reminders)
+}
+
+// getOrDefaultPreference returns the stored preferences or an unsaved default record
+func (s *notificationService) getOrDefaultPreference(userID string) (*models.NotificationPreference, error) {
+	preference, err := s.notificationRepo.GetPreferenceByUserID(userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get notification preferences", err)
+	}
+	if preference != nil {
+		return preference, nil
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	return &models.NotificationPreference{
+		UserID:            userUUID,
+		WarrantyReminders: true,
+		WarrantyLeadDays:  models.DefaultWarrantyLeadDays,
+	}, nil
+}
+
+func (s *notificationService) convertToResponse(preference *models.NotificationPreference) dto.NotificationPreferenceResponse {
+	return dto.NotificationPreferenceResponse{
+		WarrantyReminders: preference.WarrantyReminders,
+		WarrantyLeadDays:  preference.WarrantyLeadDays,
+	}
+}
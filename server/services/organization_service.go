@@ -0,0 +1,359 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrganizationService interface {
+	GetOrganizations(userID, activeOrganizationID string) (*dto.OrganizationsResponse, error)
+	GetOrganization(userID, activeOrganizationID, organizationID string) (*dto.OrganizationResponse, error)
+	CreateOrganization(userID string, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	UpdateOrganization(userID, activeOrganizationID, organizationID string, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error)
+	SwitchOrganization(c *gin.Context, userID, organizationID string) (*dto.OrganizationResponse, error)
+
+	// member features
+	AddMember(userID, organizationID string, req *dto.AddOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error)
+	RemoveMember(userID, organizationID, memberUserID string) error
+
+	// role features
+	GetRoles() []dto.RoleResponse
+	GetMembers(organizationID string) (*dto.OrganizationMembersResponse, error)
+	AssignRole(userID, organizationID, memberUserID string, req *dto.AssignRoleRequest) (*dto.OrganizationMemberResponse, error)
+}
+
+type organizationService struct {
+	organizationRepo repositories.OrganizationRepository
+	userRepo         repositories.UserRepository
+}
+
+func NewOrganizationService(organizationRepo repositories.OrganizationRepository, userRepo repositories.UserRepository) OrganizationService {
+	return &organizationService{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (s *organizationService) GetOrganizations(userID, activeOrganizationID string) (*dto.OrganizationsResponse, error) {
+	memberships, err := s.organizationRepo.GetMembershipsByUserID(userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get organizations", err)
+	}
+
+	organizations := make([]dto.OrganizationResponse, 0, len(memberships))
+	for _, member := range memberships {
+		organizations = append(organizations, convertOrganizationToResponse(&member.Organization, member.Role, activeOrganizationID))
+	}
+
+	return &dto.OrganizationsResponse{
+		Organizations: organizations,
+		Total:         len(organizations),
+	}, nil
+}
+
+func (s *organizationService) GetOrganization(userID, activeOrganizationID, organizationID string) (*dto.OrganizationResponse, error) {
+	member, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.organizationRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get organization members", err)
+	}
+
+	resp := convertOrganizationToResponse(&member.Organization, member.Role, activeOrganizationID)
+	resp.Members = make([]dto.OrganizationMemberResponse, 0, len(members))
+	for _, m := range members {
+		resp.Members = append(resp.Members, convertOrganizationMemberToResponse(&m))
+	}
+
+	return &resp, nil
+}
+
+func (s *organizationService) CreateOrganization(userID string, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	organization := &models.Organization{
+		Name:    strings.TrimSpace(req.Name),
+		OwnerID: userUUID,
+	}
+	owner := &models.OrganizationMember{
+		UserID: userUUID,
+		Role:   models.OrganizationRoleOwner,
+	}
+
+	if err := s.organizationRepo.Create(organization, owner); err != nil {
+		return nil, response.NewInternalServerError("Failed to create organization", err)
+	}
+
+	resp := convertOrganizationToResponse(organization, owner.Role, "")
+	return &resp, nil
+}
+
+func (s *organizationService) UpdateOrganization(userID, activeOrganizationID, organizationID string, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	member, err := s.getMembershipWithPermission(organizationID, userID, utils.PermissionOrganizationManage)
+	if err != nil {
+		return nil, err
+	}
+
+	organization := &member.Organization
+	organization.Name = strings.TrimSpace(req.Name)
+	if req.AssetTagPrefix != "" {
+		organization.AssetTagPrefix = strings.ToUpper(req.AssetTagPrefix)
+	}
+
+	if err := s.organizationRepo.Update(organization); err != nil {
+		return nil, response.NewInternalServerError("Failed to update organization", err)
+	}
+
+	resp := convertOrganizationToResponse(organization, member.Role, activeOrganizationID)
+	return &resp, nil
+}
+
+// SwitchOrganization makes the organization active and reissues the access token for it
+func (s *organizationService) SwitchOrganization(c *gin.Context, userID, organizationID string) (*dto.OrganizationResponse, error) {
+	member, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationRepo.SetActiveOrganization(userID, organizationID); err != nil {
+		return nil, response.NewInternalServerError("Failed to switch organization", err)
+	}
+
+	// keep the admin flag and any impersonation of the current session
+	accessToken, err := utils.GenerateAccessToken(utils.AccessTokenSubject{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		Role:           member.Role,
+		IsAdmin:        c.GetBool("isAdmin"),
+		ImpersonatorID: c.GetString("impersonatorID"),
+		SessionID:      c.GetString("sessionID"),
+		EmailVerified:  c.GetBool("emailVerified"),
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate access token", err)
+	}
+
+	// set accessToken
+	utils.SetAccessTokenCookie(c, accessToken)
+
+	resp := convertOrganizationToResponse(&member.Organization, member.Role, organizationID)
+	return &resp, nil
+}
+
+func (s *organizationService) AddMember(userID, organizationID string, req *dto.AddOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error) {
+	actor, err := s.getMembershipWithPermission(organizationID, userID, utils.PermissionMembersManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.Organization.IsPersonal {
+		return nil, response.NewBadRequest("Members cannot be added to a personal organization")
+	}
+
+	if req.Role == models.OrganizationRoleOwner && actor.Role != models.OrganizationRoleOwner {
+		return nil, response.NewForbidden("Only owners can add other owners")
+	}
+
+	user, err := s.userRepo.GetByEmail(strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get user", err)
+	}
+	if user == nil {
+		return nil, response.NewNotFound("No user is registered with this email")
+	}
+
+	existing, err := s.organizationRepo.GetMembership(organizationID, user.ID.String())
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check membership", err)
+	}
+	if existing != nil {
+		return nil, response.NewConflict("User is already a member of this organization")
+	}
+
+	member := &models.OrganizationMember{
+		OrganizationID: actor.OrganizationID,
+		UserID:         user.ID,
+		Role:           req.Role,
+	}
+	if err := s.organizationRepo.AddMember(member); err != nil {
+		return nil, response.NewInternalServerError("Failed to add member", err)
+	}
+
+	member.User = *user
+	resp := convertOrganizationMemberToResponse(member)
+	return &resp, nil
+}
+
+// RemoveMember lets members leave on their own and managers remove others,
+// as long as the organization keeps at least one owner
+func (s *organizationService) RemoveMember(userID, organizationID, memberUserID string) error {
+	actor, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	if userID != memberUserID && !utils.HasPermission(actor.Role, utils.PermissionMembersManage) {
+		return response.NewForbidden("You don't have permission to remove other members")
+	}
+
+	member, err := s.organizationRepo.GetMembership(organizationID, memberUserID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get member", err)
+	}
+	if member == nil {
+		return response.NewNotFound("Member not found")
+	}
+
+	if member.Role == models.OrganizationRoleOwner {
+		if userID != memberUserID && actor.Role != models.OrganizationRoleOwner {
+			return response.NewForbidden("Only owners can remove other owners")
+		}
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.organizationRepo.RemoveMember(member); err != nil {
+		return response.NewInternalServerError("Failed to remove member", err)
+	}
+
+	return nil
+}
+
+func (s *organizationService) getMembership(organizationID, userID string) (*models.OrganizationMember, error) {
+	member, err := s.organizationRepo.GetMembership(organizationID, userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get organization", err)
+	}
+	if member == nil {
+		return nil, response.NewNotFound("Organization not found")
+	}
+	return member, nil
+}
+
+func (s *organizationService) GetRoles() []dto.RoleResponse {
+	roles := make([]dto.RoleResponse, 0, len(utils.Roles))
+	for _, role := range utils.Roles {
+		roles = append(roles, dto.RoleResponse{
+			Name:        role,
+			Permissions: utils.RolePermissions(role),
+		})
+	}
+	return roles
+}
+
+func (s *organizationService) GetMembers(organizationID string) (*dto.OrganizationMembersResponse, error) {
+	members, err := s.organizationRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get organization members", err)
+	}
+
+	resp := &dto.OrganizationMembersResponse{
+		OrganizationID: organizationID,
+		Members:        make([]dto.OrganizationMemberResponse, 0, len(members)),
+		Total:          len(members),
+	}
+	for i := range members {
+		resp.Members = append(resp.Members, convertOrganizationMemberToResponse(&members[i]))
+	}
+
+	return resp, nil
+}
+
+// AssignRole changes a member's role, granting or revoking the owner role is reserved to owners
+func (s *organizationService) AssignRole(userID, organizationID, memberUserID string, req *dto.AssignRoleRequest) (*dto.OrganizationMemberResponse, error) {
+	// the role in the access token may be stale, so check the stored one
+	actor, err := s.getMembershipWithPermission(organizationID, userID, utils.PermissionMembersManage)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.organizationRepo.GetMembership(organizationID, memberUserID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get member", err)
+	}
+	if member == nil {
+		return nil, response.NewNotFound("Member not found")
+	}
+
+	ownerChange := member.Role != req.Role && (member.Role == models.OrganizationRoleOwner || req.Role == models.OrganizationRoleOwner)
+	if ownerChange && actor.Role != models.OrganizationRoleOwner {
+		return nil, response.NewForbidden("Only owners can grant or revoke the owner role")
+	}
+
+	if member.Role == models.OrganizationRoleOwner && req.Role != models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	if member.Role != req.Role {
+		member.Role = req.Role
+		if err := s.organizationRepo.UpdateMember(member); err != nil {
+			return nil, response.NewInternalServerError("Failed to assign role", err)
+		}
+	}
+
+	resp := convertOrganizationMemberToResponse(member)
+	return &resp, nil
+}
+
+func (s *organizationService) getMembershipWithPermission(organizationID, userID, permission string) (*models.OrganizationMember, error) {
+	member, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.HasPermission(member.Role, permission) {
+		return nil, response.NewForbidden("You don't have permission to perform this action").
+			WithContext("permission", permission)
+	}
+	return member, nil
+}
+
+func (s *organizationService) ensureAnotherOwner(organizationID string) error {
+	owners, err := s.organizationRepo.CountOwners(organizationID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to count owners", err)
+	}
+	if owners <= 1 {
+		return response.NewConflict("An organization must keep at least one owner")
+	}
+	return nil
+}
+
+func convertOrganizationToResponse(organization *models.Organization, role, activeOrganizationID string) dto.OrganizationResponse {
+	return dto.OrganizationResponse{
+		ID:             organization.ID.String(),
+		Name:           organization.Name,
+		IsPersonal:     organization.IsPersonal,
+		OwnerID:        organization.OwnerID.String(),
+		AssetTagPrefix: organization.AssetTagPrefix,
+		Role:           role,
+		IsActive:       organization.ID.String() == activeOrganizationID,
+		CreatedAt:      organization.CreatedAt,
+	}
+}
+
+func convertOrganizationMemberToResponse(member *models.OrganizationMember) dto.OrganizationMemberResponse {
+	return dto.OrganizationMemberResponse{
+		UserID:   member.UserID.String(),
+		Fullname: member.User.Fullname,
+		Email:    member.User.Email,
+		Avatar:   member.User.Avatar,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt,
+	}
+}
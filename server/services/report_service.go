@@ -0,0 +1,106 @@
+package services
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+)
+
+type ReportService interface {
+	GetDepreciationReport(organizationID string, req *dto.DepreciationReportRequest) (*dto.DepreciationReportResponse, error)
+}
+
+type reportService struct {
+	assetRepo    repositories.AssetRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewReportService(assetRepo repositories.AssetRepository, categoryRepo repositories.CategoryRepository) ReportService {
+	return &reportService{
+		assetRepo:    assetRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *reportService) GetDepreciationReport(organizationID string, req *dto.DepreciationReportRequest) (*dto.DepreciationReportResponse, error) {
+	// default to today
+	asOf := time.Now()
+	if req.AsOf != "" {
+		parsed, err := time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			return nil, response.NewBadRequest("asOf must be in YYYY-MM-DD format")
+		}
+		asOf = parsed
+	}
+
+	assets, err := s.assetRepo.GetAllByOrganizationID(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get assets", err)
+	}
+
+	categories, err := s.categoryRepo.GetAllOrganizationCategories(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get depreciation settings", err)
+	}
+	policies := utils.ResolveDepreciationPolicies(categories)
+
+	report := &dto.DepreciationReportResponse{
+		AsOf:   asOf.Format("2006-01-02"),
+		Assets: make([]dto.AssetDepreciationResponse, 0, len(assets)),
+	}
+
+	for _, asset := range assets {
+		// assets bought after the report date are not part of it
+		if asset.PurchaseDate != nil && asset.PurchaseDate.After(asOf) {
+			continue
+		}
+
+		policy, ok := policies[asset.CategoryID]
+		if !ok {
+			policy = utils.DefaultDepreciationPolicy()
+		}
+
+		result := utils.CalculateDepreciation(asset.Price, asset.PurchaseDate, asOf, policy)
+
+		item := dto.AssetDepreciationResponse{
+			AssetID:                 asset.ID.String(),
+			Name:                    asset.Name,
+			Category:                asset.Category.Name,
+			Method:                  policy.Method,
+			UsefulLifeYears:         policy.UsefulLifeYears,
+			SalvagePercent:          policy.SalvagePercent,
+			Cost:                    asset.Price,
+			SalvageValue:            result.SalvageValue,
+			PurchaseDate:            asset.PurchaseDate,
+			AccumulatedDepreciation: result.Accumulated,
+			BookValue:               result.BookValue,
+			Schedule:                []dto.DepreciationYearResponse{},
+		}
+
+		if asset.PurchaseDate != nil {
+			for _, year := range utils.DepreciationSchedule(asset.Price, *asset.PurchaseDate, policy) {
+				item.Schedule = append(item.Schedule, dto.DepreciationYearResponse{
+					Year:           year.Year,
+					StartDate:      year.StartDate,
+					EndDate:        year.EndDate,
+					StartBookValue: year.StartBookValue,
+					Depreciation:   year.Depreciation,
+					Accumulated:    year.Accumulated,
+					EndBookValue:   year.EndBookValue,
+				})
+			}
+		}
+
+		report.Assets = append(report.Assets, item)
+		report.TotalCost += item.Cost
+		report.TotalAccumulated += item.AccumulatedDepreciation
+		report.TotalBookValue += item.BookValue
+	}
+
+	report.Total = len(report.Assets)
+
+	return report, nil
+}
@@ -0,0 +1,616 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+	"github.com/fiqrioemry/go-api-toolkit/response"
+
+	"github.com/google/uuid"
+)
+
+type StockTakeService interface {
+	GetStockTakes(organizationID string, req *dto.GetStockTakesRequest) (*[]dto.StockTakeResponse, int, error)
+	GetStockTakeByID(organizationID, stockTakeID string) (*dto.StockTakeResponse, error)
+	CreateStockTake(userID, organizationID string, req *dto.CreateStockTakeRequest) (*dto.StockTakeResponse, error)
+	CancelStockTake(organizationID, stockTakeID string) (*dto.StockTakeResponse, error)
+	CloseStockTake(actor utils.AuditActor, organizationID, stockTakeID string, req *dto.CloseStockTakeRequest) (*dto.StockTakeReportResponse, error)
+	GetReport(organizationID, stockTakeID string) (*dto.StockTakeReportResponse, error)
+
+	// items
+	GetItems(organizationID, stockTakeID string, req *dto.GetStockTakeItemsRequest) (*[]dto.StockTakeItemResponse, int, error)
+	ScanItem(userID, organizationID, stockTakeID string, req *dto.ScanStockTakeItemRequest) (*dto.StockTakeItemResponse, error)
+	UpdateItem(userID, organizationID, stockTakeID, itemID string, req *dto.UpdateStockTakeItemRequest) (*dto.StockTakeItemResponse, error)
+}
+
+type stockTakeService struct {
+	stockTakeRepo  repositories.StockTakeRepository
+	assetRepo      repositories.AssetRepository
+	locationRepo   repositories.LocationRepository
+	auditService   AuditService
+	webhookService WebhookService
+}
+
+func NewStockTakeService(
+	stockTakeRepo repositories.StockTakeRepository,
+	assetRepo repositories.AssetRepository,
+	locationRepo repositories.LocationRepository,
+	auditService AuditService,
+	webhookService WebhookService,
+) StockTakeService {
+	return &stockTakeService{
+		stockTakeRepo:  stockTakeRepo,
+		assetRepo:      assetRepo,
+		locationRepo:   locationRepo,
+		auditService:   auditService,
+		webhookService: webhookService,
+	}
+}
+
+func (s *stockTakeService) GetStockTakes(organizationID string, req *dto.GetStockTakesRequest) (*[]dto.StockTakeResponse, int, error) {
+	stockTakes, total, err := s.stockTakeRepo.GetWithFilter(repositories.StockTakeFilter{
+		OrganizationID: organizationID,
+		Status:         req.Status,
+		Page:           req.Page,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get stock-takes", err)
+	}
+
+	responses := make([]dto.StockTakeResponse, 0, len(stockTakes))
+	for i := range stockTakes {
+		responses = append(responses, convertStockTakeToResponse(&stockTakes[i], nil))
+	}
+
+	return &responses, total, nil
+}
+
+func (s *stockTakeService) GetStockTakeByID(organizationID, stockTakeID string) (*dto.StockTakeResponse, error) {
+	stockTake, err := s.getStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.getSummary(stockTake.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := convertStockTakeToResponse(stockTake, summary)
+	return &resp, nil
+}
+
+func (s *stockTakeService) CreateStockTake(userID, organizationID string, req *dto.CreateStockTakeRequest) (*dto.StockTakeResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	locationIDs := make([]uuid.UUID, 0, len(req.LocationIDs))
+	for _, locationID := range req.LocationIDs {
+		location, err := s.getAccessibleLocation(organizationID, locationID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(locationIDs, location.ID) {
+			locationIDs = append(locationIDs, location.ID)
+		}
+	}
+
+	// two open audits of the same room would count the same assets twice
+	busy, err := s.stockTakeRepo.HasOpenForLocations(organizationID, locationIDs)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check open stock-takes", err)
+	}
+	if busy {
+		return nil, response.NewConflict("A stock-take is already open for one of these locations")
+	}
+
+	stockTake := &models.StockTake{
+		OrganizationID: organizationUUID,
+		Name:           strings.TrimSpace(req.Name),
+		Note:           strings.TrimSpace(req.Note),
+		Status:         models.StockTakeOpen,
+		StartedBy:      userUUID,
+	}
+
+	if err := s.stockTakeRepo.Create(stockTake, locationIDs); err != nil {
+		return nil, response.NewInternalServerError("Failed to create stock-take", err)
+	}
+
+	return s.GetStockTakeByID(organizationID, stockTake.ID.String())
+}
+
+func (s *stockTakeService) CancelStockTake(organizationID, stockTakeID string) (*dto.StockTakeResponse, error) {
+	stockTake, err := s.getOpenStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	stockTake.Status = models.StockTakeCancelled
+	if err := s.stockTakeRepo.Update(stockTake); err != nil {
+		return nil, response.NewInternalServerError("Failed to cancel stock-take", err)
+	}
+
+	return s.GetStockTakeByID(organizationID, stockTakeID)
+}
+
+// CloseStockTake marks every unchecked item missing. With ApplyCorrections the observed location
+// and condition are written back to the assets, corrections are skipped for assets that were
+// moved or changed through other means since the stock-take started.
+func (s *stockTakeService) CloseStockTake(actor utils.AuditActor, organizationID, stockTakeID string, req *dto.CloseStockTakeRequest) (*dto.StockTakeReportResponse, error) {
+	stockTake, err := s.getOpenStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	items, err := s.stockTakeRepo.GetAllItems(stockTakeID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take items", err)
+	}
+
+	var corrections []repositories.StockTakeCorrection
+	if req.ApplyCorrections {
+		note := "Stock-take: " + stockTake.Name
+		for i := range items {
+			item := &items[i]
+			if item.Asset.DeletedAt.Valid || (item.Status != models.StockTakeItemFound && item.Status != models.StockTakeItemFoundElsewhere) {
+				continue
+			}
+
+			correction := repositories.StockTakeCorrection{
+				AssetID:            item.AssetID,
+				ExpectedLocationID: item.ExpectedLocationID,
+				ExpectedCondition:  item.ExpectedCondition,
+			}
+			if item.Status == models.StockTakeItemFoundElsewhere && item.ObservedLocationID != nil && *item.ObservedLocationID != item.ExpectedLocationID {
+				fromLocationID := item.ExpectedLocationID
+				correction.Movement = &models.AssetMovement{
+					AssetID:        item.AssetID,
+					FromLocationID: &fromLocationID,
+					ToLocationID:   *item.ObservedLocationID,
+					UserID:         userUUID,
+					Note:           note,
+				}
+			}
+			if item.ConditionChanged() {
+				correction.Condition = item.ObservedCondition
+			}
+			if correction.Movement != nil || correction.Condition != "" {
+				corrections = append(corrections, correction)
+			}
+		}
+	}
+
+	now := time.Now()
+	stockTake.Status = models.StockTakeClosed
+	stockTake.ClosedBy = &userUUID
+	stockTake.ClosedAt = &now
+	stockTake.CorrectionsApplied = req.ApplyCorrections
+
+	closed, err := s.stockTakeRepo.Close(stockTake, corrections)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to close stock-take", err)
+	}
+	if !closed {
+		return nil, response.NewConflict("Stock-take is no longer open")
+	}
+
+	changed := false
+	for i := range corrections {
+		correction := &corrections[i]
+		if !correction.Moved && !correction.ConditionChanged {
+			continue
+		}
+		changed = true
+		s.recordStockTakeCorrection(actor, organizationID, correction, items)
+	}
+	if changed {
+		invalidateDashboardCache(organizationID)
+	}
+
+	return s.GetReport(organizationID, stockTakeID)
+}
+
+func (s *stockTakeService) GetReport(organizationID, stockTakeID string) (*dto.StockTakeReportResponse, error) {
+	stockTake, err := s.getStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.stockTakeRepo.GetAllItems(stockTakeID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take items", err)
+	}
+
+	summary := &dto.StockTakeSummary{}
+	report := &dto.StockTakeReportResponse{
+		Missing:          []dto.StockTakeItemResponse{},
+		FoundElsewhere:   []dto.StockTakeItemResponse{},
+		ConditionChanges: []dto.StockTakeItemResponse{},
+		Pending:          []dto.StockTakeItemResponse{},
+	}
+
+	for i := range items {
+		item := &items[i]
+		countStockTakeItem(summary, item.Status, item.Unlisted, 1)
+
+		switch item.Status {
+		case models.StockTakeItemMissing:
+			report.Missing = append(report.Missing, convertStockTakeItemToResponse(item))
+		case models.StockTakeItemFoundElsewhere:
+			report.FoundElsewhere = append(report.FoundElsewhere, convertStockTakeItemToResponse(item))
+		case models.StockTakeItemPending:
+			report.Pending = append(report.Pending, convertStockTakeItemToResponse(item))
+		}
+		if item.ConditionChanged() {
+			summary.ConditionChanged++
+			report.ConditionChanges = append(report.ConditionChanges, convertStockTakeItemToResponse(item))
+		}
+	}
+
+	report.StockTake = convertStockTakeToResponse(stockTake, summary)
+	return report, nil
+}
+
+func (s *stockTakeService) GetItems(organizationID, stockTakeID string, req *dto.GetStockTakeItemsRequest) (*[]dto.StockTakeItemResponse, int, error) {
+	if _, err := s.getStockTake(organizationID, stockTakeID); err != nil {
+		return nil, 0, err
+	}
+
+	items, total, err := s.stockTakeRepo.GetItemsWithFilter(repositories.StockTakeItemFilter{
+		StockTakeID: stockTakeID,
+		Status:      req.Status,
+		LocationID:  req.LocationID,
+		Page:        req.Page,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get stock-take items", err)
+	}
+
+	responses := make([]dto.StockTakeItemResponse, 0, len(items))
+	for i := range items {
+		responses = append(responses, convertStockTakeItemToResponse(&items[i]))
+	}
+
+	return &responses, total, nil
+}
+
+// ScanItem records an asset as seen at one of the audited locations. Assets that weren't expected
+// anywhere in the stock-take are added as unlisted items.
+func (s *stockTakeService) ScanItem(userID, organizationID, stockTakeID string, req *dto.ScanStockTakeItemRequest) (*dto.StockTakeItemResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	stockTake, err := s.getOpenStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	locationID, err := uuid.Parse(req.LocationID)
+	if err != nil || !stockTake.HasLocation(locationID) {
+		return nil, response.NewBadRequest("Location is not part of this stock-take")
+	}
+
+	var asset *models.Asset
+	if req.AssetID != "" {
+		asset, err = s.assetRepo.GetByIDAndOrganizationID(req.AssetID, organizationID)
+	} else {
+		asset, err = s.assetRepo.GetByTagAndOrganizationID(strings.ToUpper(strings.TrimSpace(req.Tag)), organizationID)
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get asset", err)
+	}
+	if asset == nil {
+		return nil, response.NewNotFound("Asset not found")
+	}
+
+	item, err := s.stockTakeRepo.GetItemByAssetID(stockTakeID, asset.ID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take item", err)
+	}
+	if item == nil {
+		item = &models.StockTakeItem{
+			StockTakeID:        stockTake.ID,
+			AssetID:            asset.ID,
+			ExpectedLocationID: asset.LocationID,
+			ExpectedCondition:  asset.Condition,
+			Unlisted:           true,
+		}
+	}
+
+	item.Status = models.StockTakeItemFoundElsewhere
+	if locationID == item.ExpectedLocationID {
+		item.Status = models.StockTakeItemFound
+	}
+	item.ObservedLocationID = &locationID
+	item.ObservedCondition = item.ExpectedCondition
+	if req.Condition != "" {
+		item.ObservedCondition = req.Condition
+	}
+	item.Note = strings.TrimSpace(req.Note)
+	markStockTakeItemChecked(item, userUUID)
+
+	if item.ID == uuid.Nil {
+		err = s.stockTakeRepo.CreateItem(item)
+	} else {
+		err = s.stockTakeRepo.UpdateItem(item)
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to save stock-take item", err)
+	}
+
+	return s.getItemResponse(stockTakeID, item.ID.String())
+}
+
+// UpdateItem marks an item by hand, e.g. when a tag is unreadable or to reset a wrong scan
+func (s *stockTakeService) UpdateItem(userID, organizationID, stockTakeID, itemID string, req *dto.UpdateStockTakeItemRequest) (*dto.StockTakeItemResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+
+	if _, err := s.getOpenStockTake(organizationID, stockTakeID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.stockTakeRepo.GetItemByID(stockTakeID, itemID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take item", err)
+	}
+	if item == nil {
+		return nil, response.NewNotFound("Stock-take item not found")
+	}
+
+	item.Status = req.Status
+	item.Note = strings.TrimSpace(req.Note)
+
+	switch req.Status {
+	case models.StockTakeItemPending:
+		item.ObservedLocationID = nil
+		item.ObservedCondition = ""
+		item.CheckedBy = nil
+		item.CheckedAt = nil
+	case models.StockTakeItemMissing:
+		item.ObservedLocationID = nil
+		item.ObservedCondition = ""
+		markStockTakeItemChecked(item, userUUID)
+	case models.StockTakeItemFound, models.StockTakeItemFoundElsewhere:
+		observedLocationID := item.ExpectedLocationID
+		if req.Status == models.StockTakeItemFoundElsewhere {
+			location, err := s.getAccessibleLocation(organizationID, req.LocationID)
+			if err != nil {
+				return nil, err
+			}
+			if location.ID == item.ExpectedLocationID {
+				return nil, response.NewBadRequest("Location is the expected location, mark the item as found instead")
+			}
+			observedLocationID = location.ID
+		}
+		item.ObservedLocationID = &observedLocationID
+		item.ObservedCondition = item.ExpectedCondition
+		if req.Condition != "" {
+			item.ObservedCondition = req.Condition
+		}
+		markStockTakeItemChecked(item, userUUID)
+	}
+
+	if err := s.stockTakeRepo.UpdateItem(item); err != nil {
+		return nil, response.NewInternalServerError("Failed to update stock-take item", err)
+	}
+
+	return s.getItemResponse(stockTakeID, itemID)
+}
+
+func (s *stockTakeService) getStockTake(organizationID, stockTakeID string) (*models.StockTake, error) {
+	stockTake, err := s.stockTakeRepo.GetByIDAndOrganizationID(stockTakeID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take", err)
+	}
+	if stockTake == nil {
+		return nil, response.NewNotFound("Stock-take not found")
+	}
+	return stockTake, nil
+}
+
+func (s *stockTakeService) getOpenStockTake(organizationID, stockTakeID string) (*models.StockTake, error) {
+	stockTake, err := s.getStockTake(organizationID, stockTakeID)
+	if err != nil {
+		return nil, err
+	}
+	if !stockTake.IsOpen() {
+		return nil, response.NewConflict("Stock-take is already " + stockTake.Status)
+	}
+	return stockTake, nil
+}
+
+func (s *stockTakeService) getSummary(stockTakeID string) (*dto.StockTakeSummary, error) {
+	counts, err := s.stockTakeRepo.CountItems(stockTakeID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to count stock-take items", err)
+	}
+
+	summary := &dto.StockTakeSummary{}
+	for _, count := range counts {
+		countStockTakeItem(summary, count.Status, count.Unlisted, count.Count)
+		summary.ConditionChanged += count.ConditionChanged
+	}
+	return summary, nil
+}
+
+func (s *stockTakeService) getItemResponse(stockTakeID, itemID string) (*dto.StockTakeItemResponse, error) {
+	item, err := s.stockTakeRepo.GetItemByID(stockTakeID, itemID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get stock-take item", err)
+	}
+	if item == nil {
+		return nil, response.NewNotFound("Stock-take item not found")
+	}
+
+	resp := convertStockTakeItemToResponse(item)
+	return &resp, nil
+}
+
+// getAccessibleLocation returns a system default location or one owned by the organization
+func (s *stockTakeService) getAccessibleLocation(organizationID, locationID string) (*models.Location, error) {
+	location, err := s.locationRepo.GetByID(locationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to validate location", err)
+	}
+	if location == nil || (location.OrganizationID != nil && location.OrganizationID.String() != organizationID) {
+		return nil, response.NewNotFound("Location not found or access denied")
+	}
+	return location, nil
+}
+
+// recordStockTakeCorrection audits an applied correction and emits its events. Corrections skip assets
+// changed since the stock-take started, so the state before it only differs in the corrected fields.
+func (s *stockTakeService) recordStockTakeCorrection(actor utils.AuditActor, organizationID string, correction *repositories.StockTakeCorrection, items []models.StockTakeItem) {
+	asset, err := s.assetRepo.GetByIDAndOrganizationID(correction.AssetID.String(), organizationID)
+	if err != nil || asset == nil {
+		return
+	}
+
+	before := *asset
+	if correction.Moved {
+		before.LocationID = correction.ExpectedLocationID
+	}
+	if correction.ConditionChanged {
+		before.Condition = correction.ExpectedCondition
+	}
+	s.auditService.Record(actor, organizationID, models.AuditActionUpdate, models.AuditEntityAsset, asset.ID.String(), &before, asset)
+
+	if correction.ConditionChanged {
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetConditionChanged, dto.AssetConditionChangedEvent{
+			AssetID:           asset.ID.String(),
+			Name:              asset.Name,
+			PreviousCondition: before.Condition,
+			Condition:         asset.Condition,
+		})
+	}
+	if correction.Moved {
+		s.webhookService.Emit(organizationID, models.WebhookEventAssetMoved, s.movementEventResponse(correction.Movement, items))
+	}
+}
+
+// movementEventResponse fills the location names from the stock-take items, the movement itself
+// is created without its associations
+func (s *stockTakeService) movementEventResponse(movement *models.AssetMovement, items []models.StockTakeItem) dto.AssetMovementResponse {
+	for i := range items {
+		if items[i].AssetID == movement.AssetID {
+			movement.FromLocation = &items[i].ExpectedLocation
+			if items[i].ObservedLocation != nil {
+				movement.ToLocation = *items[i].ObservedLocation
+			}
+			break
+		}
+	}
+	return convertMovementToResponse(movement)
+}
+
+func markStockTakeItemChecked(item *models.StockTakeItem, userID uuid.UUID) {
+	now := time.Now()
+	item.CheckedBy = &userID
+	item.CheckedAt = &now
+}
+
+func countStockTakeItem(summary *dto.StockTakeSummary, status string, unlisted bool, count int) {
+	if unlisted {
+		summary.Unlisted += count
+	} else {
+		summary.Expected += count
+	}
+
+	switch status {
+	case models.StockTakeItemPending:
+		summary.Pending += count
+	case models.StockTakeItemFound:
+		summary.Found += count
+	case models.StockTakeItemMissing:
+		summary.Missing += count
+	case models.StockTakeItemFoundElsewhere:
+		summary.FoundElsewhere += count
+	}
+}
+
+func convertStockTakeToResponse(stockTake *models.StockTake, summary *dto.StockTakeSummary) dto.StockTakeResponse {
+	locations := make([]dto.LocationResponse, 0, len(stockTake.Locations))
+	for _, location := range stockTake.Locations {
+		locations = append(locations, convertStockTakeLocation(&location.Location))
+	}
+
+	resp := dto.StockTakeResponse{
+		ID:                 stockTake.ID.String(),
+		Name:               stockTake.Name,
+		Note:               stockTake.Note,
+		Status:             stockTake.Status,
+		Locations:          locations,
+		StartedBy:          stockTake.StartedBy.String(),
+		ClosedAt:           stockTake.ClosedAt,
+		CorrectionsApplied: stockTake.CorrectionsApplied,
+		Summary:            summary,
+		CreatedAt:          stockTake.CreatedAt,
+	}
+	if stockTake.ClosedBy != nil {
+		closedBy := stockTake.ClosedBy.String()
+		resp.ClosedBy = &closedBy
+	}
+
+	return resp
+}
+
+func convertStockTakeItemToResponse(item *models.StockTakeItem) dto.StockTakeItemResponse {
+	resp := dto.StockTakeItemResponse{
+		ID:                item.ID.String(),
+		AssetID:           item.AssetID.String(),
+		AssetName:         item.Asset.Name,
+		AssetTag:          item.Asset.TagValue(),
+		Status:            item.Status,
+		Unlisted:          item.Unlisted,
+		ExpectedLocation:  convertStockTakeLocation(&item.ExpectedLocation),
+		ExpectedCondition: item.ExpectedCondition,
+		ObservedCondition: item.ObservedCondition,
+		Note:              item.Note,
+		CheckedAt:         item.CheckedAt,
+	}
+	if item.ObservedLocation != nil {
+		observed := convertStockTakeLocation(item.ObservedLocation)
+		resp.ObservedLocation = &observed
+	}
+	if item.CheckedBy != nil {
+		checkedBy := item.CheckedBy.String()
+		resp.CheckedBy = &checkedBy
+	}
+
+	return resp
+}
+
+func convertStockTakeLocation(location *models.Location) dto.LocationResponse {
+	return dto.LocationResponse{
+		ID:        location.ID.String(),
+		Name:      location.Name,
+		IsDefault: location.IsDefault,
+		IsCustom:  location.OrganizationID != nil,
+		CreatedAt: location.CreatedAt,
+		UpdatedAt: location.UpdatedAt,
+	}
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"go.uber.org/zap"
+)
+
+type TwoFactorService interface {
+	GetStatus(userID string) (*dto.TwoFactorStatusResponse, error)
+	Setup(userID string, req *dto.SetupTwoFactorRequest) (*dto.SetupTwoFactorResponse, error)
+	Enable(actor utils.AuditActor, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	Disable(actor utils.AuditActor, req *dto.DisableTwoFactorRequest) error
+	SendEmailCode(userID string) error
+	RegenerateRecoveryCodes(actor utils.AuditActor, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+
+	// sign in challenges
+	StartChallenge(user *models.User) (*dto.TwoFactorChallengeResponse, error)
+	ResendChallenge(challengeToken string) (*dto.TwoFactorChallengeResponse, error)
+	VerifyChallenge(challengeToken, code string) (*models.User, error)
+}
+
+type twoFactorService struct {
+	user         repositories.UserRepository
+	recoveryCode repositories.RecoveryCodeRepository
+	audit        AuditService
+}
+
+func NewTwoFactorService(user repositories.UserRepository, recoveryCode repositories.RecoveryCodeRepository, audit AuditService) TwoFactorService {
+	return &twoFactorService{user, recoveryCode, audit}
+}
+
+const (
+	twoFactorSetupTTL     = 10 * time.Minute
+	twoFactorChallengeTTL = 5 * time.Minute
+	twoFactorEmailCodeTTL = 5 * time.Minute
+	twoFactorMaxAttempts  = 5
+	twoFactorMaxEmails    = 5
+	recoveryCodeCount     = 10
+)
+
+// pendingTwoFactorSetup is kept in redis until the user confirms the method with a first code
+type pendingTwoFactorSetup struct {
+	Method string `json:"method"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type twoFactorChallenge struct {
+	UserID string `json:"userId"`
+}
+
+func twoFactorSetupKey(userID string) string {
+	return "asset_app:2fa_setup:" + userID
+}
+
+func twoFactorChallengeKey(token string) string {
+	return "asset_app:2fa_challenge:" + utils.HashToken(token)
+}
+
+func twoFactorEmailCodeKey(userID string) string {
+	return "asset_app:2fa_email_code:" + userID
+}
+
+func twoFactorAttemptsKey(userID string) string {
+	return "asset_app:2fa_attempt:" + userID
+}
+
+func twoFactorTOTPStepKey(userID string) string {
+	return "asset_app:2fa_totp_step:" + userID
+}
+
+func (s *twoFactorService) GetStatus(userID string) (*dto.TwoFactorStatusResponse, error) {
+	user, err := s.user.GetByID(userID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	status := &dto.TwoFactorStatusResponse{
+		Enabled:   user.HasTwoFactor(),
+		Method:    user.TwoFactorMethod,
+		EnabledAt: user.TwoFactorEnabledAt,
+	}
+
+	if user.HasTwoFactor() {
+		if status.RecoveryCodesLeft, err = s.recoveryCode.CountUnused(userID); err != nil {
+			return nil, response.NewInternalServerError("Failed to count recovery codes", err)
+		}
+	}
+
+	return status, nil
+}
+
+func (s *twoFactorService) Setup(userID string, req *dto.SetupTwoFactorRequest) (*dto.SetupTwoFactorResponse, error) {
+	user, err := s.user.GetByID(userID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	if user.HasTwoFactor() {
+		return nil, response.NewConflict("Two-factor authentication is already enabled")
+	}
+
+	pending := pendingTwoFactorSetup{Method: req.Method}
+	setup := &dto.SetupTwoFactorResponse{
+		Method:    req.Method,
+		ExpiresAt: time.Now().Add(twoFactorSetupTTL),
+	}
+
+	switch req.Method {
+	case models.TwoFactorMethodTOTP:
+		secret, err := utils.GenerateTOTPSecret()
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to generate TOTP secret", err)
+		}
+		pending.Secret = secret
+		setup.Secret = secret
+		setup.ProvisioningURI = utils.TOTPProvisioningURI(secret, config.AppConfig.AppName, user.Email)
+
+	case models.TwoFactorMethodEmail:
+		if err := s.sendEmailCode(user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := utils.AddKeys(twoFactorSetupKey(userID), pending, twoFactorSetupTTL); err != nil {
+		return nil, response.NewInternalServerError("Failed to store two-factor setup", err)
+	}
+
+	return setup, nil
+}
+
+func (s *twoFactorService) Enable(actor utils.AuditActor, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	if user.HasTwoFactor() {
+		return nil, response.NewConflict("Two-factor authentication is already enabled")
+	}
+
+	var pending pendingTwoFactorSetup
+	if err := utils.GetKey(twoFactorSetupKey(actor.UserID), &pending); err != nil {
+		return nil, response.NewBadRequest("Two-factor setup has expired, please start again")
+	}
+
+	// the first code proves the authenticator or mailbox actually works
+	candidate := *user
+	candidate.TwoFactorMethod = pending.Method
+	candidate.TwoFactorSecret = pending.Secret
+	if err := s.checkCode(&candidate, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	before := *user
+	user.TwoFactorMethod = pending.Method
+	user.TwoFactorSecret = pending.Secret
+	user.TwoFactorEnabledAt = &now
+	if err := s.user.Update(user); err != nil {
+		return nil, response.NewInternalServerError("Failed to enable two-factor authentication", err)
+	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+	utils.DeleteKeys(twoFactorSetupKey(actor.UserID))
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *twoFactorService) Disable(actor utils.AuditActor, req *dto.DisableTwoFactorRequest) error {
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
+		return response.NewNotFound("User not found")
+	}
+
+	if !user.HasTwoFactor() {
+		return response.NewBadRequest("Two-factor authentication is not enabled")
+	}
+
+	// re-authenticate with both factors
+	if user.HasPassword() && !utils.CheckPasswordHash(req.Password, user.Password) {
+		return response.NewBadRequest("Password is incorrect")
+	}
+	if err := s.checkCode(user, req.Code, true); err != nil {
+		return err
+	}
+
+	before := *user
+	user.TwoFactorMethod = ""
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = nil
+	if err := s.user.Update(user); err != nil {
+		return response.NewInternalServerError("Failed to disable two-factor authentication", err)
+	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+	if err := s.recoveryCode.DeleteByUserID(actor.UserID); err != nil {
+		return response.NewInternalServerError("Failed to delete recovery codes", err)
+	}
+
+	return nil
+}
+
+// SendEmailCode mails a code to users with email two-factor so they can confirm sensitive changes
+func (s *twoFactorService) SendEmailCode(userID string) error {
+	user, err := s.user.GetByID(userID)
+	if err != nil || user == nil {
+		return response.NewNotFound("User not found")
+	}
+
+	if user.TwoFactorMethod != models.TwoFactorMethodEmail {
+		return response.NewBadRequest("Email two-factor authentication is not enabled")
+	}
+
+	return s.sendEmailCode(user)
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(actor utils.AuditActor, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	if !user.HasTwoFactor() {
+		return nil, response.NewBadRequest("Two-factor authentication is not enabled")
+	}
+
+	// a recovery code can't be used to mint new ones
+	if err := s.checkCode(user, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *twoFactorService) StartChallenge(user *models.User) (*dto.TwoFactorChallengeResponse, error) {
+	token, err := utils.GenerateResetToken()
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate two-factor challenge", err)
+	}
+
+	challenge := twoFactorChallenge{UserID: user.ID.String()}
+	if err := utils.AddKeys(twoFactorChallengeKey(token), challenge, twoFactorChallengeTTL); err != nil {
+		return nil, response.NewInternalServerError("Failed to store two-factor challenge", err)
+	}
+
+	if user.TwoFactorMethod == models.TwoFactorMethodEmail {
+		if err := s.sendEmailCode(user); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.TwoFactorChallengeResponse{
+		ChallengeToken: token,
+		Method:         user.TwoFactorMethod,
+		ExpiresAt:      time.Now().Add(twoFactorChallengeTTL),
+	}, nil
+}
+
+func (s *twoFactorService) ResendChallenge(challengeToken string) (*dto.TwoFactorChallengeResponse, error) {
+	user, err := s.challengeUser(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorMethod != models.TwoFactorMethodEmail {
+		return nil, response.NewBadRequest("Codes can only be resent for email two-factor authentication")
+	}
+
+	if err := s.sendEmailCode(user); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorChallengeResponse{
+		ChallengeToken: challengeToken,
+		Method:         user.TwoFactorMethod,
+		ExpiresAt:      time.Now().Add(twoFactorChallengeTTL),
+	}, nil
+}
+
+// VerifyChallenge completes a sign in, the challenge can only be completed once
+func (s *twoFactorService) VerifyChallenge(challengeToken, code string) (*models.User, error) {
+	user, err := s.challengeUser(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCode(user, code, true); err != nil {
+		return nil, err
+	}
+
+	utils.DeleteKeys(twoFactorChallengeKey(challengeToken))
+
+	return user, nil
+}
+
+func (s *twoFactorService) challengeUser(challengeToken string) (*models.User, error) {
+	var challenge twoFactorChallenge
+	if err := utils.GetKey(twoFactorChallengeKey(challengeToken), &challenge); err != nil {
+		return nil, response.NewUnauthorized("Two-factor challenge is invalid or has expired")
+	}
+
+	user, err := s.user.GetByID(challenge.UserID)
+	if err != nil || user == nil {
+		return nil, response.NewUnauthorized("Two-factor challenge is invalid or has expired")
+	}
+
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Your account has been deactivated")
+	}
+
+	if !user.HasTwoFactor() {
+		return nil, response.NewUnauthorized("Two-factor challenge is invalid or has expired")
+	}
+
+	return user, nil
+}
+
+// checkCode verifies a code for the user's method, or a recovery code when allowRecovery is set.
+// Failed attempts are limited per user across every place a code is asked for.
+func (s *twoFactorService) checkCode(user *models.User, code string, allowRecovery bool) error {
+	userID := user.ID.String()
+	attemptsKey := twoFactorAttemptsKey(userID)
+	if err := utils.CheckAttempts(attemptsKey, twoFactorMaxAttempts); err != nil {
+		return response.NewTooManyRequests("Too many two-factor attempts, please try again later")
+	}
+
+	valid, err := s.matchCode(user, code, allowRecovery)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		utils.IncrementAttempts(attemptsKey)
+		return response.NewBadRequest("Invalid two-factor code")
+	}
+
+	utils.DeleteKeys(attemptsKey)
+	return nil
+}
+
+func (s *twoFactorService) matchCode(user *models.User, code string, allowRecovery bool) (bool, error) {
+	userID := user.ID.String()
+
+	switch user.TwoFactorMethod {
+	case models.TwoFactorMethodTOTP:
+		if step, ok := utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now()); ok {
+			// a code can't be replayed within its validity window
+			var lastStep int
+			if err := utils.GetKey(twoFactorTOTPStepKey(userID), &lastStep); err == nil && int64(lastStep) >= step {
+				return false, nil
+			}
+			utils.AddKeys(twoFactorTOTPStepKey(userID), step, 2*time.Minute)
+			return true, nil
+		}
+
+	case models.TwoFactorMethodEmail:
+		var codeHash string
+		if err := utils.GetKey(twoFactorEmailCodeKey(userID), &codeHash); err == nil && codeHash == utils.HashToken(code) {
+			utils.DeleteKeys(twoFactorEmailCodeKey(userID))
+			return true, nil
+		}
+	}
+
+	if !allowRecovery || !user.HasTwoFactor() {
+		return false, nil
+	}
+
+	used, err := s.recoveryCode.Consume(userID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return false, response.NewInternalServerError("Failed to check recovery code", err)
+	}
+	if used {
+		utils.GetLogger().Info("two-factor recovery code used", zap.String("userId", userID))
+	}
+	return used, nil
+}
+
+func (s *twoFactorService) sendEmailCode(user *models.User) error {
+	sentKey := "asset_app:2fa_email_sent:" + user.ID.String()
+	if err := utils.CheckAttempts(sentKey, twoFactorMaxEmails); err != nil {
+		return response.NewTooManyRequests("Too many codes requested, please try again later")
+	}
+	utils.IncrementAttempts(sentKey)
+
+	code := utils.GenerateOTP(6)
+
+	if err := utils.AddKeys(twoFactorEmailCodeKey(user.ID.String()), utils.HashToken(code), twoFactorEmailCodeTTL); err != nil {
+		return response.NewInternalServerError("Failed to store two-factor code", err)
+	}
+
+	if err := utils.SendOTPEmail(user.Email, user.Fullname, code, twoFactorEmailCodeTTL); err != nil {
+		utils.DeleteKeys(twoFactorEmailCodeKey(user.ID.String()))
+		return response.NewInternalServerError("Failed to send two-factor code", err)
+	}
+
+	return nil
+}
+
+func (s *twoFactorService) replaceRecoveryCodes(user *models.User) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate recovery codes", err)
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashToken(utils.NormalizeRecoveryCode(code))
+	}
+
+	if err := s.recoveryCode.Replace(user.ID, hashes); err != nil {
+		return nil, response.NewInternalServerError("Failed to store recovery codes", err)
+	}
+
+	return codes, nil
+}
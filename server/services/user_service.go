@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fiqrioemry/asset_management_system_app/server/config"
@@ -13,6 +14,7 @@ import (
 	"github.com/fiqrioemry/go-api-toolkit/response"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/idtoken"
 )
@@ -20,37 +22,134 @@ import (
 type UserService interface {
 
 	// authentication features
-	Login(req *dto.LoginRequest) (*dto.AuthResponse, error)
-	Register(req *dto.RegisterRequest) (*dto.AuthResponse, error)
+	Login(actor utils.AuditActor, req *dto.LoginRequest) (*dto.AuthResponse, error)
+	Register(actor utils.AuditActor, req *dto.RegisterRequest) (*dto.AuthResponse, error)
 	RefreshSession(c *gin.Context, token string) (*dto.UserSession, error)
+	Logout(token string) error
+
+	// two-factor sign in features
+	VerifyTwoFactor(actor utils.AuditActor, req *dto.VerifyTwoFactorRequest) (*dto.AuthResponse, error)
+	ResendTwoFactor(req *dto.ResendTwoFactorRequest) (*dto.TwoFactorChallengeResponse, error)
+
+	// session features
+	GetSessions(userID, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(userID, sessionID string) error
+	RevokeAllSessions(userID string) error
+	CleanupExpiredSessions(now time.Time) error
 
 	// user features
 	GetMe(id string) (*dto.UserProfileResponse, error)
-	UpdateMe(id string, req *dto.UpdateUserRequest) (*dto.UserProfileResponse, error)
+	UpdateMe(actor utils.AuditActor, req *dto.UpdateUserRequest) (*dto.UserProfileResponse, error)
 
 	// change password features
-	ChangePassword(userID string, req *dto.ChangePasswordRequest) error
+	ChangePassword(actor utils.AuditActor, req *dto.ChangePasswordRequest) error
+
+	// email verification features
+	VerifyEmail(actor utils.AuditActor, req *dto.VerifyEmailRequest) error
+	ResendVerification(c *gin.Context, req *dto.ResendVerificationRequest) error
 
 	// password reset features
 	ForgotPassword(c *gin.Context, req *dto.ForgotPasswordRequest) error
 	ValidateToken(token string) (string, error)
-	ResetPassword(req *dto.ResetPasswordRequest) error
+	ResetPassword(actor utils.AuditActor, req *dto.ResetPasswordRequest) error
 
 	// Google OAuth features
-	GetGoogleOAuthURL() string
-	GoogleSignIn(tokenId string) (*dto.AuthResponse, error)
-	HandleGoogleOAuthCallback(code string) (*dto.AuthResponse, error)
+	GetGoogleOAuthURL(userID string) (string, string, error)
+	GoogleSignIn(actor utils.AuditActor, idToken string) (*dto.AuthResponse, error)
+	HandleGoogleOAuthCallback(actor utils.AuditActor, state, code string) (*dto.GoogleOAuthCallbackResponse, error)
+	LinkGoogle(actor utils.AuditActor, idToken string) (*dto.UserProfileResponse, error)
+	UnlinkGoogle(actor utils.AuditActor) (*dto.UserProfileResponse, error)
 }
 
 type userService struct {
-	user repositories.UserRepository
+	user         repositories.UserRepository
+	organization repositories.OrganizationRepository
+	session      repositories.SessionRepository
+	twoFactor    TwoFactorService
+	audit        AuditService
+}
+
+func NewUserService(user repositories.UserRepository, organization repositories.OrganizationRepository, session repositories.SessionRepository, twoFactor TwoFactorService, audit AuditService) UserService {
+	return &userService{user: user, organization: organization, session: session, twoFactor: twoFactor, audit: audit}
 }
 
-func NewUserService(user repositories.UserRepository) UserService {
-	return &userService{user: user}
+// activeMembership returns the user's membership of the organization they are working in,
+// falling back to (and creating if needed) the user's personal organization
+func (s *userService) activeMembership(user *models.User) (*models.OrganizationMember, error) {
+	return resolveActiveMembership(s.organization, user)
+}
+
+func resolveActiveMembership(organization repositories.OrganizationRepository, user *models.User) (*models.OrganizationMember, error) {
+	if user.ActiveOrganizationID != nil {
+		member, err := organization.GetMembership(user.ActiveOrganizationID.String(), user.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			return member, nil
+		}
+	}
+
+	personal, err := organization.GetPersonalByOwnerID(user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if personal == nil {
+		if personal, err = organization.CreatePersonal(user); err != nil {
+			return nil, err
+		}
+	} else if err := organization.SetActiveOrganization(user.ID.String(), personal.ID.String()); err != nil {
+		return nil, err
+	}
+	user.ActiveOrganizationID = &personal.ID
+
+	member, err := organization.GetMembership(personal.ID.String(), user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, fmt.Errorf("user %s is not a member of their personal organization", user.ID)
+	}
+	return member, nil
+}
+
+// issueAccessToken signs an access token for the user acting in the membership's organization
+func issueAccessToken(user *models.User, membership *models.OrganizationMember, sessionID string) (string, error) {
+	return utils.GenerateAccessToken(utils.AccessTokenSubject{
+		UserID:         user.ID.String(),
+		OrganizationID: membership.OrganizationID.String(),
+		Role:           membership.Role,
+		IsAdmin:        user.IsAdmin,
+		SessionID:      sessionID,
+		EmailVerified:  user.IsEmailVerified(),
+	})
+}
+
+// startSession opens a new refresh token family for a sign in
+func (s *userService) startSession(user *models.User, actor utils.AuditActor) (*models.Session, string, error) {
+	now := time.Now()
+	session := &models.Session{
+		UserID:     user.ID,
+		TokenID:    uuid.NewString(),
+		UserAgent:  truncate(actor.UserAgent, 255),
+		IPAddress:  actor.IPAddress,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(utils.RefreshTokenTTL),
+	}
+
+	if err := s.session.Create(session); err != nil {
+		return nil, "", response.NewInternalServerError("Failed to create session", err)
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken(user.ID.String(), session.ID.String(), session.TokenID)
+	if err != nil {
+		return nil, "", response.NewInternalServerError("Failed to generate refresh token", err)
+	}
+
+	return session, refreshToken, nil
 }
 
-func (s *userService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
+func (s *userService) Login(actor utils.AuditActor, req *dto.LoginRequest) (*dto.AuthResponse, error) {
 	// store attempt as cache
 	redisKey := fmt.Sprintf("asset_app:login:attempt:%s", req.Email)
 	if err := utils.CheckAttempts(redisKey, 5); err != nil {
@@ -59,32 +158,78 @@ func (s *userService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
 
 	// check if exist
 	user, err := s.user.GetByEmail(req.Email)
-	if err != nil || user == nil || !utils.CheckPasswordHash(req.Password, user.Password) {
+	if err != nil || user == nil || !user.HasPassword() || !utils.CheckPasswordHash(req.Password, user.Password) {
 		// increment attempts cache
 		utils.IncrementAttempts(redisKey)
 		return nil, response.NewUnauthorized("Invalid email or password")
 	}
 
+	// deactivated accounts cannot sign in
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Your account has been deactivated")
+	}
+
 	// delete attempts cache
 	go utils.DeleteKeys(redisKey)
 
-	// generate accessToken
-	accessToken, err := utils.GenerateAccessToken(user.ID.String())
+	return s.authenticate(user, actor)
+}
+
+// authenticate signs the user in, or returns a challenge when a second factor is required
+func (s *userService) authenticate(user *models.User, actor utils.AuditActor) (*dto.AuthResponse, error) {
+	if user.HasTwoFactor() {
+		challenge, err := s.twoFactor.StartChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.AuthResponse{Challenge: challenge}, nil
+	}
+
+	return s.signIn(user, actor)
+}
+
+func (s *userService) VerifyTwoFactor(actor utils.AuditActor, req *dto.VerifyTwoFactorRequest) (*dto.AuthResponse, error) {
+	user, err := s.twoFactor.VerifyChallenge(req.ChallengeToken, req.Code)
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to generate access token", err)
+		return nil, err
 	}
 
-	// generate refreshToken
-	refreshToken, err := utils.GenerateRefreshToken(user.ID.String())
+	actor.UserID = user.ID.String()
+	return s.signIn(user, actor)
+}
+
+func (s *userService) ResendTwoFactor(req *dto.ResendTwoFactorRequest) (*dto.TwoFactorChallengeResponse, error) {
+	return s.twoFactor.ResendChallenge(req.ChallengeToken)
+}
+
+// signIn starts a new session for an authenticated user in their active organization
+func (s *userService) signIn(user *models.User, actor utils.AuditActor) (*dto.AuthResponse, error) {
+	// resolve active organization
+	membership, err := s.activeMembership(user)
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to generate refresh token", err)
+		return nil, response.NewInternalServerError("Failed to resolve active organization", err)
+	}
+
+	// start session and generate refreshToken
+	session, refreshToken, err := s.startSession(user, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate accessToken
+	accessToken, err := issueAccessToken(user, membership, session.ID.String())
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate access token", err)
 	}
 
 	userResponse := dto.UserSession{
-		ID:       user.ID.String(),
-		Email:    user.Email,
-		Fullname: user.Fullname,
-		Avatar:   user.Avatar,
+		ID:                   user.ID.String(),
+		Email:                user.Email,
+		Fullname:             user.Fullname,
+		Avatar:               user.Avatar,
+		ActiveOrganizationID: membership.OrganizationID.String(),
+		Role:                 membership.Role,
+		EmailVerified:        user.IsEmailVerified(),
 	}
 
 	return &dto.AuthResponse{
@@ -92,10 +237,9 @@ func (s *userService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
-
 }
 
-func (s *userService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+func (s *userService) Register(actor utils.AuditActor, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
 	// check user exist
 	user, err := s.user.GetByEmail(req.Email)
 	if err != nil {
@@ -124,22 +268,41 @@ func (s *userService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		return nil, response.NewConflict("Email is already registered")
 	}
 
+	// the new user registered themselves
+	actor.UserID = newUser.ID.String()
+	s.audit.Record(actor, "", models.AuditActionCreate, models.AuditEntityUser, newUser.ID.String(), nil, &newUser)
+
+	// create personal organization
+	personal, err := s.organization.CreatePersonal(&newUser)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to create personal organization", err)
+	}
+
+	// the user is signed in right away, the link can be resent if this fails
+	if err := s.sendVerificationEmail(&newUser); err != nil {
+		utils.GetLogger().Error("failed to send verification email", zap.String("userId", newUser.ID.String()), zap.Error(err))
+	}
+
 	userResponse := dto.UserSession{
-		ID:       newUser.ID.String(),
-		Email:    newUser.Email,
-		Fullname: newUser.Fullname,
-		Avatar:   newUser.Avatar,
+		ID:                   newUser.ID.String(),
+		Email:                newUser.Email,
+		Fullname:             newUser.Fullname,
+		Avatar:               newUser.Avatar,
+		ActiveOrganizationID: personal.ID.String(),
+		Role:                 models.OrganizationRoleOwner,
+		EmailVerified:        newUser.IsEmailVerified(),
 	}
 
-	// generate accessToken
-	accessToken, err := utils.GenerateAccessToken(newUser.ID.String())
+	// start session and generate refreshToken
+	session, refreshToken, err := s.startSession(&newUser, actor)
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to generate access token", err)
+		return nil, err
 	}
-	// generate refreshToken
-	refreshToken, err := utils.GenerateRefreshToken(newUser.ID.String())
+
+	// generate accessToken
+	accessToken, err := issueAccessToken(&newUser, &models.OrganizationMember{OrganizationID: personal.ID, Role: models.OrganizationRoleOwner}, session.ID.String())
 	if err != nil {
-		return nil, response.NewInternalServerError("Failed to generate refresh token", err)
+		return nil, response.NewInternalServerError("Failed to generate access token", err)
 	}
 
 	return &dto.AuthResponse{
@@ -150,38 +313,153 @@ func (s *userService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 
 }
 
+// RefreshSession rotates the refresh token, a token that was already rotated means it leaked
+// so the whole session is revoked
 func (s *userService) RefreshSession(c *gin.Context, token string) (*dto.UserSession, error) {
 	// decode refreshToken
-	userID, err := utils.DecodeRefreshToken(token)
+	claims, err := utils.DecodeRefreshToken(token)
 	if err != nil {
 		return nil, response.NewUnauthorized("Invalid refresh token")
 	}
 
+	session, err := s.session.GetByID(claims.SessionID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get session", err)
+	}
+	if session == nil || session.UserID.String() != claims.Subject || !session.IsActive(time.Now()) {
+		return nil, response.NewUnauthorized("Session has expired, please sign in again")
+	}
+
 	// check user exists
-	user, err := s.user.GetByID(userID)
+	user, err := s.user.GetByID(claims.Subject)
 	if err != nil || user == nil {
-		return nil, response.NewNotFound("User not found").WithContext("userID", userID)
+		return nil, response.NewNotFound("User not found").WithContext("userID", claims.Subject)
 	}
 
+	// deactivated accounts cannot refresh their session
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Your account has been deactivated")
+	}
+
+	// rotate refreshToken
+	actor := utils.GetAuditActor(c)
+	now := time.Now()
+	session.TokenID = uuid.NewString()
+	session.UserAgent = truncate(actor.UserAgent, 255)
+	session.IPAddress = actor.IPAddress
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(utils.RefreshTokenTTL)
+
+	rotated, err := s.session.Rotate(session, claims.ID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to rotate session", err)
+	}
+	if !rotated {
+		if err := s.session.Revoke(claims.SessionID); err != nil {
+			return nil, response.NewInternalServerError("Failed to revoke session", err)
+		}
+		utils.GetLogger().Warn("refresh token reuse detected, session revoked",
+			zap.String("userId", claims.Subject), zap.String("sessionId", claims.SessionID), zap.String("ip", actor.IPAddress))
+		return nil, response.NewUnauthorized("Session has expired, please sign in again")
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken(user.ID.String(), session.ID.String(), session.TokenID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to generate refresh token", err)
+	}
+
+	// resolve active organization
+	membership, err := s.activeMembership(user)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to resolve active organization", err)
+	}
+	organizationID := membership.OrganizationID.String()
+
 	userResponse := dto.UserSession{
-		ID:       user.ID.String(),
-		Email:    user.Email,
-		Fullname: user.Fullname,
-		Avatar:   user.Avatar,
+		ID:                   user.ID.String(),
+		Email:                user.Email,
+		Fullname:             user.Fullname,
+		Avatar:               user.Avatar,
+		ActiveOrganizationID: organizationID,
+		Role:                 membership.Role,
+		EmailVerified:        user.IsEmailVerified(),
 	}
 
 	// generate accessToken
-	accessToken, err := utils.GenerateAccessToken(user.ID.String())
+	accessToken, err := issueAccessToken(user, membership, session.ID.String())
 	if err != nil {
 		return nil, response.NewInternalServerError("Failed to generate access token", err)
 	}
 
-	// set accessToken
+	// set tokens
 	utils.SetAccessTokenCookie(c, accessToken)
+	utils.SetRefreshTokenCookie(c, refreshToken)
 
 	return &userResponse, nil
 }
 
+// Logout revokes the session of the refresh token, an invalid token has nothing left to revoke
+func (s *userService) Logout(token string) error {
+	claims, err := utils.DecodeRefreshToken(token)
+	if err != nil {
+		return nil
+	}
+
+	if err := s.session.Revoke(claims.SessionID); err != nil {
+		return response.NewInternalServerError("Failed to revoke session", err)
+	}
+	return nil
+}
+
+func (s *userService) GetSessions(userID, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := s.session.GetActiveByUserID(userID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get sessions", err)
+	}
+
+	results := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		results = append(results, dto.SessionResponse{
+			ID:         session.ID.String(),
+			Device:     utils.DescribeDevice(session.UserAgent),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+			CreatedAt:  session.CreatedAt,
+			Current:    session.ID.String() == currentSessionID,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *userService) RevokeSession(userID, sessionID string) error {
+	session, err := s.session.GetByID(sessionID)
+	if err != nil {
+		return response.NewInternalServerError("Failed to get session", err)
+	}
+	if session == nil || session.UserID.String() != userID || !session.IsActive(time.Now()) {
+		return response.NewNotFound("Session not found").WithContext("sessionID", sessionID)
+	}
+
+	if err := s.session.Revoke(sessionID); err != nil {
+		return response.NewInternalServerError("Failed to revoke session", err)
+	}
+	return nil
+}
+
+// RevokeAllSessions logs the user out everywhere, access tokens already issued expire on their own
+func (s *userService) RevokeAllSessions(userID string) error {
+	if err := s.session.RevokeAllByUserID(userID); err != nil {
+		return response.NewInternalServerError("Failed to revoke sessions", err)
+	}
+	return nil
+}
+
+func (s *userService) CleanupExpiredSessions(now time.Time) error {
+	return s.session.DeleteExpired(now)
+}
+
 func (s *userService) GetMe(id string) (*dto.UserProfileResponse, error) {
 
 	// check user exists
@@ -190,21 +468,16 @@ func (s *userService) GetMe(id string) (*dto.UserProfileResponse, error) {
 		return nil, response.NewNotFound("User not found")
 	}
 
-	return &dto.UserProfileResponse{
-		ID:       user.ID.String(),
-		Email:    user.Email,
-		Fullname: user.Fullname,
-		Avatar:   user.Avatar,
-		JoinedAt: user.CreatedAt,
-	}, nil
+	return convertUserProfileToResponse(user), nil
 }
 
-func (s *userService) UpdateMe(id string, req *dto.UpdateUserRequest) (*dto.UserProfileResponse, error) {
+func (s *userService) UpdateMe(actor utils.AuditActor, req *dto.UpdateUserRequest) (*dto.UserProfileResponse, error) {
 	// check user exists
-	user, err := s.user.GetByID(id)
+	user, err := s.user.GetByID(actor.UserID)
 	if err != nil || user == nil {
 		return nil, response.NewNotFound("User not found")
 	}
+	before := *user
 
 	if req.Fullname != "" {
 		user.Fullname = req.Fullname
@@ -217,28 +490,28 @@ func (s *userService) UpdateMe(id string, req *dto.UpdateUserRequest) (*dto.User
 	if err := s.user.Update(user); err != nil {
 		return nil, response.NewInternalServerError("Failed to update user", err)
 	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
 
-	return &dto.UserProfileResponse{
-		ID:       user.ID.String(),
-		Email:    user.Email,
-		Fullname: user.Fullname,
-		Avatar:   user.Avatar,
-		JoinedAt: user.CreatedAt,
-	}, nil
+	return convertUserProfileToResponse(user), nil
 }
 
-func (s *userService) ChangePassword(userID string, req *dto.ChangePasswordRequest) error {
+func (s *userService) ChangePassword(actor utils.AuditActor, req *dto.ChangePasswordRequest) error {
 	// Validate confirm password
 	if req.NewPassword != req.ConfirmPassword {
 		return response.NewBadRequest("New password and confirm password don't match")
 	}
 
 	// check if user exists
-	user, err := s.user.GetByID(userID)
-	if err != nil {
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
 		return response.NewNotFound("User not found")
 	}
 
+	// Google only accounts set their first password through the reset flow
+	if !user.HasPassword() {
+		return response.NewBadRequest("Your account has no password yet, set one through the forgot password flow")
+	}
+
 	// Verify current password
 	if !utils.CheckPasswordHash(req.CurrentPassword, user.Password) {
 		return response.NewBadRequest("Current password is incorrect")
@@ -251,12 +524,15 @@ func (s *userService) ChangePassword(userID string, req *dto.ChangePasswordReque
 	}
 
 	// Update password
+	before := *user
 	user.Password = hashedPassword
 	if err := s.user.Update(user); err != nil {
 		return response.NewInternalServerError("Failed to update password", err)
 	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
 
-	return nil
+	// sign out everywhere, the old password may have leaked
+	return s.RevokeAllSessions(user.ID.String())
 }
 
 func (s *userService) ForgotPassword(c *gin.Context, req *dto.ForgotPasswordRequest) error {
@@ -331,7 +607,7 @@ func (s *userService) ForgotPassword(c *gin.Context, req *dto.ForgotPasswordRequ
 	return nil
 }
 
-func (s *userService) ResetPassword(req *dto.ResetPasswordRequest) error {
+func (s *userService) ResetPassword(actor utils.AuditActor, req *dto.ResetPasswordRequest) error {
 
 	// check password match
 	if req.NewPassword != req.ConfirmPassword {
@@ -358,7 +634,7 @@ func (s *userService) ResetPassword(req *dto.ResetPasswordRequest) error {
 
 	// check user exists
 	user, err := s.user.GetByID(userID)
-	if err != nil {
+	if err != nil || user == nil {
 		return response.NewNotFound("User not found")
 	}
 
@@ -369,12 +645,22 @@ func (s *userService) ResetPassword(req *dto.ResetPasswordRequest) error {
 	}
 
 	// update user password
+	before := *user
 	user.Password = hashedPassword
 
 	if err := s.user.Update(user); err != nil {
 		return response.NewInternalServerError("Failed to update password", err)
 	}
 
+	// the reset link proves the requester is the user
+	actor.UserID = user.ID.String()
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+	// sign out everywhere, the old password may have leaked
+	if err := s.RevokeAllSessions(user.ID.String()); err != nil {
+		return err
+	}
+
 	// delete related cache keys
 	go utils.DeleteKeys(resetTokenKey)
 	go utils.DeleteKeys("asset_app:reset_token:" + email)
@@ -411,58 +697,129 @@ func (s *userService) ValidateToken(token string) (string, error) {
 
 }
 
-func (s *userService) GoogleSignIn(tokenId string) (*dto.AuthResponse, error) {
-	payload, err := idtoken.Validate(context.Background(), tokenId, config.AppConfig.GoogleClientID)
+// googleIdentity is the part of a verified Google ID token we rely on
+type googleIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// googleOAuthState is kept in redis between the redirect and the callback,
+// UserID is only set when a signed in user is linking their account
+type googleOAuthState struct {
+	UserID string `json:"userId,omitempty"`
+}
+
+const googleOAuthStateTTL = 10 * time.Minute
+
+func googleOAuthStateKey(state string) string {
+	return "asset_app:google_oauth_state:" + state
+}
+
+func verifyGoogleIDToken(idToken string) (*googleIdentity, error) {
+	payload, err := idtoken.Validate(context.Background(), idToken, config.AppConfig.GoogleClientID)
 	if err != nil {
 		return nil, response.NewUnauthorized("Invalid Google ID token")
 	}
 
-	email, ok := payload.Claims["email"].(string)
-	if !ok || email == "" {
-		return nil, response.NewNotFound("Email not found in token")
+	identity := &googleIdentity{Subject: payload.Subject}
+	identity.Email, _ = payload.Claims["email"].(string)
+	identity.EmailVerified, _ = payload.Claims["email_verified"].(bool)
+	identity.Name, _ = payload.Claims["name"].(string)
+	identity.Picture, _ = payload.Claims["picture"].(string)
+
+	if identity.Subject == "" || identity.Email == "" {
+		return nil, response.NewUnauthorized("Google ID token is missing the account identity")
 	}
 
-	name, _ := payload.Claims["name"].(string)
+	return identity, nil
+}
+
+func (s *userService) GoogleSignIn(actor utils.AuditActor, idToken string) (*dto.AuthResponse, error) {
+	identity, err := verifyGoogleIDToken(idToken)
+	if err != nil {
+		return nil, err
+	}
 
-	user, err := s.user.GetByEmail(email)
+	user, err := s.user.GetByGoogleID(identity.Subject)
 	if err != nil {
+		return nil, response.NewInternalServerError("Failed to find user", err)
+	}
+
+	if user == nil {
+		// an existing account is only attached to Google when its owner links it explicitly
+		existing, err := s.user.GetByEmail(identity.Email)
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to check user existence", err)
+		}
+		if existing != nil {
+			return nil, response.NewConflict("An account with this email already exists, sign in with your password and link Google from your profile")
+		}
+
+		if !identity.EmailVerified {
+			return nil, response.NewUnauthorized("Google account email is not verified")
+		}
+
+		avatar := identity.Picture
+		if avatar == "" {
+			avatar = utils.RandomUserAvatar(identity.Name)
+		}
+
+		// Google only accounts have no password until one is set through the reset flow,
+		// their email address was already verified by Google
+		now := time.Now()
 		user = &models.User{
-			Email:    email,
-			Avatar:   utils.RandomUserAvatar(name),
-			Fullname: name,
-			Password: "-",
+			Email:           identity.Email,
+			Fullname:        identity.Name,
+			Avatar:          avatar,
+			GoogleID:        &identity.Subject,
+			EmailVerifiedAt: &now,
 		}
 
 		if err := s.user.Create(user); err != nil {
-			return nil, err
+			return nil, response.NewConflict("Email is already registered")
 		}
 
-		if user.ID == uuid.Nil {
-			return nil, response.NewInternalServerError("Failed to assign UUID to user", err)
-		}
+		actor.UserID = user.ID.String()
+		s.audit.Record(actor, "", models.AuditActionCreate, models.AuditEntityUser, user.ID.String(), nil, user)
+
+		go sendWelcomeEmail(user.Email, user.Fullname)
 	}
 
-	accessToken, err := utils.GenerateAccessToken(user.ID.String())
-	if err != nil {
-		return nil, err
+	if user.IsDeactivated() {
+		return nil, response.NewForbidden("Your account has been deactivated")
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.ID.String())
+	actor.UserID = user.ID.String()
+	return s.authenticate(user, actor)
+}
+
+func (s *userService) GetGoogleOAuthURL(userID string) (string, string, error) {
+	state, err := utils.GenerateResetToken()
 	if err != nil {
-		return nil, err
+		return "", "", response.NewInternalServerError("Failed to generate OAuth state", err)
 	}
 
-	return &dto.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
-}
+	if err := utils.AddKeys(googleOAuthStateKey(state), googleOAuthState{UserID: userID}, googleOAuthStateTTL); err != nil {
+		return "", "", response.NewInternalServerError("Failed to store OAuth state", err)
+	}
+
+	url := config.GoogleOAuthConfig.AuthCodeURL(state, oauth2.AccessTypeOnline)
 
-func (s *userService) GetGoogleOAuthURL() string {
-	return config.GoogleOAuthConfig.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	return url, state, nil
 }
 
-func (s *userService) HandleGoogleOAuthCallback(code string) (*dto.AuthResponse, error) {
+func (s *userService) HandleGoogleOAuthCallback(actor utils.AuditActor, state, code string) (*dto.GoogleOAuthCallbackResponse, error) {
+	// states are single use
+	var stored googleOAuthState
+	stateKey := googleOAuthStateKey(state)
+	if err := utils.GetKey(stateKey, &stored); err != nil {
+		return nil, response.NewUnauthorized("Invalid or expired OAuth state")
+	}
+	utils.DeleteKeys(stateKey)
+
 	token, err := config.GoogleOAuthConfig.Exchange(context.Background(), code)
 	if err != nil {
 		return nil, response.NewUnauthorized("Failed to exchange Google OAuth code")
@@ -473,5 +830,211 @@ func (s *userService) HandleGoogleOAuthCallback(code string) (*dto.AuthResponse,
 		return nil, response.NewUnauthorized("ID token not found in Google OAuth response")
 	}
 
-	return s.GoogleSignIn(rawIDToken)
+	if stored.UserID != "" {
+		actor.UserID = stored.UserID
+		if _, err := s.LinkGoogle(actor, rawIDToken); err != nil {
+			return nil, err
+		}
+		return &dto.GoogleOAuthCallbackResponse{Linked: true}, nil
+	}
+
+	auth, err := s.GoogleSignIn(actor, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GoogleOAuthCallbackResponse{Auth: auth}, nil
+}
+
+func (s *userService) LinkGoogle(actor utils.AuditActor, idToken string) (*dto.UserProfileResponse, error) {
+	identity, err := verifyGoogleIDToken(idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	if user.HasGoogle() {
+		if *user.GoogleID == identity.Subject {
+			return convertUserProfileToResponse(user), nil
+		}
+		return nil, response.NewConflict("Another Google account is already linked, unlink it first")
+	}
+
+	linked, err := s.user.GetByGoogleID(identity.Subject)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to check Google account", err)
+	}
+	if linked != nil {
+		return nil, response.NewConflict("This Google account is already linked to another user")
+	}
+
+	before := *user
+	user.GoogleID = &identity.Subject
+
+	// Google vouches for the address when it's the same one
+	if !user.IsEmailVerified() && identity.EmailVerified && strings.EqualFold(identity.Email, user.Email) {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.user.Update(user); err != nil {
+		return nil, response.NewInternalServerError("Failed to link Google account", err)
+	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+	return convertUserProfileToResponse(user), nil
+}
+
+func (s *userService) UnlinkGoogle(actor utils.AuditActor) (*dto.UserProfileResponse, error) {
+	user, err := s.user.GetByID(actor.UserID)
+	if err != nil || user == nil {
+		return nil, response.NewNotFound("User not found")
+	}
+
+	if !user.HasGoogle() {
+		return nil, response.NewBadRequest("No Google account is linked")
+	}
+
+	// without a password the account would be locked out
+	if !user.HasPassword() {
+		return nil, response.NewBadRequest("Set a password through the forgot password flow before unlinking Google")
+	}
+
+	before := *user
+	user.GoogleID = nil
+	if err := s.user.Update(user); err != nil {
+		return nil, response.NewInternalServerError("Failed to unlink Google account", err)
+	}
+	s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+	return convertUserProfileToResponse(user), nil
+}
+
+func convertUserProfileToResponse(user *models.User) *dto.UserProfileResponse {
+	return &dto.UserProfileResponse{
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		Fullname:      user.Fullname,
+		Avatar:        user.Avatar,
+		HasPassword:   user.HasPassword(),
+		GoogleLinked:  user.HasGoogle(),
+		EmailVerified: user.IsEmailVerified(),
+		TwoFactor:     user.HasTwoFactor(),
+		JoinedAt:      user.CreatedAt,
+	}
+}
+
+const emailVerificationTTL = 24 * time.Hour
+
+type emailVerificationToken struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+}
+
+func emailVerificationKey(token string) string {
+	return "asset_app:email_verification:" + token
+}
+
+// emailVerificationUserKey points to the user's latest token so resending invalidates the previous link
+func emailVerificationUserKey(userID string) string {
+	return "asset_app:email_verification_token:" + userID
+}
+
+func (s *userService) sendVerificationEmail(user *models.User) error {
+	token, err := utils.GenerateResetToken()
+	if err != nil {
+		return err
+	}
+
+	userKey := emailVerificationUserKey(user.ID.String())
+	var previous string
+	if err := utils.GetKey(userKey, &previous); err == nil {
+		utils.DeleteKeys(emailVerificationKey(previous))
+	}
+
+	tokenKey := emailVerificationKey(token)
+	if err := utils.AddKeys(tokenKey, emailVerificationToken{UserID: user.ID.String(), Email: user.Email}, emailVerificationTTL); err != nil {
+		return err
+	}
+	if err := utils.AddKeys(userKey, token, emailVerificationTTL); err != nil {
+		utils.DeleteKeys(tokenKey)
+		return err
+	}
+
+	verifyLink := fmt.Sprintf("%s/verify-email?token=%s", config.AppConfig.FrontendURL, token)
+
+	if err := utils.SendVerificationEmail(user.Email, user.Fullname, verifyLink, emailVerificationTTL); err != nil {
+		utils.DeleteKeys(tokenKey, userKey)
+		return err
+	}
+
+	return nil
+}
+
+func (s *userService) VerifyEmail(actor utils.AuditActor, req *dto.VerifyEmailRequest) error {
+	tokenKey := emailVerificationKey(req.Token)
+
+	var tokenData emailVerificationToken
+	if err := utils.GetKey(tokenKey, &tokenData); err != nil {
+		return response.NewBadRequest("Invalid or expired verification token")
+	}
+
+	user, err := s.user.GetByID(tokenData.UserID)
+	if err != nil || user == nil {
+		return response.NewNotFound("User not found")
+	}
+
+	// the link only verifies the address it was sent to
+	if user.Email != tokenData.Email {
+		utils.DeleteKeys(tokenKey)
+		return response.NewBadRequest("Invalid or expired verification token")
+	}
+
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		before := *user
+		user.EmailVerifiedAt = &now
+		if err := s.user.Update(user); err != nil {
+			return response.NewInternalServerError("Failed to verify email", err)
+		}
+
+		actor.UserID = user.ID.String()
+		s.audit.Record(actor, "", models.AuditActionUpdate, models.AuditEntityUser, user.ID.String(), &before, user)
+
+		go sendWelcomeEmail(user.Email, user.Fullname)
+	}
+
+	utils.DeleteKeys(tokenKey, emailVerificationUserKey(user.ID.String()))
+
+	return nil
+}
+
+func (s *userService) ResendVerification(c *gin.Context, req *dto.ResendVerificationRequest) error {
+	// Check rate limit attempts
+	attemptsKey := "asset_app:resend_verification_attempts:" + c.ClientIP()
+	if err := utils.CheckAttempts(attemptsKey, 3); err != nil {
+		return response.NewTooManyRequests("Too many verification requests, please try again later")
+	}
+	utils.IncrementAttempts(attemptsKey)
+
+	user, err := s.user.GetByEmail(req.Email)
+	if err != nil || user == nil || user.IsEmailVerified() {
+		return nil // Don't reveal if email exists or is verified
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		return response.NewInternalServerError("Failed to send verification email", err)
+	}
+
+	return nil
+}
+
+// sendWelcomeEmail runs in the background, a failure doesn't affect the request
+func sendWelcomeEmail(email, fullname string) {
+	if err := utils.SendWelcomeEmail(email, fullname); err != nil {
+		utils.GetLogger().Error("failed to send welcome email", zap.String("email", email), zap.Error(err))
+	}
 }
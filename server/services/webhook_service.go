@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/dto"
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+	"github.com/fiqrioemry/asset_management_system_app/server/repositories"
+	"github.com/fiqrioemry/asset_management_system_app/server/utils"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookMaxAttempts      = 6
+	webhookBatchSize        = 50
+	webhookClaimLease       = 2 * time.Minute
+	webhookPollInterval     = 5 * time.Second
+	webhookDeliveryRetained = 30 * 24 * time.Hour
+)
+
+type WebhookService interface {
+	GetWebhooks(organizationID string) ([]dto.WebhookResponse, error)
+	GetWebhookByID(organizationID, webhookID string) (*dto.WebhookResponse, error)
+	CreateWebhook(userID, organizationID string, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	UpdateWebhook(organizationID, webhookID string, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(organizationID, webhookID string) error
+	GetDeliveries(organizationID, webhookID string, req *dto.GetWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, int, error)
+	Redeliver(organizationID, webhookID, deliveryID string) (*dto.WebhookDeliveryResponse, error)
+
+	// Emit queues the event for every subscribed webhook of the organization, it never fails the caller
+	Emit(organizationID, event string, data any)
+	// DeliverDue sends one batch of deliveries whose next attempt is due
+	DeliverDue(now time.Time) error
+	StartWorker()
+	CleanupDeliveries(now time.Time) error
+}
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	sender      *utils.WebhookSender
+	wake        chan struct{}
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, sender *utils.WebhookSender) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+func (s *webhookService) GetWebhooks(organizationID string) ([]dto.WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.GetByOrganizationID(organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get webhooks", err)
+	}
+
+	results := make([]dto.WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		results = append(results, convertWebhookToResponse(&webhooks[i]))
+	}
+
+	return results, nil
+}
+
+func (s *webhookService) GetWebhookByID(organizationID, webhookID string) (*dto.WebhookResponse, error) {
+	webhook, err := s.getWebhook(organizationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := convertWebhookToResponse(webhook)
+	return &result, nil
+}
+
+func (s *webhookService) CreateWebhook(userID, organizationID string, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid user ID")
+	}
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, response.NewBadRequest("Invalid organization ID")
+	}
+
+	webhook := models.Webhook{
+		OrganizationID: organizationUUID,
+		CreatedBy:      userUUID,
+		Name:           strings.TrimSpace(req.Name),
+		URL:            req.URL,
+		Secret:         req.Secret,
+		Events:         joinWebhookEvents(req.Events),
+		IsActive:       true,
+	}
+
+	if err := s.webhookRepo.Create(&webhook); err != nil {
+		return nil, response.NewInternalServerError("Failed to create webhook", err)
+	}
+
+	result := convertWebhookToResponse(&webhook)
+	return &result, nil
+}
+
+func (s *webhookService) UpdateWebhook(organizationID, webhookID string, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	webhook, err := s.getWebhook(organizationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	webhook.Name = strings.TrimSpace(req.Name)
+	webhook.URL = req.URL
+	webhook.Events = joinWebhookEvents(req.Events)
+	webhook.IsActive = *req.IsActive
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, response.NewInternalServerError("Failed to update webhook", err)
+	}
+
+	result := convertWebhookToResponse(webhook)
+	return &result, nil
+}
+
+func (s *webhookService) DeleteWebhook(organizationID, webhookID string) error {
+	webhook, err := s.getWebhook(organizationID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(webhook); err != nil {
+		return response.NewInternalServerError("Failed to delete webhook", err)
+	}
+
+	return nil
+}
+
+func (s *webhookService) GetDeliveries(organizationID, webhookID string, req *dto.GetWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, int, error) {
+	if _, err := s.getWebhook(organizationID, webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := s.webhookRepo.GetDeliveriesWithFilter(repositories.WebhookDeliveryFilter{
+		WebhookID: webhookID,
+		Status:    req.Status,
+		Page:      req.Page,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, 0, response.NewInternalServerError("Failed to get webhook deliveries", err)
+	}
+
+	results := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		results = append(results, convertWebhookDeliveryToResponse(&deliveries[i]))
+	}
+
+	return results, total, nil
+}
+
+// Redeliver queues a copy of a logged delivery, it keeps the event ID so receivers can deduplicate
+func (s *webhookService) Redeliver(organizationID, webhookID, deliveryID string) (*dto.WebhookDeliveryResponse, error) {
+	webhook, err := s.getWebhook(organizationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.IsActive {
+		return nil, response.NewBadRequest("Webhook is disabled")
+	}
+
+	original, err := s.webhookRepo.GetDelivery(webhookID, deliveryID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get webhook delivery", err)
+	}
+	if original == nil {
+		return nil, response.NewNotFound("Webhook delivery not found")
+	}
+
+	now := time.Now()
+	deliveries := []models.WebhookDelivery{{
+		WebhookID:     webhook.ID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+	}}
+
+	if err := s.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		return nil, response.NewInternalServerError("Failed to queue webhook delivery", err)
+	}
+	s.notifyWorker()
+
+	result := convertWebhookDeliveryToResponse(&deliveries[0])
+	return &result, nil
+}
+
+func (s *webhookService) Emit(organizationID, event string, data any) {
+	if organizationID == "" {
+		return
+	}
+
+	webhooks, err := s.webhookRepo.GetActiveByOrganizationID(organizationID)
+	if err != nil {
+		utils.GetLogger().Error("failed to get webhooks", zap.String("organizationId", organizationID), zap.String("event", event), zap.Error(err))
+		return
+	}
+
+	var subscribed []models.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	now := time.Now()
+	eventID := uuid.New()
+	payload, err := json.Marshal(dto.WebhookEventPayload{
+		ID:             eventID.String(),
+		Event:          event,
+		CreatedAt:      now,
+		OrganizationID: organizationID,
+		Data:           data,
+	})
+	if err != nil {
+		utils.GetLogger().Error("failed to encode webhook payload", zap.String("event", event), zap.Error(err))
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		utils.GetLogger().Error("failed to queue webhook deliveries", zap.String("organizationId", organizationID), zap.String("event", event), zap.Error(err))
+		return
+	}
+	s.notifyWorker()
+}
+
+func (s *webhookService) DeliverDue(now time.Time) error {
+	deliveries, err := s.webhookRepo.GetDueDeliveries(now, webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		// skip deliveries another worker picked up in the meantime
+		claimed, err := s.webhookRepo.ClaimDelivery(delivery, now.Add(webhookClaimLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		s.deliver(delivery)
+		if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+			utils.GetLogger().Error("failed to update webhook delivery", zap.String("deliveryId", delivery.ID.String()), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// StartWorker polls for due deliveries and wakes up early whenever an event is emitted
+func (s *webhookService) StartWorker() {
+	go func() {
+		ticker := time.NewTicker(webhookPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+
+			if err := s.DeliverDue(time.Now()); err != nil {
+				utils.GetLogger().Error("failed to deliver webhooks", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// CleanupDeliveries drops finished deliveries once they are older than the retention window
+func (s *webhookService) CleanupDeliveries(now time.Time) error {
+	return s.webhookRepo.DeleteDeliveriesBefore(now.Add(-webhookDeliveryRetained))
+}
+
+// deliver makes one attempt and schedules the next one with exponential backoff on failure
+func (s *webhookService) deliver(delivery *models.WebhookDelivery) {
+	webhook := delivery.Webhook
+	if webhook == nil || !webhook.IsActive {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = "webhook is disabled"
+		return
+	}
+
+	delivery.Attempts++
+	result, err := s.sender.Send(context.Background(), utils.WebhookRequest{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		Event:      delivery.Event,
+		EventID:    delivery.EventID.String(),
+		DeliveryID: delivery.ID.String(),
+		Payload:    delivery.Payload,
+	})
+
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.DurationMs = 0
+	if result != nil {
+		delivery.ResponseStatus = result.StatusCode
+		delivery.ResponseBody = result.Body
+		delivery.DurationMs = result.Duration.Milliseconds()
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = truncate(err.Error(), 500)
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(utils.WebhookRetryDelay(delivery.Attempts))
+		delivery.Error = truncate(err.Error(), 500)
+		delivery.NextAttemptAt = &next
+	}
+}
+
+func (s *webhookService) notifyWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *webhookService) getWebhook(organizationID, webhookID string) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByIDAndOrganizationID(webhookID, organizationID)
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get webhook", err)
+	}
+	if webhook == nil {
+		return nil, response.NewNotFound("Webhook not found")
+	}
+	return webhook, nil
+}
+
+func validateWebhookURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return response.NewBadRequest("Webhook URL must be an http or https URL")
+	}
+	return nil
+}
+
+func joinWebhookEvents(events []string) string {
+	var unique []string
+	for _, event := range events {
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+func convertWebhookToResponse(webhook *models.Webhook) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:        webhook.ID.String(),
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Events:    webhook.EventList(),
+		IsActive:  webhook.IsActive,
+		CreatedBy: webhook.CreatedBy.String(),
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func convertWebhookDeliveryToResponse(delivery *models.WebhookDelivery) dto.WebhookDeliveryResponse {
+	result := dto.WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		WebhookID:      delivery.WebhookID.String(),
+		EventID:        delivery.EventID.String(),
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.RedeliveryOf != nil {
+		redeliveryOf := delivery.RedeliveryOf.String()
+		result.RedeliveryOf = &redeliveryOf
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// auditIgnoredFields are never written to the audit log
+var auditIgnoredFields = map[string]bool{
+	"updatedAt": true,
+	"deletedAt": true,
+}
+
+// auditSensitiveFields are recorded as changed without their values
+var auditSensitiveFields = map[string]bool{
+	"password": true,
+}
+
+const auditRedactedValue = "[redacted]"
+
+// AuditDiff returns the fields that differ between two snapshots of an entity.
+// Either side may be nil for creates and deletes, nested relationships are left out.
+func AuditDiff(before, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := auditSnapshot(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := auditSnapshot(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// only keep the changed fields when both sides exist
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if reflect.DeepEqual(value, afterFields[key]) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	redactAuditFields(beforeFields)
+	redactAuditFields(afterFields)
+
+	return marshalAuditFields(beforeFields), marshalAuditFields(afterFields), nil
+}
+
+// auditSnapshot collects the scalar fields of a model by their json names
+func auditSnapshot(entity any) (map[string]any, error) {
+	value := reflect.ValueOf(entity)
+	if entity == nil || value.IsZero() {
+		return nil, nil
+	}
+	value = reflect.Indirect(value)
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("audit snapshot of %T is not supported", entity)
+	}
+
+	raw := make(map[string]any)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" || auditIgnoredFields[name] || isAuditRelationship(field.Type) {
+			continue
+		}
+		raw[name] = value.Field(i).Interface()
+	}
+
+	// round trip so values compare the way they are stored
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// isAuditRelationship reports associations, which are audited as their own entities
+func isAuditRelationship(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	}
+	return false
+}
+
+func redactAuditFields(fields map[string]any) {
+	for key := range fields {
+		if auditSensitiveFields[key] {
+			fields[key] = auditRedactedValue
+		}
+	}
+}
+
+func marshalAuditFields(fields map[string]any) json.RawMessage {
+	if fields == nil {
+		return nil
+	}
+	data, _ := json.Marshal(fields)
+	return data
+}
@@ -15,3 +15,36 @@ func MustGetUserID(c *gin.Context) string {
 	}
 	return idStr
 }
+
+func MustGetOrganizationID(c *gin.Context) string {
+	organizationID, exists := c.Get("organizationID")
+	if !exists {
+		panic("organizationID not found in context")
+	}
+	idStr, ok := organizationID.(string)
+	if !ok {
+		panic("organizationID in context is not a string")
+	}
+	return idStr
+}
+
+// AuditActor describes who made a request, it is recorded with every audit entry
+type AuditActor struct {
+	UserID         string
+	ImpersonatorID string
+	IPAddress      string
+	UserAgent      string
+	RequestID      string
+}
+
+// GetAuditActor reads the request details set by the RequestContext and AuthRequired middlewares,
+// UserID is empty on public routes
+func GetAuditActor(c *gin.Context) AuditActor {
+	return AuditActor{
+		UserID:         c.GetString("userID"),
+		ImpersonatorID: c.GetString("impersonatorID"),
+		IPAddress:      c.GetString("clientIP"),
+		UserAgent:      c.Request.UserAgent(),
+		RequestID:      c.GetString("requestID"),
+	}
+}
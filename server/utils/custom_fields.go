@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+)
+
+const maxCustomFieldTextLength = 255
+
+// ResolveCategoryFields returns the fields that apply to assets of the category, inherited fields
+// of the root come first and each level is ordered by position
+func ResolveCategoryFields(categories []models.Category, fields []models.CustomField, categoryID uuid.UUID) []models.CustomField {
+	chain := NewCategoryTree(categories).Ancestry(categoryID)
+
+	var resolved []models.CustomField
+	for i := len(chain) - 1; i >= 0; i-- {
+		var level []models.CustomField
+		for _, field := range fields {
+			if field.CategoryID == chain[i] {
+				level = append(level, field)
+			}
+		}
+		slices.SortStableFunc(level, func(a, b models.CustomField) int { return a.Position - b.Position })
+		resolved = append(resolved, level...)
+	}
+	return resolved
+}
+
+// ParseCustomFieldValue converts a submitted value to the typed value of the field. Numbers,
+// booleans and dates are also accepted as strings since multipart forms only carry text.
+func ParseCustomFieldValue(field *models.CustomField, raw any) (*models.AssetCustomFieldValue, error) {
+	value := &models.AssetCustomFieldValue{FieldID: field.ID}
+
+	switch field.Type {
+	case models.CustomFieldNumber:
+		var number float64
+		switch v := raw.(type) {
+		case float64:
+			number = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number", field.Key)
+			}
+			number = parsed
+		default:
+			return nil, fmt.Errorf("%s must be a number", field.Key)
+		}
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("%s must be a number", field.Key)
+		}
+		value.NumberValue = &number
+
+	case models.CustomFieldDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", field.Key)
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", field.Key)
+		}
+		value.DateValue = &date
+
+	case models.CustomFieldBoolean:
+		var flag bool
+		switch v := raw.(type) {
+		case bool:
+			flag = v
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false", field.Key)
+			}
+			flag = parsed
+		default:
+			return nil, fmt.Errorf("%s must be true or false", field.Key)
+		}
+		value.BoolValue = &flag
+
+	case models.CustomFieldEnum:
+		text, ok := raw.(string)
+		if !ok || !slices.Contains(field.Options, strings.TrimSpace(text)) {
+			return nil, fmt.Errorf("%s must be one of: %s", field.Key, strings.Join(field.Options, ", "))
+		}
+		text = strings.TrimSpace(text)
+		value.TextValue = &text
+
+	default:
+		var text string
+		switch v := raw.(type) {
+		case string:
+			text = strings.TrimSpace(v)
+		case float64, bool:
+			text = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("%s must be text", field.Key)
+		}
+		if len([]rune(text)) > maxCustomFieldTextLength {
+			return nil, fmt.Errorf("%s must be at most %d characters", field.Key, maxCustomFieldTextLength)
+		}
+		if text == "" {
+			return nil, nil
+		}
+		value.TextValue = &text
+	}
+
+	return value, nil
+}
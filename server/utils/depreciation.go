@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"math"
+	"time"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DepreciationStraightLine      = "straight-line"
+	DepreciationDecliningBalance  = "declining-balance"
+	DepreciationSumOfYearsDigits  = "sum-of-years-digits"
+	defaultDepreciationMethod     = DepreciationStraightLine
+	defaultDepreciationUsefulLife = 5
+	defaultDepreciationSalvage    = 10.0
+)
+
+type DepreciationPolicy struct {
+	Method          string
+	UsefulLifeYears int
+	SalvagePercent  float64
+}
+
+type DepreciationYear struct {
+	Year           int
+	StartDate      time.Time
+	EndDate        time.Time
+	StartBookValue float64
+	Depreciation   float64
+	Accumulated    float64
+	EndBookValue   float64
+}
+
+type DepreciationResult struct {
+	Cost         float64
+	SalvageValue float64
+	Accumulated  float64
+	BookValue    float64
+}
+
+// ResolveDepreciationPolicies walks every category up to its root so that
+// unset settings are inherited from the closest parent that defines them
+func ResolveDepreciationPolicies(categories []models.Category) map[uuid.UUID]DepreciationPolicy {
+	byID := make(map[uuid.UUID]*models.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	policies := make(map[uuid.UUID]DepreciationPolicy, len(categories))
+	for _, category := range categories {
+		var method *string
+		var usefulLife *int
+		var salvage *float64
+
+		current := byID[category.ID]
+		for depth := 0; current != nil && depth <= len(categories); depth++ {
+			if method == nil {
+				method = current.DepreciationMethod
+			}
+			if usefulLife == nil {
+				usefulLife = current.UsefulLifeYears
+			}
+			if salvage == nil {
+				salvage = current.SalvagePercent
+			}
+			if current.ParentID == nil {
+				break
+			}
+			current = byID[*current.ParentID]
+		}
+
+		policy := DepreciationPolicy{
+			Method:          defaultDepreciationMethod,
+			UsefulLifeYears: defaultDepreciationUsefulLife,
+			SalvagePercent:  defaultDepreciationSalvage,
+		}
+		if method != nil {
+			policy.Method = *method
+		}
+		if usefulLife != nil {
+			policy.UsefulLifeYears = *usefulLife
+		}
+		if salvage != nil {
+			policy.SalvagePercent = *salvage
+		}
+
+		policies[category.ID] = policy
+	}
+
+	return policies
+}
+
+// DefaultDepreciationPolicy is used when an asset's category can't be resolved
+func DefaultDepreciationPolicy() DepreciationPolicy {
+	return DepreciationPolicy{
+		Method:          defaultDepreciationMethod,
+		UsefulLifeYears: defaultDepreciationUsefulLife,
+		SalvagePercent:  defaultDepreciationSalvage,
+	}
+}
+
+// CalculateDepreciation returns the accumulated depreciation and book value as of a date.
+// Assets without a purchase date are not depreciated.
+func CalculateDepreciation(cost float64, purchaseDate *time.Time, asOf time.Time, policy DepreciationPolicy) DepreciationResult {
+	salvage := cost * policy.SalvagePercent / 100
+	if purchaseDate == nil {
+		return DepreciationResult{Cost: cost, SalvageValue: roundMoney(salvage), BookValue: roundMoney(cost)}
+	}
+
+	years := float64(monthsBetween(*purchaseDate, asOf)) / 12
+	bookValue := bookValueAt(cost, salvage, years, policy)
+
+	return DepreciationResult{
+		Cost:         cost,
+		SalvageValue: roundMoney(salvage),
+		Accumulated:  roundMoney(cost - bookValue),
+		BookValue:    roundMoney(bookValue),
+	}
+}
+
+// DepreciationSchedule returns one entry per year of the asset's useful life
+func DepreciationSchedule(cost float64, purchaseDate time.Time, policy DepreciationPolicy) []DepreciationYear {
+	salvage := cost * policy.SalvagePercent / 100
+
+	schedule := make([]DepreciationYear, 0, policy.UsefulLifeYears)
+	for year := 1; year <= policy.UsefulLifeYears; year++ {
+		start := bookValueAt(cost, salvage, float64(year-1), policy)
+		end := bookValueAt(cost, salvage, float64(year), policy)
+
+		schedule = append(schedule, DepreciationYear{
+			Year:           year,
+			StartDate:      purchaseDate.AddDate(year-1, 0, 0),
+			EndDate:        purchaseDate.AddDate(year, 0, -1),
+			StartBookValue: roundMoney(start),
+			Depreciation:   roundMoney(start - end),
+			Accumulated:    roundMoney(cost - end),
+			EndBookValue:   roundMoney(end),
+		})
+	}
+
+	return schedule
+}
+
+func bookValueAt(cost, salvage, years float64, policy DepreciationPolicy) float64 {
+	life := float64(policy.UsefulLifeYears)
+	if years <= 0 || cost <= salvage {
+		return cost
+	}
+	if life <= 0 || years >= life {
+		return salvage
+	}
+
+	depreciable := cost - salvage
+
+	switch policy.Method {
+	case DepreciationDecliningBalance:
+		// double declining balance, never below salvage value
+		rate := math.Min(2/life, 1)
+		return math.Max(cost*math.Pow(1-rate, years), salvage)
+
+	case DepreciationSumOfYearsDigits:
+		sum := life * (life + 1) / 2
+		fullYears := math.Floor(years)
+		accumulated := fullYears*life - fullYears*(fullYears-1)/2
+		accumulated += (years - fullYears) * (life - fullYears)
+		return cost - depreciable*accumulated/sum
+
+	default:
+		return cost - depreciable*years/life
+	}
+}
+
+func monthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	return max(months, 0)
+}
+
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
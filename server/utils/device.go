@@ -0,0 +1,42 @@
+package utils
+
+import "strings"
+
+// order matters, several browsers include the tokens of the ones they are based on
+var deviceBrowsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+	{"PostmanRuntime/", "Postman"},
+	{"curl/", "curl"},
+}
+
+var deviceSystems = []struct{ token, name string }{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
+
+// DescribeDevice turns a user agent into a short label such as "Chrome on Windows"
+func DescribeDevice(userAgent string) string {
+	browser := "Unknown browser"
+	for _, b := range deviceBrowsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	for _, system := range deviceSystems {
+		if strings.Contains(userAgent, system.token) {
+			return browser + " on " + system.name
+		}
+	}
+	return browser
+}
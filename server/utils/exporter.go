@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type ExportColumn struct {
+	Key   string
+	Title string
+}
+
+// TableWriter writes rows one by one so large exports don't have to be held in memory
+type TableWriter interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func ExportContentType(format string) string {
+	return exportContentTypes[format]
+}
+
+func NewTableWriter(format string, w io.Writer, columns []ExportColumn) (TableWriter, error) {
+	switch format {
+	case "csv":
+		return newCSVTableWriter(w, columns)
+	case "json":
+		return &jsonTableWriter{w: w, columns: columns}, nil
+	case "xlsx":
+		return newXLSXTableWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// csv
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVTableWriter(w io.Writer, columns []ExportColumn) (*csvTableWriter, error) {
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Title
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvTableWriter{writer: writer}, nil
+}
+
+func (t *csvTableWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatExportValue(value)
+	}
+	if err := t.writer.Write(record); err != nil {
+		return err
+	}
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+// json
+type jsonTableWriter struct {
+	w       io.Writer
+	columns []ExportColumn
+	rows    int
+}
+
+func (t *jsonTableWriter) WriteRow(values []any) error {
+	row := make(map[string]any, len(t.columns))
+	for i, column := range t.columns {
+		if i < len(values) {
+			row[column.Key] = values[i]
+		}
+	}
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	prefix := ","
+	if t.rows == 0 {
+		prefix = "["
+	}
+	t.rows++
+
+	if _, err := io.WriteString(t.w, prefix); err != nil {
+		return err
+	}
+	_, err = t.w.Write(data)
+	return err
+}
+
+func (t *jsonTableWriter) Close() error {
+	closing := "]"
+	if t.rows == 0 {
+		closing = "[]"
+	}
+	_, err := io.WriteString(t.w, closing)
+	return err
+}
+
+// xlsx
+type xlsxTableWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXTableWriter(w io.Writer, columns []ExportColumn) (*xlsxTableWriter, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column.Title
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		return nil, err
+	}
+
+	return &xlsxTableWriter{w: w, file: file, stream: stream, row: 1}, nil
+}
+
+func (t *xlsxTableWriter) WriteRow(values []any) error {
+	t.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+	return t.stream.SetRow(cell, values)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.w)
+}
+
+func formatExportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	crand "crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"regexp"
 	"strconv"
@@ -13,12 +15,17 @@ func RandomUserAvatar(fullname string) string {
 	return fmt.Sprintf("https://api.dicebear.com/6.x/initials/svg?seed=%s", fullname)
 }
 
+// GenerateOTP uses crypto/rand since the codes are used as a second factor
 func GenerateOTP(length int) string {
 	digits := "0123456789"
 	var sb strings.Builder
 
 	for range length {
-		sb.WriteByte(digits[rand.Intn(len(digits))])
+		n, err := crand.Int(crand.Reader, big.NewInt(int64(len(digits))))
+		if err != nil {
+			panic(fmt.Sprintf("crypto/rand failed: %v", err))
+		}
+		sb.WriteByte(digits[n.Int64()])
 	}
 
 	return sb.String()
@@ -26,7 +33,7 @@ func GenerateOTP(length int) string {
 
 func GenerateResetToken() (string, error) {
 	bytes := make([]byte, 32) // 256-bit token
-	if _, err := rand.Read(bytes); err != nil {
+	if _, err := crand.Read(bytes); err != nil {
 		return "", err
 	}
 	return hex.EncodeToString(bytes), nil
@@ -51,3 +58,14 @@ func leftPad(s string, pad string, length int) string {
 	}
 	return s
 }
+
+// PersonalAccessTokenPrefix makes leaked tokens easy to recognize for secret scanners
+const PersonalAccessTokenPrefix = "amspat_"
+
+func GeneratePersonalAccessToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := crand.Read(bytes); err != nil {
+		return "", err
+	}
+	return PersonalAccessTokenPrefix + hex.EncodeToString(bytes), nil
+}
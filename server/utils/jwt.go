@@ -10,23 +10,63 @@ import (
 )
 
 type Claims struct {
-	UserID string `json:"userId"`
+	UserID         string `json:"userId"`
+	OrganizationID string `json:"organizationId"`
+	Role           string `json:"role"`
+	IsAdmin        bool   `json:"isAdmin,omitempty"`
+	ImpersonatorID string `json:"impersonatorId,omitempty"`
+	SessionID      string `json:"sid,omitempty"`
+	EmailVerified  bool   `json:"emailVerified,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateAccessToken(userID string) (string, error) {
-	if userID == "" {
+// RefreshClaims ties a refresh token to its session, ID is rotated on every refresh
+type RefreshClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// AccessTokenSubject describes who an access token is issued for
+type AccessTokenSubject struct {
+	UserID         string
+	OrganizationID string
+	Role           string
+	IsAdmin        bool
+	SessionID      string
+	EmailVerified  bool
+
+	// ImpersonatorID is the administrator acting as the user, such tokens are short lived
+	ImpersonatorID string
+}
+
+func GenerateAccessToken(subject AccessTokenSubject) (string, error) {
+	if subject.UserID == "" {
 		return "", errors.New("userID cannot be empty")
 	}
 
+	if subject.OrganizationID == "" {
+		return "", errors.New("organizationID cannot be empty")
+	}
+
 	if config.AppConfig.AccessTokenSecret == "" {
 		return "", errors.New("access token secret is not configured")
 	}
 
+	ttl := 60 * time.Minute
+	if subject.ImpersonatorID != "" {
+		ttl = 15 * time.Minute
+	}
+
 	claims := Claims{
-		UserID: userID,
+		UserID:         subject.UserID,
+		OrganizationID: subject.OrganizationID,
+		Role:           subject.Role,
+		IsAdmin:        subject.IsAdmin,
+		ImpersonatorID: subject.ImpersonatorID,
+		SessionID:      subject.SessionID,
+		EmailVerified:  subject.EmailVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(60 * time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    config.AppConfig.AppName,
@@ -45,21 +85,32 @@ func GenerateAccessToken(userID string) (string, error) {
 	return tokenString, nil
 }
 
-func GenerateRefreshToken(userID string) (string, error) {
+// RefreshTokenTTL is how long a session stays signed in without being refreshed
+const RefreshTokenTTL = 7 * 24 * time.Hour
+
+func GenerateRefreshToken(userID, sessionID, tokenID string) (string, error) {
 	if userID == "" {
 		return "", errors.New("userID cannot be empty")
 	}
 
+	if sessionID == "" || tokenID == "" {
+		return "", errors.New("sessionID and tokenID cannot be empty")
+	}
+
 	if config.AppConfig.RefreshTokenSecret == "" {
 		return "", errors.New("refresh token secret is not configured")
 	}
 
-	claims := jwt.RegisteredClaims{
-		Subject:   userID,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		NotBefore: jwt.NewNumericDate(time.Now()),
-		Issuer:    config.AppConfig.AppName,
+	claims := RefreshClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    config.AppConfig.AppName,
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -98,12 +149,12 @@ func DecodeAccessToken(tokenString string) (*Claims, error) {
 	return nil, errors.New("invalid token claims")
 }
 
-func DecodeRefreshToken(tokenString string) (string, error) {
+func DecodeRefreshToken(tokenString string) (*RefreshClaims, error) {
 	if tokenString == "" {
-		return "", errors.New("token cannot be empty")
+		return nil, errors.New("token cannot be empty")
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (any, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, func(token *jwt.Token) (any, error) {
 
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -112,18 +163,18 @@ func DecodeRefreshToken(tokenString string) (string, error) {
 	})
 
 	if err != nil {
-		return "", errors.New("failed to parse refresh token: " + err.Error())
+		return nil, errors.New("failed to parse refresh token: " + err.Error())
 	}
-	if claims, ok := token.Claims.(*jwt.RegisteredClaims); ok && token.Valid {
-		return claims.Subject, nil
+	if claims, ok := token.Claims.(*RefreshClaims); ok && token.Valid && claims.SessionID != "" && claims.ID != "" {
+		return claims, nil
 	}
 
-	return "", errors.New("invalid refresh token claims")
+	return nil, errors.New("invalid refresh token claims")
 }
 
 func SetRefreshTokenCookie(c *gin.Context, refreshToken string) {
 	domain := config.AppConfig.CookieDomain
-	c.SetCookie("refreshToken", refreshToken, 7*24*3600, "/", domain, false, false)
+	c.SetCookie("refreshToken", refreshToken, int(RefreshTokenTTL.Seconds()), "/", domain, false, false)
 }
 
 func ClearRefreshTokenCookie(c *gin.Context) {
@@ -154,3 +205,21 @@ func ClearAccessTokenCookie(c *gin.Context) {
 		false,
 	)
 }
+
+// the oauth state cookie ties the callback to the browser that started the redirect
+func SetOAuthStateCookie(c *gin.Context, state string) {
+	domain := config.AppConfig.CookieDomain
+	c.SetCookie("oauthState", state, 600, "/", domain, false, true)
+}
+
+func ClearOAuthStateCookie(c *gin.Context) {
+	c.SetCookie(
+		"oauthState",
+		"",
+		-1,
+		"/",
+		"",
+		false,
+		true,
+	)
+}
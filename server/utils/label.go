@@ -0,0 +1,294 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	LabelSymbologyQR      = "qr"
+	LabelSymbologyCode128 = "code128"
+
+	qrModulePixels      = 8
+	qrQuietZone         = 4
+	code128ModulePixels = 2
+	code128QuietZone    = 10
+	code128BarModules   = 40
+	labelCaptionHeight  = 20
+)
+
+// LabelCode is an encoded QR code or barcode as a grid of modules, 1D codes are
+// stretched to a fixed bar height
+type LabelCode struct {
+	Symbology string
+	code      barcode.Barcode
+}
+
+func EncodeLabel(symbology, content string) (*LabelCode, error) {
+	var code barcode.Barcode
+	var err error
+
+	switch symbology {
+	case LabelSymbologyQR:
+		code, err = qr.Encode(content, qr.M, qr.Auto)
+	case LabelSymbologyCode128:
+		code, err = code128.Encode(content)
+	default:
+		return nil, fmt.Errorf("unsupported label symbology: %s", symbology)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelCode{Symbology: symbology, code: code}, nil
+}
+
+// Size returns the width and height in modules without the quiet zone
+func (l *LabelCode) Size() (int, int) {
+	bounds := l.code.Bounds()
+	if l.Symbology == LabelSymbologyCode128 {
+		return bounds.Dx(), code128BarModules
+	}
+	return bounds.Dx(), bounds.Dy()
+}
+
+func (l *LabelCode) IsDark(x, y int) bool {
+	bounds := l.code.Bounds()
+	if l.Symbology == LabelSymbologyCode128 {
+		y = 0
+	}
+	r, _, _, _ := l.code.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+	return r < 0x8000
+}
+
+func (l *LabelCode) geometry() (modulePixels, quietZone int) {
+	if l.Symbology == LabelSymbologyCode128 {
+		return code128ModulePixels, code128QuietZone
+	}
+	return qrModulePixels, qrQuietZone
+}
+
+// RenderLabelPNG draws the code with its quiet zone and the caption centered underneath
+func RenderLabelPNG(w io.Writer, label *LabelCode, caption string) error {
+	modulePixels, quietZone := label.geometry()
+	cols, rows := label.Size()
+
+	face := basicfont.Face7x13
+	captionWidth := font.MeasureString(face, caption).Ceil()
+
+	width := max((cols+2*quietZone)*modulePixels, captionWidth+2*quietZone*modulePixels)
+	height := (rows + 2*quietZone) * modulePixels
+	if caption != "" {
+		height += labelCaptionHeight
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	offsetX := (width - cols*modulePixels) / 2
+	offsetY := quietZone * modulePixels
+	for y := range rows {
+		for x := range cols {
+			if label.IsDark(x, y) {
+				module := image.Rect(offsetX+x*modulePixels, offsetY+y*modulePixels, offsetX+(x+1)*modulePixels, offsetY+(y+1)*modulePixels)
+				draw.Draw(img, module, image.Black, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	if caption != "" {
+		drawer := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(color.Black),
+			Face: face,
+			Dot:  fixed.P((width-captionWidth)/2, height-labelCaptionHeight/2),
+		}
+		drawer.DrawString(caption)
+	}
+
+	return png.Encode(w, img)
+}
+
+// RenderLabelSVG draws one rect per dark run of modules so the output stays small
+func RenderLabelSVG(w io.Writer, label *LabelCode, caption string) error {
+	_, quietZone := label.geometry()
+	cols, rows := label.Size()
+
+	// 1D codes use narrow modules so the caption fits under the bars
+	unit := 1
+	if label.Symbology == LabelSymbologyQR {
+		unit = 4
+	}
+
+	width := (cols + 2*quietZone) * unit
+	codeHeight := (rows + 2*quietZone) * unit
+	height := codeHeight
+	if caption != "" {
+		height += labelCaptionHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`, width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/>`, width, height)
+
+	// bars of 1D codes are a single row drawn at full height
+	drawnRows, rowHeight := rows, unit
+	if label.Symbology == LabelSymbologyCode128 {
+		drawnRows, rowHeight = 1, rows*unit
+	}
+
+	for y := range drawnRows {
+		for x := 0; x < cols; x++ {
+			if !label.IsDark(x, y) {
+				continue
+			}
+			start := x
+			for x+1 < cols && label.IsDark(x+1, y) {
+				x++
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, (start+quietZone)*unit, quietZone*unit+y*rowHeight, (x-start+1)*unit, rowHeight)
+		}
+	}
+
+	if caption != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="monospace" font-size="14" text-anchor="middle">%s</text>`, width/2, codeHeight+labelCaptionHeight/2+4, html.EscapeString(caption))
+	}
+	b.WriteString(`</svg>`)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// LabelSheetLayout describes a sheet of adhesive labels, all sizes in millimeters
+type LabelSheetLayout struct {
+	PageSize     string
+	Columns      int
+	Rows         int
+	LabelWidth   float64
+	LabelHeight  float64
+	MarginTop    float64
+	MarginLeft   float64
+	ColumnGap    float64
+	RowGap       float64
+	LabelPadding float64
+}
+
+// LabelSheetLayouts are common label stock, a4-3x8 matches 63.5 x 38.1 mm and letter-3x10 matches 2.63 x 1 inch labels
+var LabelSheetLayouts = map[string]LabelSheetLayout{
+	"a4-3x8": {
+		PageSize: "A4", Columns: 3, Rows: 8, LabelWidth: 63.5, LabelHeight: 38.1,
+		MarginTop: 15.15, MarginLeft: 7.2, ColumnGap: 2.5, RowGap: 0, LabelPadding: 3,
+	},
+	"letter-3x10": {
+		PageSize: "Letter", Columns: 3, Rows: 10, LabelWidth: 66.7, LabelHeight: 25.4,
+		MarginTop: 12.7, MarginLeft: 4.8, ColumnGap: 3.2, RowGap: 0, LabelPadding: 2,
+	},
+}
+
+type LabelSheetItem struct {
+	Code  *LabelCode
+	Title string
+	Tag   string
+}
+
+// WriteLabelSheet writes a PDF with one label per item, codes are drawn as vector rectangles
+// so they scan reliably at any print resolution
+func WriteLabelSheet(w io.Writer, layout LabelSheetLayout, items []LabelSheetItem) error {
+	pdf := fpdf.New("P", "mm", layout.PageSize, "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetFillColor(0, 0, 0)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	perPage := layout.Columns * layout.Rows
+	for i, item := range items {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		slot := i % perPage
+		x := layout.MarginLeft + float64(slot%layout.Columns)*(layout.LabelWidth+layout.ColumnGap)
+		y := layout.MarginTop + float64(slot/layout.Columns)*(layout.LabelHeight+layout.RowGap)
+
+		drawSheetLabel(pdf, translate, layout, x, y, item)
+	}
+
+	if len(items) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(w)
+}
+
+func drawSheetLabel(pdf *fpdf.Fpdf, translate func(string) string, layout LabelSheetLayout, x, y float64, item LabelSheetItem) {
+	padding := layout.LabelPadding
+	innerWidth := layout.LabelWidth - 2*padding
+	innerHeight := layout.LabelHeight - 2*padding
+	cols, rows := item.Code.Size()
+
+	if item.Code.Symbology == LabelSymbologyQR {
+		// square code on the left, text on the right
+		module := innerHeight / float64(cols)
+		drawModules(pdf, item.Code, x+padding, y+padding, module, module, cols, rows)
+
+		textX := x + 2*padding + innerHeight
+		textWidth := layout.LabelWidth - innerHeight - 3*padding
+		pdf.SetXY(textX, y+padding)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(textWidth, 5, translate(item.Tag), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.MultiCell(textWidth, 3.5, translate(fitText(pdf, item.Title, textWidth, 3)), "", "L", false)
+		return
+	}
+
+	// bars across the label, text underneath
+	barHeight := innerHeight - 9
+	module := innerWidth / float64(cols)
+	drawModules(pdf, item.Code, x+padding, y+padding, module, barHeight, cols, 1)
+
+	pdf.SetXY(x+padding, y+padding+barHeight+0.5)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(innerWidth, 4, translate(item.Tag), "", 2, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.CellFormat(innerWidth, 3.5, translate(fitText(pdf, item.Title, innerWidth, 1)), "", 0, "C", false, 0, "")
+}
+
+func drawModules(pdf *fpdf.Fpdf, code *LabelCode, x, y, moduleWidth, moduleHeight float64, cols, rows int) {
+	for row := range rows {
+		for col := 0; col < cols; col++ {
+			if !code.IsDark(col, row) {
+				continue
+			}
+			start := col
+			for col+1 < cols && code.IsDark(col+1, row) {
+				col++
+			}
+			pdf.Rect(x+float64(start)*moduleWidth, y+float64(row)*moduleHeight, float64(col-start+1)*moduleWidth, moduleHeight, "F")
+		}
+	}
+}
+
+// fitText shortens text so it fits in the given number of lines of the current font
+func fitText(pdf *fpdf.Fpdf, text string, width float64, lines int) string {
+	limit := width * float64(lines) * 0.9
+	if pdf.GetStringWidth(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > limit {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -19,14 +19,26 @@ type EmailTemplate struct {
 }
 
 type EmailData struct {
-	UserName    string
-	Email       string
-	ResetLink   string
-	OTPCode     string
-	ExpiryTime  string
-	AppName     string
-	SupportURL  string
-	CompanyName string
+	UserName     string
+	Email        string
+	ResetLink    string
+	VerifyLink   string
+	OTPCode      string
+	ExpiryTime   string
+	AppName      string
+	SupportURL   string
+	CompanyName  string
+	DashboardURL string
+	LeadDays     int
+	Assets       []WarrantyReminderItem
+}
+
+type WarrantyReminderItem struct {
+	Name         string
+	SerialNumber string
+	Location     string
+	Warranty     string
+	DaysLeft     int
 }
 
 // Email templates
@@ -137,6 +149,55 @@ var emailTemplates = map[string]EmailTemplate{
 </html>`,
 	},
 
+	"verify_email": {
+		Subject: "Verify Your Email - {{.AppName}}",
+		Template: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Verify Email</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #f8f9fa; padding: 20px; text-align: center; border-radius: 8px; margin-bottom: 30px; }
+        .content { background: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .button { display: inline-block; background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; font-weight: bold; margin: 20px 0; }
+        .footer { margin-top: 30px; padding-top: 20px; border-top: 1px solid #eee; font-size: 14px; color: #666; text-align: center; }
+        .warning { background: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 5px; margin: 20px 0; }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>{{.AppName}}</h1>
+        <p>Email Verification</p>
+    </div>
+    
+    <div class="content">
+        <h2>Hello {{.UserName}},</h2>
+        
+        <p>Thanks for signing up for {{.AppName}}. Please confirm that <strong>{{.Email}}</strong> is your email address:</p>
+        
+        <a href="{{.VerifyLink}}" class="button">Verify Email</a>
+        
+        <div class="warning">
+            <strong>Important:</strong> This link will expire in {{.ExpiryTime}}. If you didn't create an account, please ignore this email.
+        </div>
+        
+        <p>If the button doesn't work, copy and paste this link into your browser:</p>
+        <p style="word-break: break-all; background: #f8f9fa; padding: 10px; border-radius: 5px;">{{.VerifyLink}}</p>
+        
+        <p>Best regards,<br>The {{.CompanyName}} Team</p>
+    </div>
+    
+    <div class="footer">
+        <p>This email was sent to {{.Email}}. If you didn't create an account, please ignore this email.</p>
+        <p>&copy; {{.CompanyName}}. All rights reserved.</p>
+    </div>
+</body>
+</html>`,
+	},
+
 	"welcome": {
 		Subject: "Welcome to {{.AppName}}!",
 		Template: `
@@ -178,6 +239,57 @@ var emailTemplates = map[string]EmailTemplate{
         <p>&copy; {{.CompanyName}}. All rights reserved.</p>
     </div>
 </body>
+</html>`,
+	},
+
+	"warranty_reminder": {
+		Subject: "Warranties expiring soon - {{.AppName}}",
+		Template: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Warranty Reminder</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #f8f9fa; padding: 20px; text-align: center; border-radius: 8px; margin-bottom: 30px; }
+        .content { background: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .button { display: inline-block; background: #007bff; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; font-weight: bold; margin: 20px 0; }
+        table { width: 100%; border-collapse: collapse; margin: 20px 0; }
+        th, td { text-align: left; padding: 8px; border-bottom: 1px solid #eee; font-size: 14px; }
+        th { background: #f8f9fa; }
+        .footer { margin-top: 30px; padding-top: 20px; border-top: 1px solid #eee; font-size: 14px; color: #666; text-align: center; }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>{{.AppName}}</h1>
+        <p>Warranty Reminder</p>
+    </div>
+    
+    <div class="content">
+        <h2>Hello {{.UserName}},</h2>
+        
+        <p>The warranty of the following assets will expire within the next {{.LeadDays}} days:</p>
+        
+        <table>
+            <tr><th>Asset</th><th>Serial Number</th><th>Location</th><th>Warranty Until</th><th>Days Left</th></tr>
+            {{range .Assets}}
+            <tr><td>{{.Name}}</td><td>{{.SerialNumber}}</td><td>{{.Location}}</td><td>{{.Warranty}}</td><td>{{.DaysLeft}}</td></tr>
+            {{end}}
+        </table>
+        
+        <a href="{{.DashboardURL}}" class="button">Open Dashboard</a>
+        
+        <p>Best regards,<br>The {{.CompanyName}} Team</p>
+    </div>
+    
+    <div class="footer">
+        <p>This email was sent to {{.Email}}. You can turn off warranty reminders in your notification settings.</p>
+        <p>&copy; {{.CompanyName}}. All rights reserved.</p>
+    </div>
+</body>
 </html>`,
 	},
 }
@@ -255,6 +367,18 @@ func SendOTPEmail(toEmail, userName, otpCode string, expiryDuration time.Duratio
 	return SendTemplateEmail("otp_verification", toEmail, data)
 }
 
+// SendVerificationEmail sends the email address verification link
+func SendVerificationEmail(toEmail, userName, verifyLink string, expiryDuration time.Duration) error {
+	data := EmailData{
+		UserName:   userName,
+		Email:      toEmail,
+		VerifyLink: verifyLink,
+		ExpiryTime: formatDuration(expiryDuration),
+	}
+
+	return SendTemplateEmail("verify_email", toEmail, data)
+}
+
 // SendWelcomeEmail sends welcome email
 func SendWelcomeEmail(toEmail, userName string) error {
 	data := EmailData{
@@ -265,6 +389,19 @@ func SendWelcomeEmail(toEmail, userName string) error {
 	return SendTemplateEmail("welcome", toEmail, data)
 }
 
+// SendWarrantyReminderEmail sends a digest of assets whose warranty is about to expire
+func SendWarrantyReminderEmail(toEmail, userName, dashboardURL string, leadDays int, assets []WarrantyReminderItem) error {
+	data := EmailData{
+		UserName:     userName,
+		Email:        toEmail,
+		DashboardURL: dashboardURL,
+		LeadDays:     leadDays,
+		Assets:       assets,
+	}
+
+	return SendTemplateEmail("warranty_reminder", toEmail, data)
+}
+
 // LoadTemplatesFromFile loads email templates from external files
 func LoadTemplatesFromFile(templatesDir string) error {
 	if templatesDir == "" {
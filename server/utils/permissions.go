@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"slices"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+)
+
+const (
+	PermissionAssetsRead         = "assets:read"
+	PermissionAssetsWrite        = "assets:write"
+	PermissionAssetsDelete       = "assets:delete"
+	PermissionCatalogWrite       = "catalog:write"
+	PermissionReportsRead        = "reports:read"
+	PermissionAuditRead          = "audit:read"
+	PermissionMembersManage      = "members:manage"
+	PermissionOrganizationManage = "organization:manage"
+	PermissionWebhooksManage     = "webhooks:manage"
+	PermissionStockTakesManage   = "stock-takes:manage"
+	PermissionStockTakesCount    = "stock-takes:count"
+)
+
+// Roles lists the organization roles from most to least privileged
+var Roles = []string{
+	models.OrganizationRoleOwner,
+	models.OrganizationRoleManager,
+	models.OrganizationRoleEditor,
+	models.OrganizationRoleViewer,
+	models.OrganizationRoleAuditor,
+}
+
+var rolePermissions = map[string][]string{
+	models.OrganizationRoleOwner: {
+		PermissionAssetsRead, PermissionAssetsWrite, PermissionAssetsDelete, PermissionCatalogWrite,
+		PermissionReportsRead, PermissionAuditRead, PermissionMembersManage, PermissionOrganizationManage,
+		PermissionWebhooksManage, PermissionStockTakesManage, PermissionStockTakesCount,
+	},
+	models.OrganizationRoleManager: {
+		PermissionAssetsRead, PermissionAssetsWrite, PermissionAssetsDelete, PermissionCatalogWrite,
+		PermissionReportsRead, PermissionAuditRead, PermissionMembersManage, PermissionWebhooksManage,
+		PermissionStockTakesManage, PermissionStockTakesCount,
+	},
+	models.OrganizationRoleEditor: {
+		PermissionAssetsRead, PermissionAssetsWrite, PermissionCatalogWrite, PermissionReportsRead,
+		PermissionStockTakesCount,
+	},
+	models.OrganizationRoleViewer: {
+		PermissionAssetsRead,
+	},
+	models.OrganizationRoleAuditor: {
+		PermissionAssetsRead, PermissionReportsRead, PermissionAuditRead, PermissionStockTakesCount,
+	},
+}
+
+func RolePermissions(role string) []string {
+	return rolePermissions[role]
+}
+
+func HasPermission(role, permission string) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// ScopeReadOnly is a personal access token scope that stands for every read permission of the role
+const ScopeReadOnly = "read-only"
+
+var ReadPermissions = []string{PermissionAssetsRead, PermissionReportsRead, PermissionAuditRead}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartDailyJob runs job once a day at the given hour (server local time).
+// It runs in its own goroutine and a panicking job does not stop later runs.
+func StartDailyJob(name string, hour int, job func(now time.Time) error) {
+	go func() {
+		for {
+			next := nextDailyRun(time.Now(), hour)
+			time.Sleep(time.Until(next))
+			runJob(name, job)
+		}
+	}()
+}
+
+func runJob(name string, job func(now time.Time) error) {
+	started := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			GetLogger().Error("scheduled job panicked", zap.String("job", name), zap.Any("panic", r))
+		}
+	}()
+
+	if err := job(started); err != nil {
+		GetLogger().Error("scheduled job failed", zap.String("job", name), zap.Error(err))
+		return
+	}
+
+	GetLogger().Info("scheduled job finished", zap.String("job", name), zap.Duration("took", time.Since(started)))
+}
+
+func nextDailyRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/config"
+)
+
+// Storage keeps uploaded files and returns the URL they can be fetched from
+type Storage interface {
+	Upload(file io.Reader, opts UploadOptions) (string, error)
+	Delete(url string) error
+}
+
+// FileServer is implemented by drivers whose files are served by the API itself
+type FileServer interface {
+	Open(key string) (*os.File, error)
+}
+
+type UploadOptions struct {
+	FileName string
+	MimeType string
+	// Image lets the driver resize and convert the file when it supports it
+	Image bool
+}
+
+var storage Storage
+
+// InitStorage selects the storage driver from config.AppConfig.StorageDriver
+func InitStorage() {
+	switch config.AppConfig.StorageDriver {
+	case "local":
+		local, err := NewLocalStorage(config.AppConfig.LocalStoragePath, config.AppConfig.LocalStorageURL)
+		if err != nil {
+			panic("Failed to initialize local storage: " + err.Error())
+		}
+		storage = local
+	case "cloudinary":
+		storage = NewCloudinaryStorage(config.Cloud, config.AppConfig.CloudFolder)
+	default:
+		panic(fmt.Sprintf("Unknown storage driver: %s", config.AppConfig.StorageDriver))
+	}
+
+	fmt.Println("✅ Storage configured:", config.AppConfig.StorageDriver)
+}
+
+func GetStorage() Storage {
+	return storage
+}
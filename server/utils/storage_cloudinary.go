@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+type cloudinaryStorage struct {
+	cloud  *cloudinary.Cloudinary
+	folder string
+}
+
+func NewCloudinaryStorage(cloud *cloudinary.Cloudinary, folder string) Storage {
+	return &cloudinaryStorage{cloud: cloud, folder: folder}
+}
+
+func (s *cloudinaryStorage) Upload(file io.Reader, opts UploadOptions) (string, error) {
+	params := uploader.UploadParams{Folder: s.folder}
+	if opts.Image {
+		params.Transformation = "w_500,h_500,c_limit,f_webp"
+	}
+
+	uploadResult, err := s.cloud.Upload.Upload(context.Background(), file, params)
+	if err != nil {
+		log.Printf("failed to upload file to Cloudinary %v :", err)
+		return "", err
+	}
+
+	return uploadResult.SecureURL, nil
+}
+
+func (s *cloudinaryStorage) Delete(fileURL string) error {
+	publicID, err := extractPublicID(fileURL)
+	if err != nil {
+		return err
+	}
+
+	deleteResult, err := s.cloud.Upload.Destroy(context.Background(), uploader.DestroyParams{
+		PublicID: s.folder + "/" + publicID,
+	})
+	if err != nil {
+		log.Printf("failed to delete file from Cloudinary: %v", err)
+		return errors.New("failed to delete asset from Cloudinary")
+	}
+
+	if deleteResult.Result != "ok" {
+		return errors.New("failed to delete asset from Cloudinary: not found")
+	}
+
+	return nil
+}
+
+func extractPublicID(imageURL string) (string, error) {
+	parts := strings.Split(imageURL, "/")
+	if len(parts) == 0 {
+		return "", errors.New("invalid image URL")
+	}
+
+	fileName := parts[len(parts)-1]
+
+	publicID := strings.Split(fileName, ".")[0]
+	if publicID == "" {
+		return "", errors.New("failed to extract public_id from image URL")
+	}
+
+	return publicID, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidFileKey = errors.New("invalid file key")
+
+// localStorage writes files to a directory on disk, they are served back through the files route
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStorage{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+func (s *localStorage) Upload(file io.Reader, opts UploadOptions) (string, error) {
+	key := uuid.NewString() + fileExtension(opts)
+
+	out, err := os.OpenFile(filepath.Join(s.dir, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, file); err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *localStorage) Delete(fileURL string) error {
+	key := path.Base(fileURL)
+	if !isValidFileKey(key) {
+		return ErrInvalidFileKey
+	}
+	return os.Remove(filepath.Join(s.dir, key))
+}
+
+func (s *localStorage) Open(key string) (*os.File, error) {
+	if !isValidFileKey(key) {
+		return nil, ErrInvalidFileKey
+	}
+	return os.Open(filepath.Join(s.dir, key))
+}
+
+// keys are generated by Upload, anything that could leave the directory is rejected
+func isValidFileKey(key string) bool {
+	return key != "" && key != "." && key != ".." &&
+		!strings.ContainsAny(key, `/\`) && !strings.Contains(key, "..")
+}
+
+func fileExtension(opts UploadOptions) string {
+	if ext := filepath.Ext(opts.FileName); ext != "" && isValidFileKey(ext) {
+		return strings.ToLower(ext)
+	}
+	if opts.MimeType != "" {
+		if exts, err := mime.ExtensionsByType(opts.MimeType); err == nil && len(exts) > 0 {
+			return exts[0]
+		}
+	}
+	return ""
+}
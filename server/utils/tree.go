@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/fiqrioemry/asset_management_system_app/server/models"
+
+	"github.com/google/uuid"
+)
+
+// TreePathSeparator joins the names of a breadcrumb
+const TreePathSeparator = " > "
+
+type (
+	CategoryTree = Tree[models.Category]
+	LocationTree = Tree[models.Location]
+)
+
+// Tree indexes a flat list of parent/child records by id and parent. Records whose parent isn't in
+// the list are treated as roots, walks are bounded by the list size so a corrupt cycle can't hang.
+type Tree[T any] struct {
+	node     func(*T) treeNode
+	byID     map[uuid.UUID]*T
+	children map[uuid.UUID][]*T
+	roots    []*T
+}
+
+// treeNode is the part of a record the tree needs
+type treeNode struct {
+	ID        uuid.UUID
+	ParentID  *uuid.UUID
+	Name      string
+	IsDefault bool
+}
+
+func NewCategoryTree(categories []models.Category) *CategoryTree {
+	return newTree(categories, func(c *models.Category) treeNode {
+		return treeNode{ID: c.ID, ParentID: c.ParentID, Name: c.Name, IsDefault: c.IsDefault}
+	})
+}
+
+func NewLocationTree(locations []models.Location) *LocationTree {
+	return newTree(locations, func(l *models.Location) treeNode {
+		return treeNode{ID: l.ID, ParentID: l.ParentID, Name: l.Name, IsDefault: l.IsDefault}
+	})
+}
+
+func newTree[T any](items []T, node func(*T) treeNode) *Tree[T] {
+	tree := &Tree[T]{
+		node:     node,
+		byID:     make(map[uuid.UUID]*T, len(items)),
+		children: make(map[uuid.UUID][]*T),
+	}
+	for i := range items {
+		tree.byID[node(&items[i]).ID] = &items[i]
+	}
+	for i := range items {
+		item := &items[i]
+		if parentID := node(item).ParentID; parentID != nil && tree.byID[*parentID] != nil {
+			tree.children[*parentID] = append(tree.children[*parentID], item)
+		} else {
+			tree.roots = append(tree.roots, item)
+		}
+	}
+
+	// system defaults first, then by name, at every level
+	tree.sort(tree.roots)
+	for _, children := range tree.children {
+		tree.sort(children)
+	}
+	return tree
+}
+
+func (t *Tree[T]) Get(id uuid.UUID) *T {
+	return t.byID[id]
+}
+
+func (t *Tree[T]) Roots() []*T {
+	return t.roots
+}
+
+func (t *Tree[T]) Children(id uuid.UUID) []*T {
+	return t.children[id]
+}
+
+// DepthFirst returns every record with each one followed by its subtree, the order of a flat listing
+func (t *Tree[T]) DepthFirst() []*T {
+	ordered := make([]*T, 0, len(t.byID))
+	seen := make(map[uuid.UUID]bool, len(t.byID))
+	var walk func(items []*T)
+	walk = func(items []*T) {
+		for _, item := range items {
+			id := t.node(item).ID
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			ordered = append(ordered, item)
+			walk(t.children[id])
+		}
+	}
+	walk(t.roots)
+	return ordered
+}
+
+// Ancestry returns the record followed by its parents up to the root
+func (t *Tree[T]) Ancestry(id uuid.UUID) []uuid.UUID {
+	var chain []uuid.UUID
+	current := t.byID[id]
+	for current != nil && len(chain) < len(t.byID) {
+		node := t.node(current)
+		chain = append(chain, node.ID)
+		if node.ParentID == nil {
+			break
+		}
+		current = t.byID[*node.ParentID]
+	}
+	return chain
+}
+
+// Descendants returns every record below the given one, closest first
+func (t *Tree[T]) Descendants(id uuid.UUID) []uuid.UUID {
+	var descendants []uuid.UUID
+	seen := map[uuid.UUID]bool{id: true}
+	queue := t.children[id]
+	for len(queue) > 0 {
+		itemID := t.node(queue[0]).ID
+		queue = queue[1:]
+		if seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+		descendants = append(descendants, itemID)
+		queue = append(queue, t.children[itemID]...)
+	}
+	return descendants
+}
+
+// Level is the depth of the record, 0 for a root
+func (t *Tree[T]) Level(id uuid.UUID) int {
+	return max(len(t.Ancestry(id))-1, 0)
+}
+
+// LevelUnder is the depth a record placed under parentID has, 0 without a parent
+func (t *Tree[T]) LevelUnder(parentID *uuid.UUID) int {
+	if parentID == nil {
+		return 0
+	}
+	return t.Level(*parentID) + 1
+}
+
+// Path is the breadcrumb of the record, e.g. "Technology > Computers > Laptops"
+func (t *Tree[T]) Path(id uuid.UUID) string {
+	chain := t.Ancestry(id)
+	names := make([]string, len(chain))
+	for i, ancestorID := range chain {
+		names[len(chain)-1-i] = t.node(t.byID[ancestorID]).Name
+	}
+	return strings.Join(names, TreePathSeparator)
+}
+
+// IsDescendant reports whether candidate is the record itself or somewhere below it,
+// such a candidate can't become the record's parent
+func (t *Tree[T]) IsDescendant(candidate, id uuid.UUID) bool {
+	return slices.Contains(t.Ancestry(candidate), id)
+}
+
+func (t *Tree[T]) sort(items []*T) {
+	slices.SortStableFunc(items, func(a, b *T) int {
+		na, nb := t.node(a), t.node(b)
+		if na.IsDefault != nb.IsDefault {
+			if na.IsDefault {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(strings.ToLower(na.Name), strings.ToLower(nb.Name))
+	})
+}
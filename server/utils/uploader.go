@@ -1,83 +1,22 @@
 package utils
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
-
-	"github.com/fiqrioemry/asset_management_system_app/server/config"
-
-	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 )
 
 const MaxFileSize = 2 * 1024 * 1024
 
-var AllowedImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
-
-func UploadToCloudinary(file io.Reader) (string, error) {
-	ctx := context.Background()
-
-	folder := config.AppConfig.CloudFolder
-
-	uploadResult, err := config.Cloud.Upload.Upload(ctx, file, uploader.UploadParams{
-		Folder:         folder,
-		Transformation: "w_500,h_500,c_limit,f_webp",
-	})
+// MaxDocumentSize applies to PDF attachments such as receipts and manuals
+const MaxDocumentSize = 10 * 1024 * 1024
 
-	if err != nil {
-		log.Printf("failed to upload file to Cloudinary %v :", err)
-		return "", err
-	}
-
-	return uploadResult.SecureURL, nil
-}
-
-func DeleteFromCloudinary(imageURL string) error {
-	ctx := context.Background()
-
-	publicID, err := extractPublicID(imageURL)
-	if err != nil {
-		return err
-	}
-
-	deleteResult, err := config.Cloud.Upload.Destroy(ctx, uploader.DestroyParams{
-		PublicID: config.AppConfig.CloudFolder + "/" + publicID,
-	})
-	if err != nil {
-		log.Printf("failed to delete file from Cloudinary: %v", err)
-		return errors.New("failed to delete asset from Cloudinary")
-	}
-
-	if deleteResult.Result != "ok" {
-		return errors.New("failed to delete asset from Cloudinary: not found")
-	}
-
-	return nil
-}
-
-func extractPublicID(imageURL string) (string, error) {
-	parts := strings.Split(imageURL, "/")
-	if len(parts) == 0 {
-		return "", errors.New("invalid image URL")
-	}
-
-	fileName := parts[len(parts)-1]
-
-	publicID := strings.Split(fileName, ".")[0]
-	if publicID == "" {
-		return "", errors.New("failed to extract public_id from image URL")
-	}
+var AllowedImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
 
-	return publicID, nil
-}
+var AllowedDocumentTypes = []string{"application/pdf"}
 
 func ValidateImageFile(fileHeader *multipart.FileHeader) error {
 	if fileHeader.Size > MaxFileSize {
@@ -141,12 +80,69 @@ func UploadImageWithValidation(fileHeader *multipart.FileHeader) (string, error)
 		return "", err
 	}
 
-	return UploadToCloudinary(file)
+	return GetStorage().Upload(file, UploadOptions{FileName: fileHeader.Filename, MimeType: mimeType, Image: true})
+}
+
+// UploadAttachmentWithValidation accepts images and PDF documents and returns the stored URL with the detected mime type
+func UploadAttachmentWithValidation(fileHeader *multipart.FileHeader) (string, string, error) {
+	if fileHeader == nil {
+		return "", "", errors.New("no file provided")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, 512)
+	n, err := file.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "", "", err
+	}
+
+	mimeType := http.DetectContentType(buffer[:n])
+	isImage := isAllowedImageType(mimeType)
+	isDocument := isAllowedDocumentType(mimeType)
+
+	switch {
+	case isImage && fileHeader.Size > MaxFileSize:
+		return "", "", errors.New("image size is too large, maximum 2MB")
+	case isDocument && fileHeader.Size > MaxDocumentSize:
+		return "", "", errors.New("document size is too large, maximum 10MB")
+	case !isImage && !isDocument:
+		return "", "", fmt.Errorf("invalid file format: %s. Only JPG, PNG, GIF, WEBP and PDF are allowed", mimeType)
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		return "", "", err
+	}
+
+	url, err := GetStorage().Upload(file, UploadOptions{FileName: fileHeader.Filename, MimeType: mimeType, Image: isImage})
+	if err != nil {
+		return "", "", err
+	}
+
+	return url, mimeType, nil
+}
+
+func isAllowedDocumentType(fileType string) bool {
+	for _, allowedType := range AllowedDocumentTypes {
+		if strings.EqualFold(fileType, allowedType) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsImageType reports whether the mime type is one of the accepted image formats
+func IsImageType(mimeType string) bool {
+	return isAllowedImageType(mimeType)
 }
 
 func CleanupImageOnError(imageURL string) {
 	if imageURL != "" {
-		_ = DeleteFromCloudinary(imageURL)
+		_ = GetStorage().Delete(imageURL)
 	}
 }
 
@@ -168,7 +164,7 @@ func UploadMultipleImagesWithValidation(fileHeaders []*multipart.FileHeader) ([]
 		}
 		defer file.Close()
 
-		imageURL, err := UploadToCloudinary(file)
+		imageURL, err := GetStorage().Upload(file, UploadOptions{FileName: fileHeader.Filename, Image: true})
 		if err != nil {
 			return nil, err
 		}
@@ -182,36 +178,7 @@ func UploadMultipleImagesWithValidation(fileHeaders []*multipart.FileHeader) ([]
 func CleanupImagesOnError(imageURLs []string) {
 	for _, url := range imageURLs {
 		if url != "" {
-			_ = DeleteFromCloudinary(url)
+			_ = GetStorage().Delete(url)
 		}
 	}
 }
-
-func UploadToLocal(file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	uploadPath := "./uploads/"
-	err := os.MkdirAll(uploadPath, os.ModePerm)
-	if err != nil {
-		return "", err
-	}
-
-	filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), fileHeader.Filename)
-	filePath := filepath.Join(uploadPath, filename)
-
-	out, err := os.Create(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer out.Close()
-
-	_, err = file.Seek(0, 0)
-	if err != nil {
-		return "", err
-	}
-
-	_, err = out.ReadFrom(file)
-	if err != nil {
-		return "", err
-	}
-
-	return filePath, nil
-}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookTimeout         = 10 * time.Second
+	webhookMaxResponseBody = 1000
+	webhookRetryBaseDelay  = 30 * time.Second
+	webhookRetryMaxDelay   = 6 * time.Hour
+)
+
+// webhookBlockedNetworks are special-purpose ranges that are not publicly routable but aren't
+// covered by the net.IP private, loopback and link-local checks
+var webhookBlockedNetworks = parseCIDRs(
+	"0.0.0.0/8",       // "this" network
+	"100.64.0.0/10",   // carrier-grade NAT
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved, including broadcast
+	"64:ff9b::/96",    // NAT64, can reach internal IPv4 addresses
+	"64:ff9b:1::/48",  // local-use NAT64
+	"100::/64",        // discard-only
+	"2001:db8::/32",   // documentation
+	"2002::/16",       // 6to4, can embed internal IPv4 addresses
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isPublicWebhookIP reports whether a webhook may be delivered to ip
+func isPublicWebhookIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range webhookBlockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookRequest is a single delivery attempt
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	Event      string
+	EventID    string
+	DeliveryID string
+	Payload    []byte
+}
+
+type WebhookResult struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+}
+
+// WebhookSender posts signed payloads, the HTTP client is injectable so deliveries can be
+// pointed at an httptest server
+type WebhookSender struct {
+	client    *http.Client
+	userAgent string
+	now       func() time.Time
+}
+
+func NewWebhookSender(client *http.Client, userAgent string) *WebhookSender {
+	return &WebhookSender{client: client, userAgent: userAgent, now: time.Now}
+}
+
+// NewWebhookHTTPClient refuses to connect to loopback, private, link-local and other unroutable
+// addresses unless allowPrivate is set, so webhooks can't be used to reach internal services
+func NewWebhookHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicWebhookIP(net.ParseIP(host)) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		// a redirect could point anywhere, receivers must answer on the registered URL
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// SignWebhookPayload is the hex HMAC-SHA256 of "timestamp.payload", receivers recompute it
+// with their secret and should reject old timestamps
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send returns an error for transport failures and non 2xx responses, the result is filled
+// whenever the receiver answered
+func (s *WebhookSender) Send(ctx context.Context, req WebhookRequest) (*WebhookResult, error) {
+	timestamp := s.now().Unix()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", s.userAgent)
+	httpReq.Header.Set("X-Webhook-Event", req.Event)
+	httpReq.Header.Set("X-Webhook-ID", req.EventID)
+	httpReq.Header.Set("X-Webhook-Delivery", req.DeliveryID)
+	httpReq.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(req.Secret, timestamp, req.Payload))
+
+	started := time.Now()
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	result := &WebhookResult{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(started),
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, errors.New("receiver responded with " + resp.Status)
+	}
+
+	return result, nil
+}
+
+// WebhookRetryDelay is the wait before the next attempt, doubling from 30 seconds
+func WebhookRetryDelay(attempts int) time.Duration {
+	// compared as float so a large attempt count can't overflow the duration
+	delay := float64(webhookRetryBaseDelay) * math.Pow(2, float64(attempts-1))
+	if delay >= float64(webhookRetryMaxDelay) {
+		return webhookRetryMaxDelay
+	}
+	return time.Duration(delay)
+}
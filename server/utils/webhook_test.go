@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestWebhookSender(now time.Time) *WebhookSender {
+	sender := NewWebhookSender(NewWebhookHTTPClient(true), "Test Webhooks")
+	sender.now = func() time.Time { return now }
+	return sender
+}
+
+func TestWebhookSenderSignsPayload(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"event":"asset.created"}`)
+
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	result, err := newTestWebhookSender(now).Send(context.Background(), WebhookRequest{
+		URL:        server.URL,
+		Secret:     "whsec_test",
+		Event:      "asset.created",
+		EventID:    "event-1",
+		DeliveryID: "delivery-1",
+		Payload:    payload,
+	})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Fatalf("status code = %d, want %d", result.StatusCode, http.StatusNoContent)
+	}
+
+	if string(body) != string(payload) {
+		t.Errorf("body = %q, want %q", body, payload)
+	}
+	if got, want := header.Get("X-Webhook-Timestamp"), strconv.FormatInt(now.Unix(), 10); got != want {
+		t.Errorf("X-Webhook-Timestamp = %q, want %q", got, want)
+	}
+	if got, want := header.Get("X-Webhook-Signature"), "sha256="+SignWebhookPayload("whsec_test", now.Unix(), payload); got != want {
+		t.Errorf("X-Webhook-Signature = %q, want %q", got, want)
+	}
+	if got := header.Get("X-Webhook-Event"); got != "asset.created" {
+		t.Errorf("X-Webhook-Event = %q, want %q", got, "asset.created")
+	}
+	if got := header.Get("X-Webhook-Delivery"); got != "delivery-1" {
+		t.Errorf("X-Webhook-Delivery = %q, want %q", got, "delivery-1")
+	}
+}
+
+func TestWebhookSenderResponseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"ok", http.StatusOK, false},
+		{"accepted", http.StatusAccepted, false},
+		{"redirect", http.StatusFound, true},
+		{"client error", http.StatusGone, true},
+		{"server error", http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, "receiver body")
+			}))
+			defer server.Close()
+
+			result, err := newTestWebhookSender(time.Now()).Send(context.Background(), WebhookRequest{
+				URL:     server.URL,
+				Secret:  "whsec_test",
+				Payload: []byte(`{}`),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result == nil {
+				t.Fatal("result is nil although the receiver answered")
+			}
+			if result.StatusCode != tt.status {
+				t.Errorf("status code = %d, want %d", result.StatusCode, tt.status)
+			}
+			if result.Body != "receiver body" {
+				t.Errorf("body = %q, want %q", result.Body, "receiver body")
+			}
+		})
+	}
+}
+
+func TestWebhookSenderRejectsPrivateTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("private target was contacted")
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(NewWebhookHTTPClient(false), "Test Webhooks")
+	result, err := sender.Send(context.Background(), WebhookRequest{URL: server.URL, Payload: []byte(`{}`)})
+	if err == nil {
+		t.Fatal("Send to a loopback address succeeded, want error")
+	}
+	if result != nil {
+		t.Errorf("result = %+v, want nil on transport failure", result)
+	}
+}
+
+func TestIsPublicWebhookIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"0.1.2.3", false},
+		{"198.18.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:10.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+		{"2002:a00:1::1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicWebhookIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("isPublicWebhookIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, webhookRetryMaxDelay},
+		{64, webhookRetryMaxDelay},
+		{1000, webhookRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := WebhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("WebhookRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}